@@ -0,0 +1,260 @@
+package chaos
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+
+	"github.com/docker/docker/api/types/container"
+
+	"github.com/testcontainers/testcontainers-go"
+	tcexec "github.com/testcontainers/testcontainers-go/exec"
+)
+
+// chain is the iptables chain holding the rules that reset the connections of the container.
+const chain = "TESTCONTAINERS_CHAOS"
+
+// allInterfaces is the shell expression listing all the network interfaces
+// of the container, except the loopback one.
+const allInterfaces = `$(ip -o link show | awk -F': ' '{print $2}' | cut -d@ -f1 | grep -v '^lo$')`
+
+var (
+	// injectorsMx protects the map of the injectors, by container ID. The injectors are locked
+	// on their own, so starting a helper container does not block the calls for other containers.
+	injectorsMx sync.Mutex
+	injectors   = map[string]*injector{}
+)
+
+// injector is the helper container injecting faults into the traffic of a container,
+// sharing its network namespace.
+type injector struct {
+	mtx        sync.Mutex               // serializes the calls for the container
+	helper     testcontainers.Container // nil until the helper container is started
+	interfaces []string
+	removed    bool // true once removed from the injectors
+}
+
+// lockInjector returns the locked injector of the container with the given ID, creating it if create is true.
+// It returns nil if the container has no injector and create is false.
+func lockInjector(id string, create bool) *injector {
+	for {
+		injectorsMx.Lock()
+		inj, ok := injectors[id]
+		if !ok && create {
+			inj = &injector{}
+			injectors[id] = inj
+		}
+		injectorsMx.Unlock()
+
+		if inj == nil {
+			return nil
+		}
+
+		inj.mtx.Lock()
+		if !inj.removed {
+			return inj
+		}
+
+		// the injector was removed while waiting for it
+		inj.mtx.Unlock()
+	}
+}
+
+// Apply injects the faults into the traffic of the container, replacing the ones previously
+// injected, if any. The faults are injected by a helper container which joins the network
+// namespace of the container and runs tc with the netem queueing discipline and iptables,
+// so the container itself does not need any extra tool nor capability.
+//
+// The latency, jitter, packet loss and bandwidth limit apply to the packets sent by the container,
+// to any destination, so they also delay the responses sent to the clients of the container.
+// Calling Apply without faults removes the injected ones, but keeps the helper container running.
+func Apply(ctx context.Context, c testcontainers.Container, opts ...Option) error {
+	o, err := newOptions(opts...)
+	if err != nil {
+		return err
+	}
+
+	inj := lockInjector(c.GetContainerID(), true)
+	defer inj.mtx.Unlock()
+
+	if inj.helper == nil {
+		inj.helper, err = startHelper(ctx, c.GetContainerID(), o.image)
+		if err != nil {
+			return err
+		}
+	}
+
+	// the faults are removed from the interfaces used previously, in case they changed.
+	script := removeScript(inj.interfaces) + applyScript(o)
+	if err := inj.run(ctx, script); err != nil {
+		return fmt.Errorf("apply faults: %w", err)
+	}
+
+	inj.interfaces = o.interfaces
+
+	return nil
+}
+
+// Remove removes the faults injected into the traffic of the container, restoring its
+// normal behavior, and terminates the helper container. It does nothing if no faults were
+// injected into the container.
+func Remove(ctx context.Context, c testcontainers.Container) error {
+	id := c.GetContainerID()
+
+	inj := lockInjector(id, false)
+	if inj == nil {
+		return nil
+	}
+	defer inj.mtx.Unlock()
+
+	injectorsMx.Lock()
+	delete(injectors, id)
+	injectorsMx.Unlock()
+
+	inj.removed = true
+
+	// the helper container failed to start
+	if inj.helper == nil {
+		return nil
+	}
+
+	var errs []error
+	if err := inj.run(ctx, removeScript(inj.interfaces)); err != nil {
+		errs = append(errs, fmt.Errorf("remove faults: %w", err))
+	}
+
+	if err := inj.helper.Terminate(ctx); err != nil {
+		errs = append(errs, fmt.Errorf("terminate helper container: %w", err))
+	}
+
+	return errors.Join(errs...)
+}
+
+// WithFaults injects the faults into the traffic of the container once it's ready, so the
+// wait strategy is not affected by them, and removes them before the container is stopped or
+// terminated. The faults are injected again if the container is started again.
+// The faults can be changed at runtime calling [Apply] and [Remove].
+func WithFaults(opts ...Option) testcontainers.CustomizeRequestOption {
+	return func(req *testcontainers.GenericContainerRequest) error {
+		// fail fast on invalid faults
+		if _, err := newOptions(opts...); err != nil {
+			return err
+		}
+
+		remove := func(ctx context.Context, c testcontainers.Container) error {
+			return Remove(ctx, c)
+		}
+
+		req.LifecycleHooks = append(req.LifecycleHooks, testcontainers.ContainerLifecycleHooks{
+			PostReadies: []testcontainers.ContainerHook{
+				func(ctx context.Context, c testcontainers.Container) error {
+					return Apply(ctx, c, opts...)
+				},
+			},
+			PreStops:      []testcontainers.ContainerHook{remove},
+			PreTerminates: []testcontainers.ContainerHook{remove},
+		})
+
+		return nil
+	}
+}
+
+// startHelper starts a helper container in the network namespace of the container with the given ID.
+func startHelper(ctx context.Context, containerID string, image string) (testcontainers.Container, error) {
+	helper, err := testcontainers.GenericContainer(ctx, testcontainers.GenericContainerRequest{
+		ContainerRequest: testcontainers.ContainerRequest{
+			Image:      image,
+			Entrypoint: []string{"tail", "-f", "/dev/null"},
+			HostConfigModifier: func(hc *container.HostConfig) {
+				hc.NetworkMode = container.NetworkMode("container:" + containerID)
+				hc.CapAdd = []string{"NET_ADMIN"}
+			},
+		},
+		Started: true,
+	})
+	if err != nil {
+		return nil, errors.Join(fmt.Errorf("start helper container: %w", err), testcontainers.TerminateContainer(helper))
+	}
+
+	return helper, nil
+}
+
+// run runs the shell script in the helper container.
+func (inj *injector) run(ctx context.Context, script string) error {
+	code, r, err := inj.helper.Exec(ctx, []string{"sh", "-c", script}, tcexec.Multiplexed())
+	if err != nil {
+		return err
+	}
+
+	if code != 0 {
+		output, _ := io.ReadAll(r)
+		return fmt.Errorf("exit code %d: %s", code, strings.TrimSpace(string(output)))
+	}
+
+	return nil
+}
+
+// interfacesExpr returns the shell expression listing the network interfaces.
+func interfacesExpr(interfaces []string) string {
+	if len(interfaces) == 0 {
+		return allInterfaces
+	}
+
+	return strings.Join(interfaces, " ")
+}
+
+// applyScript returns the shell script injecting the faults, which expects no faults to be injected.
+func applyScript(o *options) string {
+	var netem []string
+	if o.latency > 0 {
+		netem = append(netem, "delay", fmt.Sprintf("%dus", o.latency.Microseconds()))
+		if o.jitter > 0 {
+			netem = append(netem, fmt.Sprintf("%dus", o.jitter.Microseconds()))
+		}
+	}
+
+	if o.packetLoss > 0 {
+		netem = append(netem, "loss", fmt.Sprintf("%g%%", o.packetLoss))
+	}
+
+	if o.bandwidth != "" {
+		netem = append(netem, "rate", o.bandwidth)
+	}
+
+	var sb strings.Builder
+	sb.WriteString("set -e\n")
+
+	if len(netem) > 0 {
+		fmt.Fprintf(&sb, "for dev in %s; do tc qdisc add dev \"$dev\" root netem %s; done\n", interfacesExpr(o.interfaces), strings.Join(netem, " "))
+	}
+
+	if o.resets {
+		fmt.Fprintf(&sb, "iptables -N %s\n", chain)
+		fmt.Fprintf(&sb, "iptables -I INPUT -j %s\n", chain)
+
+		if len(o.resetPorts) == 0 {
+			fmt.Fprintf(&sb, "iptables -A %s -p tcp -j REJECT --reject-with tcp-reset\n", chain)
+		}
+
+		for _, port := range o.resetPorts {
+			fmt.Fprintf(&sb, "iptables -A %s -p tcp --dport %s -j REJECT --reject-with tcp-reset\n", chain, port)
+		}
+	}
+
+	return sb.String()
+}
+
+// removeScript returns the shell script removing the injected faults, if any.
+func removeScript(interfaces []string) string {
+	var sb strings.Builder
+
+	fmt.Fprintf(&sb, "for dev in %s; do tc qdisc del dev \"$dev\" root 2>/dev/null || true; done\n", interfacesExpr(interfaces))
+	fmt.Fprintf(&sb, "iptables -D INPUT -j %s 2>/dev/null || true\n", chain)
+	fmt.Fprintf(&sb, "iptables -F %s 2>/dev/null || true\n", chain)
+	fmt.Fprintf(&sb, "iptables -X %s 2>/dev/null || true\n", chain)
+
+	return sb.String()
+}
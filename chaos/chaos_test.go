@@ -0,0 +1,230 @@
+package chaos
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+func TestNewOptions(t *testing.T) {
+	o, err := newOptions()
+	require.NoError(t, err)
+	require.Equal(t, DefaultImage, o.image)
+
+	o, err = newOptions(
+		WithImage("my-netshoot:latest"),
+		WithInterfaces("eth0"),
+		WithLatency(100*time.Millisecond),
+		WithJitter(10*time.Millisecond),
+		WithPacketLoss(12.5),
+		WithBandwidth("1mbit"),
+		WithConnectionResets("5432/tcp", "6379"),
+	)
+	require.NoError(t, err)
+	require.Equal(t, &options{
+		image:      "my-netshoot:latest",
+		interfaces: []string{"eth0"},
+		latency:    100 * time.Millisecond,
+		jitter:     10 * time.Millisecond,
+		packetLoss: 12.5,
+		bandwidth:  "1mbit",
+		resets:     true,
+		resetPorts: []string{"5432", "6379"},
+	}, o)
+
+	t.Run("invalid", func(t *testing.T) {
+		for name, opt := range map[string]Option{
+			"negative-latency":   WithLatency(-time.Second),
+			"negative-jitter":    WithJitter(-time.Second),
+			"packet-loss":        WithPacketLoss(101),
+			"empty-bandwidth":    WithBandwidth(""),
+			"injected-bandwidth": WithBandwidth("1mbit; reboot"),
+			"udp-reset":          WithConnectionResets("53/udp"),
+			"invalid-port":       WithConnectionResets("70000"),
+			"injected-interface": WithInterfaces("eth0;reboot"),
+			"jitter-no-latency":  WithJitter(time.Millisecond),
+		} {
+			t.Run(name, func(t *testing.T) {
+				_, err := newOptions(opt)
+				require.Error(t, err)
+			})
+		}
+	})
+}
+
+func TestApplyScript(t *testing.T) {
+	o, err := newOptions(
+		WithInterfaces("eth0", "eth1"),
+		WithLatency(100*time.Millisecond),
+		WithJitter(1500*time.Microsecond),
+		WithPacketLoss(5),
+		WithBandwidth("1mbit"),
+		WithConnectionResets("5432"),
+	)
+	require.NoError(t, err)
+
+	require.Equal(t, `set -e
+for dev in eth0 eth1; do tc qdisc add dev "$dev" root netem delay 100000us 1500us loss 5% rate 1mbit; done
+iptables -N TESTCONTAINERS_CHAOS
+iptables -I INPUT -j TESTCONTAINERS_CHAOS
+iptables -A TESTCONTAINERS_CHAOS -p tcp --dport 5432 -j REJECT --reject-with tcp-reset
+`, applyScript(o))
+
+	t.Run("all-connections", func(t *testing.T) {
+		o, err := newOptions(WithConnectionResets())
+		require.NoError(t, err)
+
+		require.Equal(t, `set -e
+iptables -N TESTCONTAINERS_CHAOS
+iptables -I INPUT -j TESTCONTAINERS_CHAOS
+iptables -A TESTCONTAINERS_CHAOS -p tcp -j REJECT --reject-with tcp-reset
+`, applyScript(o))
+	})
+
+	t.Run("no-faults", func(t *testing.T) {
+		o, err := newOptions()
+		require.NoError(t, err)
+
+		require.Equal(t, "set -e\n", applyScript(o))
+	})
+}
+
+func TestRemoveScript(t *testing.T) {
+	require.Equal(t, `for dev in eth0; do tc qdisc del dev "$dev" root 2>/dev/null || true; done
+iptables -D INPUT -j TESTCONTAINERS_CHAOS 2>/dev/null || true
+iptables -F TESTCONTAINERS_CHAOS 2>/dev/null || true
+iptables -X TESTCONTAINERS_CHAOS 2>/dev/null || true
+`, removeScript([]string{"eth0"}))
+
+	require.Contains(t, removeScript(nil), "for dev in "+allInterfaces+";")
+}
+
+func TestLockInjector(t *testing.T) {
+	require.Nil(t, lockInjector("a", false))
+
+	a := lockInjector("a", true)
+
+	// the injectors of the other containers are not blocked
+	b := lockInjector("b", true)
+	require.NotSame(t, a, b)
+	b.mtx.Unlock()
+
+	// an injector removed while waiting for it is replaced with a new one
+	locked := make(chan *injector)
+	go func() {
+		locked <- lockInjector("a", true)
+	}()
+
+	injectorsMx.Lock()
+	delete(injectors, "a")
+	injectorsMx.Unlock()
+	a.removed = true
+	a.mtx.Unlock()
+
+	again := <-locked
+	require.NotSame(t, a, again)
+	require.False(t, again.removed)
+	again.mtx.Unlock()
+
+	injectorsMx.Lock()
+	delete(injectors, "a")
+	delete(injectors, "b")
+	injectorsMx.Unlock()
+}
+
+func TestApply(t *testing.T) {
+	ctx := context.Background()
+
+	ctr, err := testcontainers.GenericContainer(ctx, testcontainers.GenericContainerRequest{
+		ContainerRequest: testcontainers.ContainerRequest{
+			Image:        "docker.io/nginx:alpine",
+			ExposedPorts: []string{"80/tcp"},
+			WaitingFor:   wait.ForListeningPort("80/tcp"),
+		},
+		Started: true,
+	})
+	testcontainers.CleanupContainer(t, ctr)
+	require.NoError(t, err)
+
+	endpoint, err := ctr.PortEndpoint(ctx, "80/tcp", "http")
+	require.NoError(t, err)
+
+	get := func() (time.Duration, error) {
+		start := time.Now()
+
+		resp, err := http.Get(endpoint)
+		if err != nil {
+			return 0, err
+		}
+		defer resp.Body.Close()
+
+		return time.Since(start), nil
+	}
+
+	// applyFaults {
+	err = Apply(ctx, ctr, WithLatency(500*time.Millisecond))
+	require.NoError(t, err)
+	// }
+
+	elapsed, err := get()
+	require.NoError(t, err)
+	require.GreaterOrEqual(t, elapsed, 500*time.Millisecond)
+
+	err = Apply(ctx, ctr, WithConnectionResets("80"))
+	require.NoError(t, err)
+
+	_, err = get()
+	require.Error(t, err)
+
+	// removeFaults {
+	err = Remove(ctx, ctr)
+	require.NoError(t, err)
+	// }
+
+	elapsed, err = get()
+	require.NoError(t, err)
+	require.Less(t, elapsed, 500*time.Millisecond)
+}
+
+func TestWithFaults(t *testing.T) {
+	ctx := context.Background()
+
+	// withFaults {
+	req := testcontainers.GenericContainerRequest{
+		ContainerRequest: testcontainers.ContainerRequest{
+			Image:        "docker.io/nginx:alpine",
+			ExposedPorts: []string{"80/tcp"},
+			WaitingFor:   wait.ForListeningPort("80/tcp"),
+		},
+		Started: true,
+	}
+
+	err := WithFaults(WithLatency(300*time.Millisecond), WithPacketLoss(1)).Customize(&req)
+	require.NoError(t, err)
+
+	ctr, err := testcontainers.GenericContainer(ctx, req)
+	// }
+	testcontainers.CleanupContainer(t, ctr)
+	require.NoError(t, err)
+
+	endpoint, err := ctr.PortEndpoint(ctx, "80/tcp", "http")
+	require.NoError(t, err)
+
+	start := time.Now()
+	resp, err := http.Get(endpoint)
+	require.NoError(t, err)
+	resp.Body.Close()
+	require.GreaterOrEqual(t, time.Since(start), 300*time.Millisecond)
+
+	require.NoError(t, ctr.Stop(ctx, nil))
+
+	injectorsMx.Lock()
+	defer injectorsMx.Unlock()
+	require.NotContains(t, injectors, ctr.GetContainerID())
+}
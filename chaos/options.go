@@ -0,0 +1,153 @@
+package chaos
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DefaultImage is the image of the helper container used to inject the faults,
+// which must provide the tc and iptables commands.
+const DefaultImage = "docker.io/nicolaka/netshoot:v0.13"
+
+// options holds the faults to inject into the traffic of a container,
+// and the configuration of the helper container.
+type options struct {
+	image      string
+	interfaces []string
+
+	latency    time.Duration
+	jitter     time.Duration
+	packetLoss float64
+	bandwidth  string
+	resets     bool
+	resetPorts []string
+}
+
+// Option is a type that represents a fault to inject into the traffic of a container,
+// or a setting of the helper container injecting them.
+type Option func(*options) error
+
+// WithImage sets the image of the helper container, which must provide the tc and iptables commands.
+// Default: [DefaultImage].
+func WithImage(image string) Option {
+	return func(o *options) error {
+		o.image = image
+
+		return nil
+	}
+}
+
+// WithInterfaces sets the network interfaces of the container the faults are injected into.
+// Default: all the interfaces of the container, except the loopback one.
+func WithInterfaces(interfaces ...string) Option {
+	return func(o *options) error {
+		o.interfaces = interfaces
+
+		return nil
+	}
+}
+
+// WithLatency delays the packets sent by the container.
+func WithLatency(latency time.Duration) Option {
+	return func(o *options) error {
+		if latency < 0 {
+			return fmt.Errorf("latency must not be negative: %s", latency)
+		}
+		o.latency = latency
+
+		return nil
+	}
+}
+
+// WithJitter varies the latency of the packets sent by the container, which must be set
+// with [WithLatency], randomly adding or subtracting up to the given duration.
+func WithJitter(jitter time.Duration) Option {
+	return func(o *options) error {
+		if jitter < 0 {
+			return fmt.Errorf("jitter must not be negative: %s", jitter)
+		}
+		o.jitter = jitter
+
+		return nil
+	}
+}
+
+// WithPacketLoss drops the given percentage of the packets sent by the container, between 0 and 100.
+func WithPacketLoss(percent float64) Option {
+	return func(o *options) error {
+		if percent < 0 || percent > 100 {
+			return fmt.Errorf("packet loss must be between 0 and 100: %v", percent)
+		}
+		o.packetLoss = percent
+
+		return nil
+	}
+}
+
+// WithBandwidth limits the rate of the traffic sent by the container,
+// using the units of tc, e.g. "512kbit", "1mbit" or "10mbps".
+func WithBandwidth(rate string) Option {
+	return func(o *options) error {
+		if rate == "" || strings.ContainsAny(rate, " \t\n;&|$`'\"") {
+			return fmt.Errorf("invalid bandwidth rate: %q", rate)
+		}
+		o.bandwidth = rate
+
+		return nil
+	}
+}
+
+// WithConnectionResets resets the TCP connections to the given ports of the container, e.g. "5432"
+// or "5432/tcp", replying to every packet received on them with a TCP reset, so both the established
+// and the new connections fail. If no port is given, all the TCP connections of the container are reset,
+// including the ones opened by the container.
+func WithConnectionResets(ports ...string) Option {
+	return func(o *options) error {
+		resetPorts := make([]string, 0, len(ports))
+		for _, p := range ports {
+			port, proto, _ := strings.Cut(p, "/")
+			if proto != "" && proto != "tcp" {
+				return fmt.Errorf("connections can only be reset for TCP ports: %s", p)
+			}
+
+			if n, err := strconv.Atoi(port); err != nil || n < 1 || n > 65535 {
+				return fmt.Errorf("invalid port: %s", p)
+			}
+
+			resetPorts = append(resetPorts, port)
+		}
+
+		o.resets = true
+		o.resetPorts = resetPorts
+
+		return nil
+	}
+}
+
+// newOptions applies the given options to the default ones.
+func newOptions(opts ...Option) (*options, error) {
+	o := &options{
+		image: DefaultImage,
+	}
+
+	for _, opt := range opts {
+		if err := opt(o); err != nil {
+			return nil, err
+		}
+	}
+
+	if o.jitter > 0 && o.latency == 0 {
+		return nil, errors.New("jitter requires a latency")
+	}
+
+	for _, iface := range o.interfaces {
+		if iface == "" || strings.ContainsAny(iface, " \t\n;&|$`'\"/") {
+			return nil, fmt.Errorf("invalid interface: %q", iface)
+		}
+	}
+
+	return o, nil
+}
@@ -0,0 +1,72 @@
+// Command tccleanup lists and removes the containers, networks, volumes and images created by
+// Testcontainers for Go, identified by their labels, e.g. the resources leaked by the test runs
+// which were killed, which ran with Ryuk disabled, or the reused containers.
+//
+// Usage:
+//
+//	go run github.com/testcontainers/testcontainers-go/cmd/tccleanup [flags]
+//
+// The flags are:
+//
+//	-session id
+//		only the resources of the given test session
+//	-project path
+//		only the resources of the test sessions run in the given project directory
+//	-older-than duration
+//		only the resources created more than the given duration ago, e.g. 24h
+//	-orphans
+//		only the resources of the test sessions whose owning process no longer exists
+//	-reused
+//		only the reusable containers, which outlive the test sessions when the reuse is enabled
+//	-dry-run
+//		list the resources without removing them
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+
+	"github.com/testcontainers/testcontainers-go/internal/core"
+)
+
+func main() {
+	var opts options
+	flag.StringVar(&opts.sessionID, "session", "", "only the resources of the given test session")
+	flag.StringVar(&opts.project, "project", "", "only the resources of the test sessions run in the given project directory")
+	flag.DurationVar(&opts.olderThan, "older-than", 0, "only the resources created more than the given duration ago, e.g. 24h")
+	flag.BoolVar(&opts.orphans, "orphans", false, "only the resources of the test sessions whose owning process no longer exists")
+	flag.BoolVar(&opts.reused, "reused", false, "only the reusable containers, which outlive the test sessions when the reuse is enabled")
+	flag.BoolVar(&opts.dryRun, "dry-run", false, "list the resources without removing them")
+	flag.Parse()
+
+	if err := cleanup(opts); err != nil {
+		fmt.Fprintf(os.Stderr, "tccleanup: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// cleanup lists and removes the resources matching the options.
+func cleanup(opts options) error {
+	if opts.project != "" {
+		project, err := filepath.Abs(opts.project)
+		if err != nil {
+			return fmt.Errorf("project path: %w", err)
+		}
+		opts.project = project
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	cli, err := core.NewClient(ctx)
+	if err != nil {
+		return fmt.Errorf("docker client: %w", err)
+	}
+	defer cli.Close()
+
+	return run(ctx, cli, opts, os.Stdout)
+}
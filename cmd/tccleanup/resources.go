@@ -0,0 +1,232 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/filters"
+	"github.com/docker/docker/api/types/image"
+	"github.com/docker/docker/api/types/network"
+	"github.com/docker/docker/api/types/volume"
+	"github.com/docker/docker/client"
+	"github.com/docker/docker/errdefs"
+
+	"github.com/testcontainers/testcontainers-go/internal/core"
+)
+
+// options are the filters of the resources to remove.
+type options struct {
+	sessionID string
+	project   string
+	olderThan time.Duration
+	orphans   bool
+	reused    bool
+	dryRun    bool
+}
+
+// resourceKind is the kind of a resource. The resources are listed and removed in the order
+// of their kinds, as the containers use the rest of the resources.
+type resourceKind string
+
+const (
+	containerKind resourceKind = "container"
+	networkKind   resourceKind = "network"
+	volumeKind    resourceKind = "volume"
+	imageKind     resourceKind = "image"
+)
+
+// resource is a resource created by Testcontainers for Go.
+type resource struct {
+	kind    resourceKind
+	id      string // the name for the volumes
+	name    string
+	labels  map[string]string
+	created time.Time // zero if unknown
+}
+
+// run lists the resources matching the options, writing them to w, and removes them unless it's a dry run.
+func run(ctx context.Context, cli client.APIClient, opts options, w io.Writer) error {
+	resources, err := listResources(ctx, cli, opts)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	orphans := map[string]bool{}
+
+	var matching []resource
+	for _, r := range resources {
+		if opts.matches(r, now, orphans) {
+			matching = append(matching, r)
+		}
+	}
+
+	if len(matching) == 0 {
+		fmt.Fprintln(w, "No resources found")
+		return nil
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "KIND\tID\tNAME\tSESSION\tCREATED")
+	for _, r := range matching {
+		created := "unknown"
+		if !r.created.IsZero() {
+			created = now.Sub(r.created).Round(time.Second).String() + " ago"
+		}
+
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\n", r.kind, shortID(r.id), r.name, shortID(r.labels[core.LabelSessionID]), created)
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+
+	if opts.dryRun {
+		fmt.Fprintf(w, "%d resources would be removed\n", len(matching))
+		return nil
+	}
+
+	var errs []error
+	for _, r := range matching {
+		if err := removeResource(ctx, cli, r); err != nil && !errdefs.IsNotFound(err) {
+			errs = append(errs, fmt.Errorf("remove %s %s: %w", r.kind, r.name, err))
+		}
+	}
+
+	fmt.Fprintf(w, "Removed %d resources\n", len(matching)-len(errs))
+
+	return errors.Join(errs...)
+}
+
+// filters returns the filters of the resources created by Testcontainers for Go matching the session, project and reused options.
+func (o options) filters() filters.Args {
+	args := filters.NewArgs(
+		filters.Arg("label", core.LabelBase+"=true"),
+		filters.Arg("label", core.LabelLang+"=go"),
+	)
+
+	if o.sessionID != "" {
+		args.Add("label", core.LabelSessionID+"="+o.sessionID)
+	}
+
+	if o.project != "" {
+		args.Add("label", core.LabelProjectPath+"="+o.project)
+	}
+
+	if o.reused {
+		args.Add("label", core.LabelReuseHash)
+	}
+
+	return args
+}
+
+// matches returns true if the resource matches the age and orphans options, caching whether the sessions are orphans.
+func (o options) matches(r resource, now time.Time, orphans map[string]bool) bool {
+	if o.olderThan > 0 && (r.created.IsZero() || now.Sub(r.created) < o.olderThan) {
+		return false
+	}
+
+	if !o.orphans {
+		return true
+	}
+
+	sessionID := r.labels[core.LabelSessionID]
+	orphan, ok := orphans[sessionID]
+	if !ok {
+		orphan = core.IsOrphanSession(sessionID, r.labels[core.LabelSessionOwner])
+		orphans[sessionID] = orphan
+	}
+
+	return orphan
+}
+
+// listResources lists the resources created by Testcontainers for Go matching the session, project and reused options.
+func listResources(ctx context.Context, cli client.APIClient, opts options) ([]resource, error) {
+	args := opts.filters()
+
+	var resources []resource
+
+	containers, err := cli.ContainerList(ctx, container.ListOptions{All: true, Filters: args})
+	if err != nil {
+		return nil, fmt.Errorf("list containers: %w", err)
+	}
+
+	for _, c := range containers {
+		var name string
+		if len(c.Names) > 0 {
+			name = strings.TrimPrefix(c.Names[0], "/")
+		}
+
+		resources = append(resources, resource{kind: containerKind, id: c.ID, name: name, labels: c.Labels, created: time.Unix(c.Created, 0)})
+	}
+
+	networks, err := cli.NetworkList(ctx, network.ListOptions{Filters: args})
+	if err != nil {
+		return nil, fmt.Errorf("list networks: %w", err)
+	}
+
+	for _, n := range networks {
+		resources = append(resources, resource{kind: networkKind, id: n.ID, name: n.Name, labels: n.Labels, created: n.Created})
+	}
+
+	volumes, err := cli.VolumeList(ctx, volume.ListOptions{Filters: args})
+	if err != nil {
+		return nil, fmt.Errorf("list volumes: %w", err)
+	}
+
+	for _, v := range volumes.Volumes {
+		created, _ := time.Parse(time.RFC3339, v.CreatedAt)
+		resources = append(resources, resource{kind: volumeKind, id: v.Name, name: v.Name, labels: v.Labels, created: created})
+	}
+
+	images, err := cli.ImageList(ctx, image.ListOptions{Filters: args})
+	if err != nil {
+		return nil, fmt.Errorf("list images: %w", err)
+	}
+
+	for _, img := range images {
+		name := "<none>"
+		if len(img.RepoTags) > 0 {
+			name = img.RepoTags[0]
+		}
+
+		resources = append(resources, resource{kind: imageKind, id: img.ID, name: name, labels: img.Labels, created: time.Unix(img.Created, 0)})
+	}
+
+	return resources, nil
+}
+
+// removeResource removes a resource, including the volumes of the containers and the child images of the images.
+func removeResource(ctx context.Context, cli client.APIClient, r resource) error {
+	switch r.kind {
+	case containerKind:
+		return cli.ContainerRemove(ctx, r.id, container.RemoveOptions{RemoveVolumes: true, Force: true})
+	case networkKind:
+		return cli.NetworkRemove(ctx, r.id)
+	case volumeKind:
+		return cli.VolumeRemove(ctx, r.id, true)
+	case imageKind:
+		_, err := cli.ImageRemove(ctx, r.id, image.RemoveOptions{Force: true, PruneChildren: true})
+		return err
+	}
+
+	return fmt.Errorf("unknown resource kind %q", r.kind)
+}
+
+// shortID returns the first 12 characters of an ID, without its algorithm, e.g. sha256:.
+func shortID(id string) string {
+	if _, hex, ok := strings.Cut(id, ":"); ok {
+		id = hex
+	}
+
+	if len(id) > 12 {
+		return id[:12]
+	}
+
+	return id
+}
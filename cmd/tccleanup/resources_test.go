@@ -0,0 +1,172 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/filters"
+	"github.com/docker/docker/api/types/image"
+	"github.com/docker/docker/api/types/network"
+	"github.com/docker/docker/api/types/volume"
+	"github.com/docker/docker/client"
+	"github.com/stretchr/testify/require"
+
+	"github.com/testcontainers/testcontainers-go/internal/core"
+)
+
+// mockCli is a mock implementation of client.APIClient, listing the given resources
+// and recording the filters of the lists and the removed resources.
+type mockCli struct {
+	client.APIClient
+
+	containers []types.Container
+	networks   []network.Summary
+	volumes    []*volume.Volume
+	images     []image.Summary
+
+	filters filters.Args
+	removed []string
+}
+
+func (m *mockCli) ContainerList(_ context.Context, opts container.ListOptions) ([]types.Container, error) {
+	m.filters = opts.Filters
+	return m.containers, nil
+}
+
+func (m *mockCli) NetworkList(_ context.Context, _ network.ListOptions) ([]network.Summary, error) {
+	return m.networks, nil
+}
+
+func (m *mockCli) VolumeList(_ context.Context, _ volume.ListOptions) (volume.ListResponse, error) {
+	return volume.ListResponse{Volumes: m.volumes}, nil
+}
+
+func (m *mockCli) ImageList(_ context.Context, _ image.ListOptions) ([]image.Summary, error) {
+	return m.images, nil
+}
+
+func (m *mockCli) ContainerRemove(_ context.Context, id string, _ container.RemoveOptions) error {
+	m.removed = append(m.removed, "container "+id)
+	return nil
+}
+
+func (m *mockCli) NetworkRemove(_ context.Context, id string) error {
+	m.removed = append(m.removed, "network "+id)
+	return nil
+}
+
+func (m *mockCli) VolumeRemove(_ context.Context, name string, _ bool) error {
+	m.removed = append(m.removed, "volume "+name)
+	return nil
+}
+
+func (m *mockCli) ImageRemove(_ context.Context, id string, _ image.RemoveOptions) ([]image.DeleteResponse, error) {
+	m.removed = append(m.removed, "image "+id)
+	return nil, nil
+}
+
+func newMockCli(t *testing.T) *mockCli {
+	t.Helper()
+
+	hostname, err := os.Hostname()
+	require.NoError(t, err)
+
+	labels := func(sessionID string, owner string) map[string]string {
+		return map[string]string{
+			core.LabelBase:         "true",
+			core.LabelLang:         "go",
+			core.LabelSessionID:    sessionID,
+			core.LabelSessionOwner: owner,
+		}
+	}
+
+	// above the maximum pid of Linux, so the owner is gone
+	orphan := labels("0123456789abcdef", hostname+":4194305")
+	remote := labels("fedcba9876543210", "another-host:1")
+
+	now := time.Now()
+	old := now.Add(-48 * time.Hour)
+
+	return &mockCli{
+		containers: []types.Container{
+			{ID: "c1c1c1c1c1c1c1c1", Names: []string{"/redis"}, Labels: orphan, Created: old.Unix()},
+			{ID: "c2c2c2c2c2c2c2c2", Names: []string{"/postgres"}, Labels: remote, Created: now.Unix()},
+		},
+		networks: []network.Summary{{ID: "n1n1n1n1n1n1n1n1", Name: "tc-network", Labels: orphan, Created: old}},
+		volumes:  []*volume.Volume{{Name: "data", Labels: remote, CreatedAt: old.Format(time.RFC3339)}},
+		images:   []image.Summary{{ID: "sha256:i1i1i1i1i1i1i1i1", RepoTags: []string{"testcontainers-build:abc"}, Labels: orphan, Created: now.Unix()}},
+	}
+}
+
+func TestRun(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("all", func(t *testing.T) {
+		cli := newMockCli(t)
+		var out bytes.Buffer
+
+		require.NoError(t, run(ctx, cli, options{}, &out))
+		require.Equal(t, []string{
+			"container c1c1c1c1c1c1c1c1", "container c2c2c2c2c2c2c2c2", "network n1n1n1n1n1n1n1n1",
+			"volume data", "image sha256:i1i1i1i1i1i1i1i1",
+		}, cli.removed)
+		require.Equal(t, []string{core.LabelBase + "=true", core.LabelLang + "=go"}, cli.filters.Get("label"))
+		require.Contains(t, out.String(), "container  c1c1c1c1c1c1  redis")
+		require.Contains(t, out.String(), "image      i1i1i1i1i1i1  testcontainers-build:abc")
+		require.Contains(t, out.String(), "Removed 5 resources")
+	})
+
+	t.Run("dry-run", func(t *testing.T) {
+		cli := newMockCli(t)
+		var out bytes.Buffer
+
+		require.NoError(t, run(ctx, cli, options{dryRun: true}, &out))
+		require.Empty(t, cli.removed)
+		require.Contains(t, out.String(), "5 resources would be removed")
+	})
+
+	t.Run("session-and-project", func(t *testing.T) {
+		cli := newMockCli(t)
+
+		require.NoError(t, run(ctx, cli, options{sessionID: "0123456789abcdef", project: "/src/app"}, &bytes.Buffer{}))
+		require.ElementsMatch(t, []string{
+			core.LabelBase + "=true", core.LabelLang + "=go",
+			core.LabelSessionID + "=0123456789abcdef", core.LabelProjectPath + "=/src/app",
+		}, cli.filters.Get("label"))
+	})
+
+	t.Run("reused", func(t *testing.T) {
+		cli := newMockCli(t)
+
+		require.NoError(t, run(ctx, cli, options{reused: true}, &bytes.Buffer{}))
+		require.ElementsMatch(t, []string{
+			core.LabelBase + "=true", core.LabelLang + "=go", core.LabelReuseHash,
+		}, cli.filters.Get("label"))
+	})
+
+	t.Run("older-than", func(t *testing.T) {
+		cli := newMockCli(t)
+
+		require.NoError(t, run(ctx, cli, options{olderThan: 24 * time.Hour}, &bytes.Buffer{}))
+		require.Equal(t, []string{"container c1c1c1c1c1c1c1c1", "network n1n1n1n1n1n1n1n1", "volume data"}, cli.removed)
+	})
+
+	t.Run("orphans", func(t *testing.T) {
+		cli := newMockCli(t)
+
+		require.NoError(t, run(ctx, cli, options{orphans: true}, &bytes.Buffer{}))
+		require.Equal(t, []string{"container c1c1c1c1c1c1c1c1", "network n1n1n1n1n1n1n1n1", "image sha256:i1i1i1i1i1i1i1i1"}, cli.removed)
+	})
+
+	t.Run("no-resources", func(t *testing.T) {
+		var out bytes.Buffer
+
+		require.NoError(t, run(ctx, &mockCli{}, options{}, &out))
+		require.Equal(t, "No resources found\n", out.String())
+	})
+}
@@ -3,11 +3,17 @@ package testcontainers
 import (
 	"archive/tar"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"errors"
 	"fmt"
 	"io"
+	"io/fs"
 	"os"
+	"path"
 	"path/filepath"
+	"sort"
+	"strconv"
 	"strings"
 	"time"
 
@@ -19,6 +25,7 @@ import (
 	"github.com/docker/docker/pkg/archive"
 	"github.com/docker/go-connections/nat"
 	"github.com/google/uuid"
+	"github.com/moby/patternmatcher"
 	"github.com/moby/patternmatcher/ignorefile"
 
 	tcexec "github.com/testcontainers/testcontainers-go/exec"
@@ -48,6 +55,12 @@ type Container interface {
 	IsRunning() bool                                                // IsRunning returns true if the container is running, false otherwise.
 	Start(context.Context) error                                    // start the container
 	Stop(context.Context, *time.Duration) error                     // stop the container
+	Pause(context.Context) error                                    // pause all the processes in the container
+	Unpause(context.Context) error                                  // unpause all the processes in the container
+	Kill(ctx context.Context, signal string) error                  // send a signal to the main process of the container
+
+	// Restart stops and starts the container again, keeping the host ports it's mapped to.
+	Restart(ctx context.Context, opts ...RestartOption) error
 
 	// Terminate stops and removes the container and its image if it was built and not flagged as kept.
 	Terminate(ctx context.Context) error
@@ -61,6 +74,10 @@ type Container interface {
 	Networks(context.Context) ([]string, error)                     // get container networks
 	NetworkAliases(context.Context) (map[string][]string, error)    // get container network aliases for a network
 	Exec(ctx context.Context, cmd []string, options ...tcexec.ProcessOption) (int, io.Reader, error)
+
+	// ExecSession runs a command interactively, with a standard input and separate output streams.
+	ExecSession(ctx context.Context, cmd []string, options ...tcexec.ProcessOption) (*ExecSession, error)
+
 	ContainerIP(context.Context) (string, error)    // get container ip
 	ContainerIPs(context.Context) ([]string, error) // get all container IPs
 	CopyToContainer(ctx context.Context, fileContent []byte, containerFilePath string, fileMode int64) error
@@ -68,6 +85,20 @@ type Container interface {
 	CopyFileToContainer(ctx context.Context, hostFilePath string, containerFilePath string, fileMode int64) error
 	CopyFileFromContainer(ctx context.Context, filePath string) (io.ReadCloser, error)
 	GetLogProductionErrorChannel() <-chan error
+
+	// FS returns the read-only filesystem of the container.
+	FS(ctx context.Context) ContainerFS
+
+	// CopyDirFromContainer copies the content of a directory of the container to a directory of the host,
+	// preserving symlinks, modes, ownership and modification times.
+	CopyDirFromContainer(ctx context.Context, containerDirPath string, hostDirPath string) error
+
+	// Snapshot commits the filesystem and the anonymous volumes of the container, stored under the given name.
+	Snapshot(ctx context.Context, name string) error
+
+	// Restore replaces the container with a new one created from the snapshot with the given name,
+	// keeping its configuration, network aliases and, if still available, host ports.
+	Restore(ctx context.Context, name string) error
 }
 
 // ImageBuildInfo defines what is needed to build an image
@@ -83,6 +114,24 @@ type ImageBuildInfo interface {
 	GetAuthConfigs() map[string]registry.AuthConfig // Deprecated. Testcontainers will detect registry credentials automatically. Return the auth configs to be able to pull from an authenticated docker registry
 }
 
+// buildKitInfo is implemented by the ImageBuildInfo requesting the BuildKit features, like [ContainerRequest].
+// It's not part of ImageBuildInfo, so its implementations outside of this package keep compiling.
+type buildKitInfo interface {
+	GetBuildSecrets() map[string][]byte // return the secrets exposed to the build, by ID
+	GetBuildSSH() map[string]string     // return the SSH agent sockets forwarded to the build, by ID
+	GetBuildLogConsumer() LogConsumer   // return the consumer of the build output, if any
+}
+
+var _ buildKitInfo = (*ContainerRequest)(nil)
+
+// builtImageReuser is implemented by the ImageBuildInfo tagging the built image with the hash of its build, like [ContainerRequest].
+// It's not part of ImageBuildInfo, so its implementations outside of this package keep compiling.
+type builtImageReuser interface {
+	ShouldReuseBuiltImage() bool // return true if the image is tagged with the hash of its build, reusing it if it already exists
+}
+
+var _ builtImageReuser = (*ContainerRequest)(nil)
+
 // FromDockerfile represents the parameters needed to build an image from a Dockerfile
 // rather than using a pre-built one
 type FromDockerfile struct {
@@ -97,11 +146,42 @@ type FromDockerfile struct {
 	// KeepImage describes whether DockerContainer.Terminate should not delete the
 	// container image. Useful for images that are built from a Dockerfile and take a
 	// long time to build. Keeping the image also Docker to reuse it.
+	// If the Tag is not set, the kept image is tagged with a hash of the build context,
+	// the Dockerfile, the build args and the target, and the build is skipped entirely
+	// when an image with that tag already exists.
 	KeepImage bool
 	// BuildOptionsModifier Modifier for the build options before image build. Use it for
 	// advanced configurations while building the image. Please consider that the modifier
 	// is called after the default build options are set.
 	BuildOptionsModifier func(*types.ImageBuildOptions)
+	// ContextFS is the file system of the build context, e.g. an embed.FS, used instead of the Context directory.
+	// The files matching the patterns of its .dockerignore file are not sent to the Docker daemon.
+	ContextFS fs.FS
+	// DockerfileContent is the content of the Dockerfile, added to the build context at the Dockerfile path,
+	// replacing the file of the build context if any. The build context can be empty, so the image can be
+	// built from a Dockerfile generated in code without any file on disk.
+	DockerfileContent string
+	// BuildKit enables the BuildKit builder of the Docker daemon, which is required by the
+	// Dockerfile features of BuildKit, e.g. RUN --mount=type=cache. It's enabled automatically
+	// if Secrets, SSH or InlineCache are set.
+	BuildKit bool
+	// Target is the stage to build in a multi-stage Dockerfile, defaults to the last one.
+	Target string
+	// Secrets are the contents of the secrets exposed to the build by ID,
+	// which are mounted with RUN --mount=type=secret,id=<ID>. Requires BuildKit.
+	Secrets map[string][]byte
+	// SSH are the paths of the SSH agent sockets forwarded to the build by ID, which are mounted with
+	// RUN --mount=type=ssh,id=<ID>, where the ID defaults to "default". An empty path uses the agent
+	// of the SSH_AUTH_SOCK environment variable. Requires BuildKit.
+	SSH map[string]string
+	// CacheFrom are the images used as cache sources for the build, e.g. images built and pushed with InlineCache.
+	CacheFrom []string
+	// InlineCache embeds the build cache metadata in the built image, so it can be used in CacheFrom
+	// by other builds once it's pushed to a registry. Requires BuildKit.
+	InlineCache bool
+	// BuildLogConsumer receives the output of the build line by line, including the progress
+	// of the BuildKit builder, as logs of type StdoutLog. It's independent of PrintBuildLog.
+	BuildLogConsumer LogConsumer
 }
 
 type ContainerFile struct {
@@ -132,7 +212,8 @@ type ContainerRequest struct {
 	ImageSubstitutors        []ImageSubstitutor
 	Entrypoint               []string
 	Env                      map[string]string
-	ExposedPorts             []string // allow specifying protocol info
+	ExposedPorts             []string          // allow specifying protocol info
+	ReservedHostPorts        map[string]string // host ports reserved with WithReservedHostPort, by container port
 	Cmd                      []string
 	Labels                   map[string]string
 	Mounts                   ContainerMounts
@@ -154,7 +235,8 @@ type ContainerRequest struct {
 	ReaperImage              string                                     // Deprecated: use WithImageName ContainerOption instead. Alternative reaper image
 	ReaperOptions            []ContainerOption                          // Deprecated: the reaper is configured at the properties level, for an entire test session
 	AutoRemove               bool                                       // Deprecated: Use HostConfigModifier instead. If set to true, the container will be removed from the host when stopped
-	AlwaysPullImage          bool                                       // Always pull image
+	AlwaysPullImage          bool                                       // Always pull image, overriding the PullPolicy
+	PullPolicy               PullPolicy                                 // decides whether the image is pulled, defaults to the pull.policy property of the configuration, or PullIfMissing
 	ImagePlatform            string                                     // ImagePlatform describes the platform which the image runs on.
 	Binds                    []string                                   // Deprecated: Use HostConfigModifier instead
 	ShmSize                  int64                                      // Amount of memory shared with the host (in bytes)
@@ -166,6 +248,8 @@ type ContainerRequest struct {
 	EnpointSettingsModifier  func(map[string]*network.EndpointSettings) // Deprecated: Use EndpointSettingsModifier for the network settings before container creation
 	LifecycleHooks           []ContainerLifecycleHooks                  // define hooks to be executed during container lifecycle
 	LogConsumerCfg           *LogConsumerConfig                         // define the configuration for the log producer and its log consumers to follow the logs
+	StatsConsumerCfg         *StatsConsumerConfig                       // define the configuration for the stats producer and its stats consumers to follow the resource usage
+	PullProgressListener     PullProgressListener                       // receives the progress of the pull of the image, defaults to the listener of the provider
 }
 
 // containerOptions functional options for a container
@@ -200,6 +284,7 @@ func (c *ContainerRequest) Validate() error {
 	validationMethods := []func() error{
 		c.validateContextAndImage,
 		c.validateContextOrImageIsSpecified,
+		c.validateContextAndContextFS,
 		c.validateMounts,
 	}
 
@@ -223,6 +308,10 @@ func (c *ContainerRequest) GetContext() (io.Reader, error) {
 		return c.ContextArchive, nil
 	}
 
+	if c.ContextFS != nil || c.DockerfileContent != "" {
+		return c.contextFromFS()
+	}
+
 	// always pass context as absolute path
 	abs, err := filepath.Abs(c.Context)
 	if err != nil {
@@ -253,13 +342,162 @@ func (c *ContainerRequest) GetContext() (io.Reader, error) {
 	return buildContext, nil
 }
 
+// contextFromFS returns the build context archive of the ContextFS file system,
+// or of the Context directory, with the in-memory Dockerfile if any.
+// The archive is written while it's read, so it must be closed when no longer needed.
+func (c *ContainerRequest) contextFromFS() (io.ReadCloser, error) {
+	fsys := c.ContextFS
+	if fsys == nil && c.Context != "" {
+		// always pass context as absolute path
+		abs, err := filepath.Abs(c.Context)
+		if err != nil {
+			return nil, fmt.Errorf("error getting absolute path: %w", err)
+		}
+		c.Context = abs
+		fsys = os.DirFS(abs)
+	}
+
+	var excluded []string
+	if fsys != nil {
+		var err error
+		_, excluded, err = parseDockerIgnoreFS(fsys)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	pm, err := patternmatcher.New(excluded)
+	if err != nil {
+		return nil, fmt.Errorf("invalid .dockerignore pattern: %w", err)
+	}
+
+	dockerfile := path.Clean(filepath.ToSlash(c.GetDockerfile()))
+
+	pr, pw := io.Pipe()
+	go func() {
+		pw.CloseWithError(writeContextArchive(pw, fsys, pm, dockerfile, c.DockerfileContent))
+	}()
+
+	return pr, nil
+}
+
+// writeContextArchive writes the files of fsys which are not excluded by pm as a tar archive,
+// followed by the Dockerfile with the given content, if any. The .dockerignore file and the
+// Dockerfile are always written, as the Docker daemon needs them.
+func writeContextArchive(w io.Writer, fsys fs.FS, pm *patternmatcher.PatternMatcher, dockerfile string, dockerfileContent string) error {
+	tw := tar.NewWriter(w)
+
+	if fsys != nil {
+		err := fs.WalkDir(fsys, ".", func(name string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+
+			if name == "." || (name == dockerfile && dockerfileContent != "") {
+				return nil
+			}
+
+			if name != ".dockerignore" && name != dockerfile {
+				excluded, err := pm.MatchesOrParentMatches(name)
+				if err != nil {
+					return fmt.Errorf("match %s: %w", name, err)
+				}
+
+				if excluded {
+					if d.IsDir() && !pm.Exclusions() {
+						return fs.SkipDir
+					}
+					return nil
+				}
+			}
+
+			return addFileToArchive(tw, fsys, name)
+		})
+		if err != nil {
+			return fmt.Errorf("walk build context: %w", err)
+		}
+	}
+
+	if dockerfileContent != "" {
+		err := tw.WriteHeader(&tar.Header{
+			Name:     dockerfile,
+			Mode:     0o644,
+			Size:     int64(len(dockerfileContent)),
+			Typeflag: tar.TypeReg,
+			ModTime:  time.Unix(0, 0),
+		})
+		if err != nil {
+			return fmt.Errorf("write Dockerfile header: %w", err)
+		}
+
+		if _, err := io.WriteString(tw, dockerfileContent); err != nil {
+			return fmt.Errorf("write Dockerfile: %w", err)
+		}
+	}
+
+	return tw.Close()
+}
+
+// addFileToArchive writes the file or directory of fsys with the given name to the archive,
+// following symbolic links. Other types of files are skipped.
+func addFileToArchive(tw *tar.Writer, fsys fs.FS, name string) error {
+	info, err := fs.Stat(fsys, name)
+	if err != nil {
+		return err
+	}
+
+	if !info.IsDir() && !info.Mode().IsRegular() {
+		return nil
+	}
+
+	header, err := tar.FileInfoHeader(info, "")
+	if err != nil {
+		return fmt.Errorf("file header %s: %w", name, err)
+	}
+
+	header.Name = name
+	if info.IsDir() {
+		header.Name += "/"
+	}
+	header.Uid, header.Gid, header.Uname, header.Gname = 0, 0, "", ""
+	if header.ModTime.IsZero() {
+		// the files of an embed.FS have no modification time
+		header.ModTime = time.Unix(0, 0)
+	}
+
+	if err := tw.WriteHeader(header); err != nil {
+		return fmt.Errorf("write header %s: %w", name, err)
+	}
+
+	if info.IsDir() {
+		return nil
+	}
+
+	f, err := fsys.Open(name)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	if _, err := io.Copy(tw, f); err != nil {
+		return fmt.Errorf("write %s: %w", name, err)
+	}
+
+	return nil
+}
+
 // parseDockerIgnore returns if the file exists, the excluded files and an error if any
 func parseDockerIgnore(targetDir string) (bool, []string, error) {
+	return parseDockerIgnoreFS(os.DirFS(targetDir))
+}
+
+// parseDockerIgnoreFS returns if the .dockerignore file exists in the root
+// of the file system, the excluded files and an error if any
+func parseDockerIgnoreFS(fsys fs.FS) (bool, []string, error) {
 	// based on https://github.com/docker/cli/blob/master/cli/command/image/build/dockerignore.go#L14
-	fileLocation := filepath.Join(targetDir, ".dockerignore")
 	var excluded []string
 	exists := false
-	if f, openErr := os.Open(fileLocation); openErr == nil {
+	if f, openErr := fsys.Open(".dockerignore"); openErr == nil {
 		defer f.Close()
 
 		exists = true
@@ -321,6 +559,32 @@ func (c *ContainerRequest) GetAuthConfigs() map[string]registry.AuthConfig {
 
 // dockerFileImages returns the images from the request Dockerfile.
 func (c *ContainerRequest) dockerFileImages() ([]string, error) {
+	if c.ContextArchive == nil && c.DockerfileContent != "" {
+		// The Dockerfile is in memory.
+		images, err := core.ExtractImagesFromReader(strings.NewReader(c.DockerfileContent), c.GetBuildArgs())
+		if err != nil {
+			return nil, fmt.Errorf("extract images from Dockerfile: %w", err)
+		}
+
+		return images, nil
+	}
+
+	if c.ContextArchive == nil && c.ContextFS != nil {
+		// Source is a file system, we can read the Dockerfile from it.
+		f, err := c.ContextFS.Open(path.Clean(filepath.ToSlash(c.GetDockerfile())))
+		if err != nil {
+			return nil, fmt.Errorf("open Dockerfile: %w", err)
+		}
+		defer f.Close()
+
+		images, err := core.ExtractImagesFromReader(f, c.GetBuildArgs())
+		if err != nil {
+			return nil, fmt.Errorf("extract images from Dockerfile: %w", err)
+		}
+
+		return images, nil
+	}
+
 	if c.ContextArchive == nil {
 		// Source is a directory, we can read the Dockerfile directly.
 		images, err := core.ExtractImagesFromDockerfile(filepath.Join(c.Context, c.GetDockerfile()), c.GetBuildArgs())
@@ -395,17 +659,47 @@ func getAuthConfigsFromDockerfile(c *ContainerRequest) (map[string]registry.Auth
 }
 
 func (c *ContainerRequest) ShouldBuildImage() bool {
-	return c.FromDockerfile.Context != "" || c.FromDockerfile.ContextArchive != nil
+	return c.FromDockerfile.Context != "" || c.FromDockerfile.ContextArchive != nil ||
+		c.FromDockerfile.ContextFS != nil || c.FromDockerfile.DockerfileContent != ""
 }
 
 func (c *ContainerRequest) ShouldKeepBuiltImage() bool {
 	return c.FromDockerfile.KeepImage
 }
 
+// ShouldReuseBuiltImage returns true if the built image is kept and has no tag,
+// so it's tagged with the hash of its build and reused if it already exists.
+// Images which are not kept are removed when the container is terminated,
+// so they are always built with a random tag, which is never shared.
+func (c *ContainerRequest) ShouldReuseBuiltImage() bool {
+	return c.FromDockerfile.KeepImage && c.FromDockerfile.Tag == ""
+}
+
 func (c *ContainerRequest) ShouldPrintBuildLog() bool {
 	return c.FromDockerfile.PrintBuildLog
 }
 
+// GetBuildSecrets returns the secrets exposed to the build, by ID.
+func (c *ContainerRequest) GetBuildSecrets() map[string][]byte {
+	return c.FromDockerfile.Secrets
+}
+
+// GetBuildSSH returns the SSH agent sockets forwarded to the build, by ID.
+func (c *ContainerRequest) GetBuildSSH() map[string]string {
+	return c.FromDockerfile.SSH
+}
+
+// GetBuildLogConsumer returns the consumer of the build output, if any.
+func (c *ContainerRequest) GetBuildLogConsumer() LogConsumer {
+	return c.FromDockerfile.BuildLogConsumer
+}
+
+// useBuildKit returns true if the image must be built with BuildKit.
+func (c *ContainerRequest) useBuildKit() bool {
+	return c.FromDockerfile.BuildKit || c.FromDockerfile.InlineCache ||
+		len(c.FromDockerfile.Secrets) > 0 || len(c.FromDockerfile.SSH) > 0
+}
+
 // BuildOptions returns the image build options when building a Docker image from a Dockerfile.
 // It will apply some defaults and finally call the BuildOptionsModifier from the FromDockerfile struct,
 // if set.
@@ -413,6 +707,12 @@ func (c *ContainerRequest) BuildOptions() (types.ImageBuildOptions, error) {
 	buildOptions := types.ImageBuildOptions{
 		Remove:      true,
 		ForceRemove: true,
+		Target:      c.FromDockerfile.Target,
+		CacheFrom:   c.FromDockerfile.CacheFrom,
+	}
+
+	if c.useBuildKit() {
+		buildOptions.Version = types.BuilderBuildKit
 	}
 
 	if c.FromDockerfile.BuildOptionsModifier != nil {
@@ -423,6 +723,17 @@ func (c *ContainerRequest) BuildOptions() (types.ImageBuildOptions, error) {
 	buildOptions.BuildArgs = c.GetBuildArgs()
 	buildOptions.Dockerfile = c.GetDockerfile()
 
+	if c.FromDockerfile.InlineCache {
+		// copy the build args, so the ones of the request are not modified
+		inlineCache := "1"
+		buildArgs := make(map[string]*string, len(buildOptions.BuildArgs)+1)
+		for k, v := range buildOptions.BuildArgs {
+			buildArgs[k] = v
+		}
+		buildArgs["BUILDKIT_INLINE_CACHE"] = &inlineCache
+		buildOptions.BuildArgs = buildArgs
+	}
+
 	// Make sure the auth configs from the Dockerfile are set right after the user-defined build options.
 	authsFromDockerfile, err := getAuthConfigsFromDockerfile(c)
 	if err != nil {
@@ -437,8 +748,20 @@ func (c *ContainerRequest) BuildOptions() (types.ImageBuildOptions, error) {
 		buildOptions.AuthConfigs[registry] = authConfig
 	}
 
+	repo, tag := c.GetRepo(), c.GetTag()
+	if c.ShouldReuseBuiltImage() {
+		if c.FromDockerfile.Repo == "" {
+			repo = builtImageRepo
+		}
+
+		tag, err = c.buildHash(buildOptions)
+		if err != nil {
+			return types.ImageBuildOptions{}, fmt.Errorf("build hash: %w", err)
+		}
+	}
+
 	// make sure the first tag is the one defined in the ContainerRequest
-	tag := fmt.Sprintf("%s:%s", c.GetRepo(), c.GetTag())
+	tag = fmt.Sprintf("%s:%s", repo, tag)
 
 	// apply substitutors to the built image
 	for _, is := range c.ImageSubstitutors {
@@ -464,6 +787,12 @@ func (c *ContainerRequest) BuildOptions() (types.ImageBuildOptions, error) {
 		buildOptions.Labels = core.DefaultLabels(core.SessionID())
 	}
 
+	// label all the built images, including the kept ones, so PruneImages can remove them
+	if buildOptions.Labels == nil {
+		buildOptions.Labels = map[string]string{}
+	}
+	buildOptions.Labels[core.LabelBuilt] = "true"
+
 	// Do this as late as possible to ensure we don't leak the context on error/panic.
 	buildContext, err := c.GetContext()
 	if err != nil {
@@ -475,6 +804,71 @@ func (c *ContainerRequest) BuildOptions() (types.ImageBuildOptions, error) {
 	return buildOptions, nil
 }
 
+// builtImageRepo is the repository of the images tagged with the hash of their build.
+const builtImageRepo = "testcontainers-build"
+
+// buildHash returns the hex-encoded SHA-256 hash of the build context, the Dockerfile,
+// the build args and the target of the build options. The modification times of the files
+// are not hashed, so the hash is the same for the same files in different checkouts.
+func (c *ContainerRequest) buildHash(buildOptions types.ImageBuildOptions) (string, error) {
+	h := sha256.New()
+
+	writeField := func(values ...string) {
+		for _, v := range values {
+			fmt.Fprintf(h, "%d:%s", len(v), v)
+		}
+	}
+
+	writeField("dockerfile", buildOptions.Dockerfile, "target", buildOptions.Target)
+
+	args := make([]string, 0, len(buildOptions.BuildArgs))
+	for k := range buildOptions.BuildArgs {
+		args = append(args, k)
+	}
+	sort.Strings(args)
+
+	for _, k := range args {
+		if v := buildOptions.BuildArgs[k]; v != nil {
+			writeField("arg", k, *v)
+		} else {
+			writeField("unset-arg", k)
+		}
+	}
+
+	buildContext, err := c.GetContext()
+	if err != nil {
+		return "", err
+	}
+
+	if c.ContextArchive != nil {
+		// the archive is read again to build the image.
+		defer func() {
+			_, _ = c.ContextArchive.Seek(0, io.SeekStart)
+		}()
+	} else {
+		defer tryClose(buildContext)
+	}
+
+	tr := tar.NewReader(buildContext)
+	for {
+		hdr, err := tr.Next()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+
+			return "", fmt.Errorf("read build context: %w", err)
+		}
+
+		writeField("file", hdr.Name, string(hdr.Typeflag), strconv.FormatInt(hdr.Mode&0o7777, 8), hdr.Linkname, strconv.FormatInt(hdr.Size, 10))
+		if _, err := io.Copy(h, tr); err != nil {
+			return "", fmt.Errorf("read build context: %w", err)
+		}
+	}
+
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
 func (c *ContainerRequest) validateContextAndImage() error {
 	if c.FromDockerfile.Context != "" && c.Image != "" {
 		return errors.New("you cannot specify both an Image and Context in a ContainerRequest")
@@ -483,8 +877,16 @@ func (c *ContainerRequest) validateContextAndImage() error {
 	return nil
 }
 
+func (c *ContainerRequest) validateContextAndContextFS() error {
+	if c.FromDockerfile.Context != "" && c.FromDockerfile.ContextFS != nil {
+		return errors.New("you cannot specify both a Context and a ContextFS in a ContainerRequest")
+	}
+
+	return nil
+}
+
 func (c *ContainerRequest) validateContextOrImageIsSpecified() error {
-	if c.FromDockerfile.Context == "" && c.FromDockerfile.ContextArchive == nil && c.Image == "" {
+	if !c.ShouldBuildImage() && c.Image == "" {
 		return errors.New("you must specify either a build context or an image")
 	}
 
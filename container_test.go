@@ -9,6 +9,7 @@ import (
 	"io"
 	"log"
 	"testing"
+	"testing/fstest"
 	"time"
 
 	"github.com/docker/docker/api/types/container"
@@ -302,6 +303,106 @@ func Test_BuildImageWithContexts(t *testing.T) {
 	}
 }
 
+func Test_GetContextFromFS(t *testing.T) {
+	contextFS := fstest.MapFS{
+		".dockerignore":         {Data: []byte("*.log\nbuild\n!build/keep.txt\nDockerfile\n")},
+		"Dockerfile":            {Data: []byte("FROM docker.io/alpine\n")},
+		"app.go":                {Data: []byte("package main\n")},
+		"debug.log":             {Data: []byte("debug\n")},
+		"build/out.bin":         {Data: []byte("bin")},
+		"build/keep.txt":        {Data: []byte("keep")},
+		"conf/app.properties":   {Data: []byte("port=8080\n")},
+		"docker/Dockerfile.dev": {Data: []byte("FROM docker.io/alpine:3.20\n")},
+	}
+
+	readContext := func(t *testing.T, fromDockerfile testcontainers.FromDockerfile) map[string]string {
+		t.Helper()
+
+		req := testcontainers.ContainerRequest{FromDockerfile: fromDockerfile}
+
+		r, err := req.GetContext()
+		require.NoError(t, err)
+		defer r.(io.Closer).Close()
+
+		files := map[string]string{}
+		tr := tar.NewReader(r)
+		for {
+			hdr, err := tr.Next()
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			require.NoError(t, err)
+
+			if hdr.Typeflag == tar.TypeDir {
+				continue
+			}
+
+			bs, err := io.ReadAll(tr)
+			require.NoError(t, err)
+			files[hdr.Name] = string(bs)
+		}
+
+		return files
+	}
+
+	t.Run("context-fs", func(t *testing.T) {
+		files := readContext(t, testcontainers.FromDockerfile{
+			ContextFS: contextFS,
+		})
+
+		require.Equal(t, map[string]string{
+			".dockerignore":         "*.log\nbuild\n!build/keep.txt\nDockerfile\n",
+			"Dockerfile":            "FROM docker.io/alpine\n",
+			"app.go":                "package main\n",
+			"build/keep.txt":        "keep",
+			"conf/app.properties":   "port=8080\n",
+			"docker/Dockerfile.dev": "FROM docker.io/alpine:3.20\n",
+		}, files)
+	})
+
+	t.Run("context-fs-custom-dockerfile", func(t *testing.T) {
+		files := readContext(t, testcontainers.FromDockerfile{
+			ContextFS:  contextFS,
+			Dockerfile: "docker/Dockerfile.dev",
+		})
+
+		require.Contains(t, files, "docker/Dockerfile.dev")
+		require.NotContains(t, files, "Dockerfile")
+	})
+
+	t.Run("dockerfile-content", func(t *testing.T) {
+		files := readContext(t, testcontainers.FromDockerfile{
+			ContextFS:         contextFS,
+			DockerfileContent: "FROM docker.io/alpine:3.19\n",
+		})
+
+		require.Equal(t, "FROM docker.io/alpine:3.19\n", files["Dockerfile"])
+		require.Equal(t, "package main\n", files["app.go"])
+	})
+
+	t.Run("dockerfile-content-only", func(t *testing.T) {
+		files := readContext(t, testcontainers.FromDockerfile{
+			DockerfileContent: "FROM docker.io/alpine\n",
+			Dockerfile:        "build.Dockerfile",
+		})
+
+		require.Equal(t, map[string]string{
+			"build.Dockerfile": "FROM docker.io/alpine\n",
+		}, files)
+	})
+
+	t.Run("context-and-context-fs", func(t *testing.T) {
+		req := testcontainers.ContainerRequest{
+			FromDockerfile: testcontainers.FromDockerfile{
+				Context:   "testdata",
+				ContextFS: contextFS,
+			},
+		}
+
+		require.EqualError(t, req.Validate(), "you cannot specify both a Context and a ContextFS in a ContainerRequest")
+	})
+}
+
 func Test_GetLogsFromFailedContainer(t *testing.T) {
 	ctx := context.Background()
 	// directDockerHubReference {
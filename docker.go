@@ -3,6 +3,7 @@ package testcontainers
 import (
 	"archive/tar"
 	"bufio"
+	"bytes"
 	"context"
 	"encoding/base64"
 	"encoding/binary"
@@ -18,6 +19,7 @@ import (
 	"regexp"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/cenkalti/backoff/v4"
@@ -31,6 +33,7 @@ import (
 	"github.com/docker/docker/errdefs"
 	"github.com/docker/docker/pkg/jsonmessage"
 	"github.com/docker/go-connections/nat"
+	"github.com/docker/go-units"
 	"github.com/moby/term"
 	specs "github.com/opencontainers/image-spec/specs-go/v1"
 
@@ -62,7 +65,8 @@ type DockerContainer struct {
 	Image        string
 	exposedPorts []string // a reference to the container's requested exposed ports. It allows checking they are ready before any wait strategy
 
-	isRunning     bool
+	// isRunning is updated by the exit watcher in the background too, so it's accessed atomically.
+	isRunning     atomic.Bool
 	imageWasBuilt bool
 	// keepBuiltImage makes Terminate not remove the image if imageWasBuilt.
 	keepBuiltImage     bool
@@ -77,17 +81,36 @@ type DockerContainer struct {
 	// stopped once.
 
 	// logProductionWaitGroup is used to signal when the log production has stopped.
-	// This allows stopLogProduction to safely set logProductionStop to nil.
 	// See simplification in https://go.dev/play/p/x0pOElF2Vjf
 	logProductionWaitGroup sync.WaitGroup
 
-	logProductionStop chan struct{}
+	// logProductionMutex guards logProductionStop and logProductionStopped, as the log
+	// production can be started and stopped again, e.g. when the container is restarted.
+	logProductionMutex   sync.Mutex
+	logProductionStop    chan struct{}
+	logProductionStopped bool
 
 	logProductionTimeout *time.Duration
-	logger               Logging
-	lifecycleHooks       []ContainerLifecycleHooks
+
+	statsConsumers        []StatsConsumer
+	statsProductionCancel context.CancelFunc
+	statsProductionDone   chan struct{}
+
+	// exitWatcherMutex guards exitWatcherCancel and exitWatcherDone, which are
+	// accessed by the exit hooks, running in the background, too.
+	exitWatcherMutex  sync.Mutex
+	exitWatcherCancel context.CancelFunc
+	exitWatcherDone   chan struct{}
+
+	logger         Logging
+	lifecycleHooks []ContainerLifecycleHooks
 
 	healthStatus string // container health status, will default to healthStatusNone if no healthcheck is present
+
+	snapshots map[string]containerSnapshot // snapshots of the container, by name
+
+	restartImages  []string // IDs of the images the container was recreated from when restarted
+	restartVolumes []string // names of the anonymous volumes kept when the container was restarted
 }
 
 // SetLogger sets the logger for the container
@@ -110,7 +133,7 @@ func (c *DockerContainer) GetContainerID() string {
 }
 
 func (c *DockerContainer) IsRunning() bool {
-	return c.isRunning
+	return c.isRunning.Load()
 }
 
 // Endpoint gets proto://host:port string for the lowest numbered exposed port
@@ -234,7 +257,7 @@ func (c *DockerContainer) Start(ctx context.Context) error {
 		return fmt.Errorf("started hook: %w", err)
 	}
 
-	c.isRunning = true
+	c.isRunning.Store(true)
 
 	err = c.readiedHook(ctx)
 	if err != nil {
@@ -282,7 +305,7 @@ func (c *DockerContainer) Stop(ctx context.Context, timeout *time.Duration) erro
 
 	defer c.provider.Close()
 
-	c.isRunning = false
+	c.isRunning.Store(false)
 
 	err = c.stoppedHook(ctx)
 	if err != nil {
@@ -292,9 +315,160 @@ func (c *DockerContainer) Stop(ctx context.Context, timeout *time.Duration) erro
 	return nil
 }
 
+// Pause suspends all the processes in the container.
+//
+// A paused container is still considered running, so [DockerContainer.IsRunning]
+// keeps returning true, and the log production, if any, is resumed once the
+// container is unpaused.
+//
+// All hooks are called in the following order:
+//   - [ContainerLifecycleHooks.PrePauses]
+//   - [ContainerLifecycleHooks.PostPauses]
+func (c *DockerContainer) Pause(ctx context.Context) error {
+	err := c.pausingHook(ctx)
+	if err != nil {
+		return fmt.Errorf("pausing hook: %w", err)
+	}
+
+	if err := c.provider.client.ContainerPause(ctx, c.ID); err != nil {
+		return fmt.Errorf("container pause: %w", err)
+	}
+	defer c.provider.Close()
+
+	err = c.pausedHook(ctx)
+	if err != nil {
+		return fmt.Errorf("paused hook: %w", err)
+	}
+
+	return nil
+}
+
+// Unpause resumes all the processes in a paused container.
+//
+// All hooks are called in the following order:
+//   - [ContainerLifecycleHooks.PreUnpauses]
+//   - [ContainerLifecycleHooks.PostUnpauses]
+func (c *DockerContainer) Unpause(ctx context.Context) error {
+	err := c.unpausingHook(ctx)
+	if err != nil {
+		return fmt.Errorf("unpausing hook: %w", err)
+	}
+
+	if err := c.provider.client.ContainerUnpause(ctx, c.ID); err != nil {
+		return fmt.Errorf("container unpause: %w", err)
+	}
+	defer c.provider.Close()
+
+	err = c.unpausedHook(ctx)
+	if err != nil {
+		return fmt.Errorf("unpaused hook: %w", err)
+	}
+
+	return nil
+}
+
+// Kill sends a signal to the main process of the container, e.g. "SIGKILL" or "SIGHUP".
+// If the signal is empty, SIGKILL is sent, and the method waits for the container to exit.
+// For the terminating signals, i.e. SIGTERM, SIGINT and SIGQUIT, it waits up to 10 seconds
+// for the container to exit, as the process handles the signal before exiting. The running
+// state of the container is then refreshed, so it's consistent with the log production.
+//
+// All hooks are called in the following order:
+//   - [ContainerLifecycleHooks.PreKills]
+//   - [ContainerLifecycleHooks.PostKills]
+func (c *DockerContainer) Kill(ctx context.Context, signal string) error {
+	err := c.killingHook(ctx)
+	if err != nil {
+		return fmt.Errorf("killing hook: %w", err)
+	}
+
+	if err := c.provider.client.ContainerKill(ctx, c.ID, signal); err != nil {
+		return fmt.Errorf("container kill: %w", err)
+	}
+	defer c.provider.Close()
+
+	if isKillSignal(signal) {
+		statusCh, errCh := c.provider.client.ContainerWait(ctx, c.ID, container.WaitConditionNotRunning)
+		select {
+		case err := <-errCh:
+			if err != nil {
+				return fmt.Errorf("container wait: %w", err)
+			}
+		case <-statusCh:
+		}
+
+		c.isRunning.Store(false)
+	} else {
+		if isTerminatingSignal(signal) {
+			if err := c.waitNotRunning(ctx, killWaitTimeout); err != nil {
+				return fmt.Errorf("container wait: %w", err)
+			}
+		}
+
+		state, err := c.State(ctx)
+		if err != nil {
+			return fmt.Errorf("container state: %w", err)
+		}
+
+		c.isRunning.Store(state.Running)
+	}
+
+	err = c.killedHook(ctx)
+	if err != nil {
+		return fmt.Errorf("killed hook: %w", err)
+	}
+
+	return nil
+}
+
+// killWaitTimeout is the maximum time Kill waits for a container to exit after a terminating signal.
+const killWaitTimeout = 10 * time.Second
+
+// isTerminatingSignal returns true if the signal terminates the process by default,
+// after giving it the chance to handle it, e.g. SIGTERM.
+func isTerminatingSignal(signal string) bool {
+	switch strings.ToUpper(signal) {
+	case "TERM", "SIGTERM", "15", "INT", "SIGINT", "2", "QUIT", "SIGQUIT", "3":
+		return true
+	default:
+		return false
+	}
+}
+
+// waitNotRunning waits up to the given timeout for the container to exit.
+// It's not an error if the container is still running after the timeout.
+func (c *DockerContainer) waitNotRunning(ctx context.Context, timeout time.Duration) error {
+	waitCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	statusCh, errCh := c.provider.client.ContainerWait(waitCtx, c.ID, container.WaitConditionNotRunning)
+	select {
+	case err := <-errCh:
+		if err != nil && ctx.Err() == nil && errors.Is(err, context.DeadlineExceeded) {
+			return nil
+		}
+		return err
+	case <-statusCh:
+		return nil
+	}
+}
+
+// isKillSignal returns true if the signal is SIGKILL, which is also the
+// signal sent by the Docker daemon when no signal is given.
+func isKillSignal(signal string) bool {
+	switch strings.ToUpper(signal) {
+	case "", "KILL", "SIGKILL", "9":
+		return true
+	default:
+		return false
+	}
+}
+
 // Terminate calls stops and then removes the container including its volumes.
 // If its image was built it and all child images are also removed unless
 // the [FromDockerfile.KeepImage] on the [ContainerRequest] was set to true.
+// The images and volume archives of the snapshots of the container are removed too, and so are the
+// images and volumes kept when the container was restarted.
 //
 // The following hooks are called in order:
 //   - [ContainerLifecycleHooks.PreTerminates]
@@ -327,6 +501,7 @@ func (c *DockerContainer) Terminate(ctx context.Context) error {
 		}),
 		c.terminatedHook(ctx),
 	}
+	c.provider.untrack(sweptContainer, c.ID)
 
 	if c.imageWasBuilt && !c.keepBuiltImage {
 		_, err := c.provider.client.ImageRemove(ctx, c.Image, image.RemoveOptions{
@@ -334,10 +509,13 @@ func (c *DockerContainer) Terminate(ctx context.Context) error {
 			PruneChildren: true,
 		})
 		errs = append(errs, err)
+		c.provider.untrack(sweptImage, c.Image)
 	}
 
+	errs = append(errs, c.removeSnapshots(ctx), c.removeRestartArtifacts(ctx))
+
 	c.sessionID = ""
-	c.isRunning = false
+	c.isRunning.Store(false)
 
 	return errors.Join(errs...)
 }
@@ -713,7 +891,14 @@ func (c *DockerContainer) StartLogProducer(ctx context.Context, opts ...LogProdu
 // Use functional option WithLogProductionTimeout() to override default timeout. If it's
 // lower than 5s and greater than 60s it will be set to 5s or 60s respectively.
 func (c *DockerContainer) startLogProduction(ctx context.Context, opts ...LogProductionOption) error {
-	c.logProductionStop = make(chan struct{}, 1) // buffered channel to avoid blocking
+	// the goroutine selects on its own stop channel, as the field is replaced if the log production is started again
+	stop := make(chan struct{}, 1) // buffered channel to avoid blocking
+
+	c.logProductionMutex.Lock()
+	c.logProductionStop = stop
+	c.logProductionStopped = false
+	c.logProductionMutex.Unlock()
+
 	c.logProductionWaitGroup.Add(1)
 
 	for _, opt := range opts {
@@ -744,28 +929,61 @@ func (c *DockerContainer) startLogProduction(ctx context.Context, opts ...LogPro
 		}()
 
 		since := ""
-		// if the socket is closed we will make additional logs request with updated Since timestamp
+		// lastTimestamp is the timestamp of the last log received, so a new logs request
+		// neither drops nor repeats any log.
+		var lastTimestamp time.Time
+
+		// stopped returns true if the log production was stopped, it's checked before
+		// every new logs request, as the stop signal is only received between reads.
+		stopped := func() bool {
+			select {
+			case <-stop:
+				return true
+			default:
+				return false
+			}
+		}
+
+		// if the socket is closed, or the log production timeout is reached, e.g. because a paused
+		// container is not producing logs, we will make additional logs request with updated Since timestamp
 	BEGIN:
+		if stopped() {
+			c.logProductionError <- nil
+			return
+		}
+
 		options := container.LogsOptions{
 			ShowStdout: true,
 			ShowStderr: true,
 			Follow:     true,
 			Since:      since,
+			Timestamps: true,
 		}
 
-		ctx, cancel := context.WithTimeout(ctx, *c.logProductionTimeout)
-		defer cancel()
+		timeoutCtx, cancel := context.WithTimeout(ctx, *c.logProductionTimeout)
 
-		r, err := c.provider.client.ContainerLogs(ctx, c.GetContainerID(), options)
+		r, err := c.provider.client.ContainerLogs(timeoutCtx, c.GetContainerID(), options)
 		if err != nil {
+			cancel()
 			c.logProductionError <- err
 			return
 		}
-		defer c.provider.Close()
+
+		// reconnect closes the current logs request, so a new one starts right after the last log received.
+		reconnect := func() {
+			_ = r.Close()
+			cancel()
+
+			if !lastTimestamp.IsZero() {
+				next := lastTimestamp.Add(time.Nanosecond)
+				since = fmt.Sprintf("%d.%09d", next.Unix(), int64(next.Nanosecond()))
+			}
+		}
 
 		for {
 			select {
-			case <-c.logProductionStop:
+			case <-stop:
+				cancel()
 				c.logProductionError <- r.Close()
 				return
 			default:
@@ -777,16 +995,20 @@ func (c *DockerContainer) startLogProduction(ctx context.Context, opts ...LogPro
 				case err == io.EOF:
 					// No more logs coming
 				case errors.Is(err, net.ErrClosed):
-					now := time.Now()
-					since = fmt.Sprintf("%d.%09d", now.Unix(), int64(now.Nanosecond()))
+					reconnect()
 					goto BEGIN
 				case errors.Is(err, context.DeadlineExceeded) || errors.Is(err, context.Canceled):
-					// Probably safe to continue here
-					continue
+					if ctx.Err() == nil {
+						// Only the log production timeout was reached, so keep following the logs.
+						reconnect()
+						goto BEGIN
+					}
 				default:
 					_, _ = fmt.Fprintf(os.Stderr, "container log error: %+v. %s", err, logStoppedForOutOfSyncMessage)
 					// if we would continue here, the next header-read will result into random data...
 				}
+				_ = r.Close()
+				cancel()
 				return
 			}
 
@@ -809,14 +1031,25 @@ func (c *DockerContainer) startLogProduction(ctx context.Context, opts ...LogPro
 			if err != nil {
 				// TODO: add-logger: use logger to log out this error
 				_, _ = fmt.Fprintf(os.Stderr, "error occurred reading log with known length %s", err.Error())
-				if errors.Is(err, context.DeadlineExceeded) || errors.Is(err, context.Canceled) {
+				if (errors.Is(err, context.DeadlineExceeded) || errors.Is(err, context.Canceled)) && ctx.Err() == nil {
 					// Probably safe to continue here
-					continue
+					reconnect()
+					goto BEGIN
 				}
 				// we can not continue here as the next read most likely will not be the next header
 				_, _ = fmt.Fprintln(os.Stderr, logStoppedForOutOfSyncMessage)
+				_ = r.Close()
+				cancel()
 				return
 			}
+			// the logs are requested with their timestamps, which are removed before consuming them
+			if ts, content, ok := bytes.Cut(b, []byte{' '}); ok {
+				if t, err := time.Parse(time.RFC3339Nano, string(ts)); err == nil {
+					lastTimestamp = t
+					b = content
+				}
+			}
+
 			for _, c := range c.consumers {
 				c.Accept(Log{
 					LogType: logTypes[logType],
@@ -836,9 +1069,19 @@ func (c *DockerContainer) StopLogProducer() error {
 
 // stopLogProduction will stop the concurrent process that is reading logs
 // and sending them to each added LogConsumer
+// It's safe to call it multiple times, as only the first call stops the log production.
 func (c *DockerContainer) stopLogProduction() error {
+	c.logProductionMutex.Lock()
+	if c.logProductionStop == nil || c.logProductionStopped {
+		// the log production was never started, or it has already been stopped
+		c.logProductionMutex.Unlock()
+		return nil
+	}
+
 	// signal the log production to stop
 	c.logProductionStop <- struct{}{}
+	c.logProductionStopped = true
+	c.logProductionMutex.Unlock()
 
 	c.logProductionWaitGroup.Wait()
 
@@ -860,6 +1103,72 @@ func (c *DockerContainer) GetLogProductionErrorChannel() <-chan error {
 	return c.logProductionError
 }
 
+// startStatsProduction will start a concurrent process that will continuously read the
+// resource usage stats of the container and will send them to each stats consumer.
+// The stats are produced until stopStatsProduction is called or the container stops,
+// regardless of the given context being cancelled.
+func (c *DockerContainer) startStatsProduction(ctx context.Context) error {
+	if c.statsProductionCancel != nil {
+		// the stats production is already running
+		return nil
+	}
+
+	ctx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+
+	resp, err := c.provider.client.ContainerStats(ctx, c.GetContainerID(), true)
+	if err != nil {
+		cancel()
+		return fmt.Errorf("container stats: %w", err)
+	}
+
+	c.statsProductionCancel = cancel
+	c.statsProductionDone = make(chan struct{})
+
+	consumers := c.statsConsumers
+	containerID := c.GetContainerID()
+	done := c.statsProductionDone
+
+	go func() {
+		defer close(done)
+		defer resp.Body.Close()
+
+		decoder := json.NewDecoder(resp.Body)
+		for {
+			var s container.StatsResponse
+			if err := decoder.Decode(&s); err != nil {
+				if !errors.Is(err, io.EOF) && ctx.Err() == nil {
+					c.logger.Printf("container stats error: %v", err)
+				}
+
+				return
+			}
+
+			stats := newStats(containerID, s)
+			for _, consumer := range consumers {
+				consumer.Accept(stats)
+			}
+		}
+	}()
+
+	return nil
+}
+
+// stopStatsProduction will stop the concurrent process that is reading the stats,
+// waiting for the last stats to be sent to the consumers.
+// It's safe to call it multiple times, as only the first call stops the stats production.
+func (c *DockerContainer) stopStatsProduction() {
+	if c.statsProductionCancel == nil {
+		// the stats production was never started, or it has already been stopped
+		return
+	}
+
+	c.statsProductionCancel()
+	<-c.statsProductionDone
+
+	c.statsProductionCancel = nil
+	c.statsProductionDone = nil
+}
+
 // DockerNetwork represents a network started using Docker
 type DockerNetwork struct {
 	ID                string // Network ID from Docker
@@ -879,7 +1188,40 @@ func (n *DockerNetwork) Remove(ctx context.Context) error {
 
 	defer n.provider.Close()
 
-	return n.provider.client.NetworkRemove(ctx, n.ID)
+	if err := n.provider.client.NetworkRemove(ctx, n.ID); err != nil {
+		return err
+	}
+	n.provider.untrack(sweptNetwork, n.ID)
+
+	return nil
+}
+
+// Connect attaches the container to the network at runtime, using the given network aliases,
+// so it can reach and be reached by the rest of the containers of the network.
+func (n *DockerNetwork) Connect(ctx context.Context, c Container, aliases ...string) error {
+	defer n.provider.Close()
+
+	err := n.provider.client.NetworkConnect(ctx, n.ID, c.GetContainerID(), &network.EndpointSettings{
+		Aliases: aliases,
+	})
+	if err != nil {
+		return fmt.Errorf("network connect: %w", err)
+	}
+
+	return nil
+}
+
+// Disconnect detaches the container from the network at runtime,
+// so it can't reach nor be reached by the rest of the containers of the network.
+func (n *DockerNetwork) Disconnect(ctx context.Context, c Container) error {
+	defer n.provider.Close()
+
+	err := n.provider.client.NetworkDisconnect(ctx, n.ID, c.GetContainerID(), false)
+	if err != nil {
+		return fmt.Errorf("network disconnect: %w", err)
+	}
+
+	return nil
 }
 
 func (n *DockerNetwork) SetTerminationSignal(signal chan bool) {
@@ -918,7 +1260,22 @@ var _ ContainerProvider = (*DockerProvider)(nil)
 
 // BuildImage will build and image from context and Dockerfile, then return the tag
 func (p *DockerProvider) BuildImage(ctx context.Context, img ImageBuildInfo) (string, error) {
+	var secrets map[string][]byte
+	var sshAgents map[string]string
+	var consumer LogConsumer
+	if b, ok := img.(buildKitInfo); ok {
+		secrets, sshAgents, consumer = b.GetBuildSecrets(), b.GetBuildSSH(), b.GetBuildLogConsumer()
+	}
+
 	var buildOptions types.ImageBuildOptions
+	var reused bool
+	var session *buildSession
+	defer func() {
+		if session != nil {
+			session.close()
+		}
+	}()
+
 	resp, err := backoff.RetryNotifyWithData(
 		func() (types.ImageBuildResponse, error) {
 			var err error
@@ -928,6 +1285,43 @@ func (p *DockerProvider) BuildImage(ctx context.Context, img ImageBuildInfo) (st
 			}
 			defer tryClose(buildOptions.Context) // release resources in any case
 
+			if r, ok := img.(builtImageReuser); ok && r.ShouldReuseBuiltImage() {
+				// the tag is the hash of the build, so an existing image is the same.
+				_, _, err = p.client.ImageInspectWithRaw(ctx, buildOptions.Tags[0])
+				if err == nil {
+					reused = true
+					return types.ImageBuildResponse{}, nil
+				}
+
+				if !errdefs.IsNotFound(err) {
+					if isPermanentClientError(err) {
+						return types.ImageBuildResponse{}, backoff.Permanent(fmt.Errorf("inspect image: %w", err))
+					}
+					return types.ImageBuildResponse{}, err
+				}
+			}
+
+			if buildOptions.Version == types.BuilderBuildKit {
+				// the secrets, SSH agents and registry credentials are requested by the builder
+				// through a session, which is attached again on each attempt.
+				if session != nil {
+					session.close()
+				}
+
+				session, err = newBuildSession(secrets, sshAgents, buildOptions.AuthConfigs)
+				if err != nil {
+					return types.ImageBuildResponse{}, backoff.Permanent(fmt.Errorf("build session: %w", err))
+				}
+
+				if err = session.run(ctx, p.client); err != nil {
+					return types.ImageBuildResponse{}, err
+				}
+
+				buildOptions.SessionID = session.id
+			} else if len(secrets) > 0 || len(sshAgents) > 0 {
+				return types.ImageBuildResponse{}, backoff.Permanent(errors.New("build secrets and SSH agents require BuildKit"))
+			}
+
 			resp, err := p.client.ImageBuild(ctx, buildOptions.Context, buildOptions)
 			if err != nil {
 				if isPermanentClientError(err) {
@@ -947,6 +1341,11 @@ func (p *DockerProvider) BuildImage(ctx context.Context, img ImageBuildInfo) (st
 	if err != nil {
 		return "", err // Error is already wrapped.
 	}
+
+	if reused {
+		p.Logger.Printf("♻️ Reusing image %s, built from the same context", buildOptions.Tags[0])
+		return buildOptions.Tags[0], nil
+	}
 	defer resp.Body.Close()
 
 	output := io.Discard
@@ -954,11 +1353,22 @@ func (p *DockerProvider) BuildImage(ctx context.Context, img ImageBuildInfo) (st
 		output = os.Stderr
 	}
 
+	// the terminal is detected on the printed output, as the writer combining it
+	// with the consumer is never a terminal.
+	termFd, isTerm := term.GetFdInfo(output)
+
+	if consumer != nil {
+		w := newLogConsumerWriter(consumer)
+		defer w.Flush()
+
+		output = io.MultiWriter(output, w)
+	}
+
 	// Always process the output, even if it is not printed
 	// to ensure that errors during the build process are
 	// correctly handled.
-	termFd, isTerm := term.GetFdInfo(output)
-	if err = jsonmessage.DisplayJSONMessagesStream(resp.Body, output, termFd, isTerm, nil); err != nil {
+	progress := newBuildProgress(output)
+	if err = jsonmessage.DisplayJSONMessagesStream(resp.Body, output, termFd, isTerm, progress.handle); err != nil {
 		return "", fmt.Errorf("build image: %w", err)
 	}
 
@@ -1035,7 +1445,16 @@ func (p *DockerProvider) CreateContainer(ctx context.Context, req ContainerReque
 		return nil, err
 	}
 
-	// always append the hub substitutor after the user-defined ones
+	// always append the registry mirror and hub substitutors after the user-defined ones
+	mirrorRules, err := registryMirrorRules(p.config)
+	if err != nil {
+		return nil, fmt.Errorf("registry mirror rules: %w", err)
+	}
+
+	if len(mirrorRules) > 0 {
+		req.ImageSubstitutors = append(req.ImageSubstitutors, NewRegistryMirrorSubstitutor(mirrorRules...))
+	}
+
 	req.ImageSubstitutors = append(req.ImageSubstitutors, newPrependHubRegistry(p.config.HubImageNamePrefix))
 
 	var platform *specs.Platform
@@ -1045,6 +1464,10 @@ func (p *DockerProvider) CreateContainer(ctx context.Context, req ContainerReque
 		if err != nil {
 			return nil, err
 		}
+
+		if !req.ShouldKeepBuiltImage() {
+			p.track(ctx, sweptImage, imageName)
+		}
 	} else {
 		for _, is := range req.ImageSubstitutors {
 			modifiedTag, err := is.Substitute(imageName)
@@ -1066,29 +1489,32 @@ func (p *DockerProvider) CreateContainer(ctx context.Context, req ContainerReque
 			platform = &p
 		}
 
-		var shouldPullImage bool
+		policy, err := p.pullPolicy(req)
+		if err != nil {
+			return nil, err
+		}
 
-		if req.AlwaysPullImage {
-			shouldPullImage = true // If requested always attempt to pull image
-		} else {
-			img, _, err := p.client.ImageInspectWithRaw(ctx, imageName)
-			if err != nil {
-				if client.IsErrNotFound(err) {
-					shouldPullImage = true
-				} else {
-					return nil, err
-				}
-			}
-			if platform != nil && (img.Architecture != platform.Architecture || img.Os != platform.OS) {
-				shouldPullImage = true
+		var local *types.ImageInspect
+		img, _, err := p.client.ImageInspectWithRaw(ctx, imageName)
+		if err != nil {
+			if !client.IsErrNotFound(err) {
+				return nil, err
 			}
+		} else if platform == nil || (img.Architecture == platform.Architecture && img.Os == platform.OS) {
+			// an image for another platform is handled as a missing image
+			local = &img
+		}
+
+		shouldPullImage, err := policy.ShouldPull(imageName, local)
+		if err != nil {
+			return nil, fmt.Errorf("pull policy of image %s: %w", imageName, err)
 		}
 
 		if shouldPullImage {
 			pullOpt := image.PullOptions{
 				Platform: req.ImagePlatform, // may be empty
 			}
-			if err := p.attemptToPullImage(ctx, imageName, pullOpt); err != nil {
+			if err := p.attemptToPullImage(ctx, imageName, pullOpt, req.PullProgressListener); err != nil {
 				return nil, err
 			}
 		}
@@ -1101,6 +1527,14 @@ func (p *DockerProvider) CreateContainer(ctx context.Context, req ContainerReque
 		}
 	}
 
+	// when the reuse is enabled, the reusable containers outlive the session, so they are reused by the
+	// next test runs: they are not labeled with the session, so neither the reaper nor the sweeper remove them
+	outlivesSession := p.config.ReuseEnabled && req.Labels[core.LabelReuseHash] != ""
+	if outlivesSession {
+		delete(req.Labels, core.LabelSessionID)
+		delete(req.Labels, core.LabelSessionOwner)
+	}
+
 	dockerInput := &container.Config{
 		Entrypoint: req.Entrypoint,
 		Image:      imageName,
@@ -1126,6 +1560,8 @@ func (p *DockerProvider) CreateContainer(ctx context.Context, req ContainerReque
 		defaultPreCreateHook(p, dockerInput, hostConfig, networkingConfig),
 		defaultCopyFileToContainerHook(req.Files),
 		defaultLogConsumersHook(req.LogConsumerCfg),
+		defaultStatsConsumersHook(req.StatsConsumerCfg),
+		defaultExitWatcherHook(),
 		defaultReadinessHook(),
 	}
 
@@ -1154,6 +1590,9 @@ func (p *DockerProvider) CreateContainer(ctx context.Context, req ContainerReque
 	if err != nil {
 		return nil, fmt.Errorf("container create: %w", err)
 	}
+	if !outlivesSession {
+		p.track(ctx, sweptContainer, resp.ID)
+	}
 
 	// #248: If there is more than one network specified in the request attach newly created container to them one by one
 	if len(req.Networks) > 1 {
@@ -1243,7 +1682,15 @@ func (p *DockerProvider) waitContainerCreation(ctx context.Context, name string)
 	)
 }
 
+// ReuseOrCreateContainer reuses the running container with the name of the request, or named after
+// the hash of the request if it has no name, otherwise it creates a new one. A container created
+// from a different request is not reused, returning ErrReuseRequestChanged.
 func (p *DockerProvider) ReuseOrCreateContainer(ctx context.Context, req ContainerRequest) (Container, error) {
+	hash, err := req.prepareReuse()
+	if err != nil {
+		return nil, err
+	}
+
 	c, err := p.findContainerByName(ctx, req.Name)
 	if err != nil {
 		return nil, err
@@ -1262,6 +1709,10 @@ func (p *DockerProvider) ReuseOrCreateContainer(ctx context.Context, req Contain
 		}
 	}
 
+	if err := checkReuseHash(req.Name, c.Labels, hash); err != nil {
+		return nil, err
+	}
+
 	sessionID := core.SessionID()
 
 	var termSignal chan bool
@@ -1281,6 +1732,8 @@ func (p *DockerProvider) ReuseOrCreateContainer(ctx context.Context, req Contain
 		DefaultLoggingHook(p.Logger),
 		defaultReadinessHook(),
 		defaultLogConsumersHook(req.LogConsumerCfg),
+		defaultStatsConsumersHook(req.StatsConsumerCfg),
+		defaultExitWatcherHook(),
 	}
 
 	dc := &DockerContainer{
@@ -1300,7 +1753,7 @@ func (p *DockerProvider) ReuseOrCreateContainer(ctx context.Context, req Contain
 		return nil, err
 	}
 
-	dc.isRunning = true
+	dc.isRunning.Store(true)
 
 	err = dc.readiedHook(ctx)
 	if err != nil {
@@ -1312,7 +1765,22 @@ func (p *DockerProvider) ReuseOrCreateContainer(ctx context.Context, req Contain
 
 // attemptToPullImage tries to pull the image while respecting the ctx cancellations.
 // Besides, if the image cannot be pulled due to ErrorNotFound then no need to retry but terminate immediately.
-func (p *DockerProvider) attemptToPullImage(ctx context.Context, tag string, pullOpt image.PullOptions) error {
+func (p *DockerProvider) attemptToPullImage(ctx context.Context, tag string, pullOpt image.PullOptions, listener PullProgressListener) error {
+	// the image archives are consulted before pulling the image from the registry
+	loaded, err := p.loadImageFromArchives(ctx, tag)
+	if err != nil {
+		return err
+	}
+
+	if loaded {
+		pulledImages.Store(tag, time.Now())
+		return nil
+	}
+
+	if p.config.Offline {
+		return fmt.Errorf("pull image %s: %w", tag, ErrOffline)
+	}
+
 	registry, imageAuth, err := DockerImageAuth(ctx, tag)
 	if err != nil {
 		p.Logger.Printf("Failed to get image auth for %s. Setting empty credentials for the image: %s. Error is: %s", registry, tag, err)
@@ -1326,6 +1794,11 @@ func (p *DockerProvider) attemptToPullImage(ctx context.Context, tag string, pul
 		}
 	}
 
+	if listener == nil {
+		listener = p.pullProgressListener()
+	}
+	tracker := newPullTracker(tag, listener)
+
 	var pull io.ReadCloser
 	err = backoff.RetryNotify(
 		func() error {
@@ -1343,6 +1816,7 @@ func (p *DockerProvider) attemptToPullImage(ctx context.Context, tag string, pul
 		backoff.WithContext(backoff.NewExponentialBackOff(), ctx),
 		func(err error, duration time.Duration) {
 			p.Logger.Printf("Failed to pull image: %s, will retry", err)
+			tracker.retry(err)
 		},
 	)
 	if err != nil {
@@ -1351,8 +1825,41 @@ func (p *DockerProvider) attemptToPullImage(ctx context.Context, tag string, pul
 	defer pull.Close()
 
 	// download of docker image finishes at EOF of the pull request
-	_, err = io.ReadAll(pull)
-	return err
+	if err = tracker.read(pull); err != nil {
+		return err
+	}
+
+	pulledImages.Store(tag, time.Now())
+
+	return nil
+}
+
+// pullPolicy returns the pull policy of the request, which defaults to the pull policy of the configuration.
+func (p *DockerProvider) pullPolicy(req ContainerRequest) (PullPolicy, error) {
+	if req.AlwaysPullImage {
+		return PullAlways(), nil
+	}
+
+	if req.PullPolicy != nil {
+		return req.PullPolicy, nil
+	}
+
+	policy, err := ParsePullPolicy(p.config.PullPolicy)
+	if err != nil {
+		return nil, fmt.Errorf("pull policy of the configuration: %w", err)
+	}
+
+	return policy, nil
+}
+
+// pullProgressListener returns the listener of the progress of the image pulls of the provider,
+// which defaults to logging summaries to the logger of the provider.
+func (p *DockerProvider) pullProgressListener() PullProgressListener {
+	if p.PullProgressListener != nil {
+		return p.PullProgressListener
+	}
+
+	return NewLoggingPullProgressListener(p.Logger, defaultPullProgressInterval)
 }
 
 // Health measure the healthiness of the provider. Right now we leverage the
@@ -1499,6 +2006,8 @@ func (p *DockerProvider) CreateNetwork(ctx context.Context, req NetworkRequest)
 		return &DockerNetwork{}, err
 	}
 
+	p.track(ctx, sweptNetwork, response.ID)
+
 	n := &DockerNetwork{
 		ID:                response.ID,
 		Driver:            req.Driver,
@@ -1611,7 +2120,7 @@ func containerFromDockerResponse(ctx context.Context, response types.Container)
 
 	ctr.sessionID = core.SessionID()
 	ctr.consumers = []LogConsumer{}
-	ctr.isRunning = response.State == "running"
+	ctr.isRunning.Store(response.State == "running")
 
 	// the termination signal should be obtained from the reaper
 	ctr.terminationSignal = nil
@@ -1676,9 +2185,148 @@ func (p *DockerProvider) SaveImages(ctx context.Context, output string, images .
 	return nil
 }
 
+// LoadImages imports the images of a tar archive, as created by SaveImages or docker save,
+// which can be compressed with gzip
+func (p *DockerProvider) LoadImages(ctx context.Context, input string) error {
+	inputFile, err := os.Open(input)
+	if err != nil {
+		return fmt.Errorf("opening input file %w", err)
+	}
+	defer func() {
+		_ = inputFile.Close()
+	}()
+
+	resp, err := p.client.ImageLoad(ctx, inputFile, true)
+	if err != nil {
+		return fmt.Errorf("loading images %w", err)
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	if !resp.JSON {
+		_, err = io.Copy(io.Discard, resp.Body)
+		return err
+	}
+
+	// the errors of the load are reported in the messages of the response
+	if err = jsonmessage.DisplayJSONMessagesStream(resp.Body, io.Discard, 0, false, nil); err != nil {
+		return fmt.Errorf("loading images %w", err)
+	}
+
+	return nil
+}
+
+// loadImageFromArchives loads the image from the first archive of the image archives directory
+// of the configuration containing it, returning false if no archive contains it.
+func (p *DockerProvider) loadImageFromArchives(ctx context.Context, img string) (bool, error) {
+	if p.config.ImageArchivesDir == "" {
+		return false, nil
+	}
+
+	archive, err := findImageArchive(p.Logger, p.config.ImageArchivesDir, img)
+	if err != nil || archive == "" {
+		return false, err
+	}
+
+	p.Logger.Printf("📦 Loading image %s from archive %s", img, archive)
+	if err = p.LoadImages(ctx, archive); err != nil {
+		return false, fmt.Errorf("load image %s from archive %s: %w", img, archive, err)
+	}
+
+	return true, nil
+}
+
 // PullImage pulls image from registry
 func (p *DockerProvider) PullImage(ctx context.Context, img string) error {
-	return p.attemptToPullImage(ctx, img, image.PullOptions{})
+	return p.attemptToPullImage(ctx, img, image.PullOptions{}, nil)
+}
+
+// InspectImage returns the details of an image, identified by its name or ID
+func (p *DockerProvider) InspectImage(ctx context.Context, img string) (ImageDetails, error) {
+	inspect, _, err := p.client.ImageInspectWithRaw(ctx, img)
+	if err != nil {
+		return ImageDetails{}, fmt.Errorf("inspecting image %w", err)
+	}
+
+	details := ImageDetails{
+		ID:       inspect.ID,
+		RepoTags: inspect.RepoTags,
+		Digests:  inspect.RepoDigests,
+		Platform: specs.Platform{
+			OS:           inspect.Os,
+			Architecture: inspect.Architecture,
+			Variant:      inspect.Variant,
+		},
+		Size: inspect.Size,
+	}
+
+	if inspect.Config != nil {
+		details.Labels = inspect.Config.Labels
+	}
+
+	if created, err := time.Parse(time.RFC3339Nano, inspect.Created); err == nil {
+		details.Created = created
+	}
+
+	return details, nil
+}
+
+// TagImage adds the target tag to the source image, identified by its name or ID
+func (p *DockerProvider) TagImage(ctx context.Context, source string, target string) error {
+	if err := p.client.ImageTag(ctx, source, target); err != nil {
+		return fmt.Errorf("tagging image %w", err)
+	}
+
+	return nil
+}
+
+// RemoveImage removes an image, identified by its name or ID. Removing a tag of an image
+// with other tags only removes the tag. The removal of an image used by a container fails,
+// unless it's forced with [RemoveImageForce] and the container is not running.
+func (p *DockerProvider) RemoveImage(ctx context.Context, img string, opts ...RemoveImageOption) error {
+	options := &removeImageOptions{}
+	for _, opt := range opts {
+		opt(options)
+	}
+
+	_, err := p.client.ImageRemove(ctx, img, image.RemoveOptions{
+		Force:         options.force,
+		PruneChildren: true,
+	})
+	if err != nil {
+		return fmt.Errorf("removing image %w", err)
+	}
+
+	return nil
+}
+
+// PruneImages removes the images built by testcontainers, labeled with core.LabelBuilt,
+// created more than olderThan ago and not used by any container, except the ones of the
+// current session. It returns the IDs of the removed images.
+func (p *DockerProvider) PruneImages(ctx context.Context, olderThan time.Duration) ([]string, error) {
+	report, err := p.client.ImagesPrune(ctx, filters.NewArgs(
+		filters.Arg("dangling", "false"),
+		filters.Arg("label", core.LabelBuilt+"=true"),
+		filters.Arg("label!", core.LabelSessionID+"="+core.SessionID()),
+		filters.Arg("until", olderThan.String()),
+	))
+	if err != nil {
+		return nil, fmt.Errorf("pruning images %w", err)
+	}
+
+	var deleted []string
+	for _, r := range report.ImagesDeleted {
+		if r.Deleted != "" {
+			deleted = append(deleted, r.Deleted)
+		}
+	}
+
+	if len(deleted) > 0 {
+		p.Logger.Printf("🧹 Pruned %d images built by testcontainers (%s)", len(deleted), units.HumanSize(float64(report.SpaceReclaimed)))
+	}
+
+	return deleted, nil
 }
 
 var permanentClientErrors = []func(error) bool{
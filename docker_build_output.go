@@ -0,0 +1,298 @@
+package testcontainers
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/docker/docker/pkg/jsonmessage"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// buildTraceID is the ID of the messages of the build output holding
+// the progress of the BuildKit builder, encoded as protobuf.
+const buildTraceID = "moby.buildkit.trace"
+
+// buildProgress writes the progress of the BuildKit builder as plain text,
+// like the plain progress output of the Docker CLI, e.g.:
+//
+//	#1 [internal] load build definition from Dockerfile
+//	#1 DONE 0.1s
+type buildProgress struct {
+	w        io.Writer
+	vertices map[string]*buildVertex
+}
+
+// buildVertex is a step of a build, identified by its digest.
+type buildVertex struct {
+	index     int
+	started   bool
+	completed bool
+}
+
+// newBuildProgress returns the progress of a build, written to w.
+func newBuildProgress(w io.Writer) *buildProgress {
+	return &buildProgress{w: w, vertices: map[string]*buildVertex{}}
+}
+
+// handle writes the progress held by an auxiliary message of the build output.
+// Other auxiliary messages, like the ID of the built image, and malformed
+// messages are ignored, as they are not needed to build the image.
+func (p *buildProgress) handle(msg jsonmessage.JSONMessage) {
+	if msg.ID != buildTraceID || msg.Aux == nil {
+		return
+	}
+
+	// the protobuf message is encoded as a base64 string
+	var data []byte
+	if err := json.Unmarshal(*msg.Aux, &data); err != nil {
+		return
+	}
+
+	_ = p.write(data)
+}
+
+// write writes the vertices, logs and warnings of a moby.buildkit.v1.StatusResponse message.
+func (p *buildProgress) write(data []byte) error {
+	return consumeFields(data, func(num protowire.Number, v []byte) error {
+		switch num {
+		case 1: // vertexes
+			return p.writeVertex(v)
+		case 3: // logs
+			return p.writeLog(v)
+		case 4: // warnings
+			return p.writeWarning(v)
+		}
+
+		return nil
+	})
+}
+
+// writeVertex writes the state of a moby.buildkit.v1.Vertex message.
+func (p *buildProgress) writeVertex(data []byte) error {
+	var digest, name, errMsg string
+	var cached bool
+	var started, completed time.Time
+
+	err := consumeFields(data, func(num protowire.Number, v []byte) error {
+		switch num {
+		case 1:
+			digest = string(v)
+		case 3:
+			name = string(v)
+		case 4:
+			cached = len(v) > 0 && v[0] != 0
+		case 5:
+			started = decodeTimestamp(v)
+		case 6:
+			completed = decodeTimestamp(v)
+		case 7:
+			errMsg = string(v)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	vtx := p.vertex(digest)
+
+	if !vtx.started && (!started.IsZero() || cached) {
+		vtx.started = true
+		fmt.Fprintf(p.w, "#%d %s\n", vtx.index, name)
+	}
+
+	if vtx.completed || completed.IsZero() {
+		return nil
+	}
+	vtx.completed = true
+
+	switch {
+	case errMsg != "":
+		fmt.Fprintf(p.w, "#%d ERROR: %s\n", vtx.index, errMsg)
+	case cached:
+		fmt.Fprintf(p.w, "#%d CACHED\n", vtx.index)
+	default:
+		fmt.Fprintf(p.w, "#%d DONE %.1fs\n", vtx.index, completed.Sub(started).Seconds())
+	}
+
+	return nil
+}
+
+// writeLog writes the output of a moby.buildkit.v1.VertexLog message, line by line.
+func (p *buildProgress) writeLog(data []byte) error {
+	var digest string
+	var msg []byte
+
+	err := consumeFields(data, func(num protowire.Number, v []byte) error {
+		switch num {
+		case 1:
+			digest = string(v)
+		case 4:
+			msg = v
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	vtx := p.vertex(digest)
+	for _, line := range strings.Split(strings.TrimRight(string(msg), "\n"), "\n") {
+		fmt.Fprintf(p.w, "#%d %s\n", vtx.index, line)
+	}
+
+	return nil
+}
+
+// writeWarning writes the short description of a moby.buildkit.v1.VertexWarning message.
+func (p *buildProgress) writeWarning(data []byte) error {
+	var digest string
+	var short []byte
+
+	err := consumeFields(data, func(num protowire.Number, v []byte) error {
+		switch num {
+		case 1:
+			digest = string(v)
+		case 3:
+			short = v
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(p.w, "#%d WARNING: %s\n", p.vertex(digest).index, short)
+
+	return nil
+}
+
+// vertex returns the vertex with the given digest, numbered in order of appearance.
+func (p *buildProgress) vertex(digest string) *buildVertex {
+	vtx, ok := p.vertices[digest]
+	if !ok {
+		vtx = &buildVertex{index: len(p.vertices) + 1}
+		p.vertices[digest] = vtx
+	}
+
+	return vtx
+}
+
+// consumeFields calls fn with the number and the value of each field of a protobuf message,
+// where the value of the varint fields is their encoded form, and the other fields are skipped.
+func consumeFields(data []byte, fn func(num protowire.Number, v []byte) error) error {
+	for len(data) > 0 {
+		num, typ, n := protowire.ConsumeTag(data)
+		if n < 0 {
+			return protowire.ParseError(n)
+		}
+		data = data[n:]
+
+		var v []byte
+		switch typ {
+		case protowire.BytesType:
+			v, n = protowire.ConsumeBytes(data)
+		case protowire.VarintType:
+			var u uint64
+			u, n = protowire.ConsumeVarint(data)
+			v = protowire.AppendVarint(nil, u)
+		default:
+			n = protowire.ConsumeFieldValue(num, typ, data)
+		}
+		if n < 0 {
+			return protowire.ParseError(n)
+		}
+		data = data[n:]
+
+		if v == nil {
+			continue
+		}
+
+		if err := fn(num, v); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// decodeTimestamp decodes a google.protobuf.Timestamp message, returning the zero time if it's malformed.
+func decodeTimestamp(data []byte) time.Time {
+	var seconds, nanos int64
+
+	err := consumeFields(data, func(num protowire.Number, v []byte) error {
+		u, n := protowire.ConsumeVarint(v)
+		if n < 0 {
+			return protowire.ParseError(n)
+		}
+
+		switch num {
+		case 1:
+			seconds = int64(u)
+		case 2:
+			nanos = int64(u)
+		}
+		return nil
+	})
+	if err != nil {
+		return time.Time{}
+	}
+
+	return time.Unix(seconds, nanos)
+}
+
+// logConsumerWriter is an io.Writer sending the written lines
+// to a log consumer, as logs of type StdoutLog.
+type logConsumerWriter struct {
+	mx       sync.Mutex
+	consumer LogConsumer
+	buf      bytes.Buffer
+}
+
+// newLogConsumerWriter returns a writer sending the written lines to the consumer.
+func newLogConsumerWriter(consumer LogConsumer) *logConsumerWriter {
+	return &logConsumerWriter{consumer: consumer}
+}
+
+// Write implements io.Writer, sending the complete lines to the consumer.
+func (w *logConsumerWriter) Write(p []byte) (int, error) {
+	w.mx.Lock()
+	defer w.mx.Unlock()
+
+	w.buf.Write(p)
+
+	for {
+		i := bytes.IndexByte(w.buf.Bytes(), '\n')
+		if i < 0 {
+			break
+		}
+
+		line := make([]byte, i+1)
+		copy(line, w.buf.Next(i+1))
+
+		w.consumer.Accept(Log{LogType: StdoutLog, Content: line})
+	}
+
+	return len(p), nil
+}
+
+// Flush sends the last line to the consumer, if it does not end with a new line.
+func (w *logConsumerWriter) Flush() {
+	w.mx.Lock()
+	defer w.mx.Unlock()
+
+	if w.buf.Len() == 0 {
+		return
+	}
+
+	line := make([]byte, w.buf.Len())
+	copy(line, w.buf.Bytes())
+	w.buf.Reset()
+
+	w.consumer.Accept(Log{LogType: StdoutLog, Content: line})
+}
@@ -0,0 +1,151 @@
+package testcontainers
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/docker/docker/pkg/jsonmessage"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+func TestBuildProgress(t *testing.T) {
+	start := time.Unix(1700000000, 0)
+
+	timestamp := func(ts time.Time) []byte {
+		var b []byte
+		b = protowire.AppendTag(b, 1, protowire.VarintType)
+		b = protowire.AppendVarint(b, uint64(ts.Unix()))
+		b = protowire.AppendTag(b, 2, protowire.VarintType)
+		b = protowire.AppendVarint(b, uint64(ts.Nanosecond()))
+		return b
+	}
+
+	type vertex struct {
+		digest    string
+		name      string
+		cached    bool
+		started   time.Time
+		completed time.Time
+		err       string
+	}
+
+	appendBytes := func(b []byte, num protowire.Number, v []byte) []byte {
+		b = protowire.AppendTag(b, num, protowire.BytesType)
+		return protowire.AppendBytes(b, v)
+	}
+
+	encodeVertex := func(v vertex) []byte {
+		var b []byte
+		b = appendBytes(b, 1, []byte(v.digest))
+		b = appendBytes(b, 3, []byte(v.name))
+		if v.cached {
+			b = protowire.AppendTag(b, 4, protowire.VarintType)
+			b = protowire.AppendVarint(b, 1)
+		}
+		if !v.started.IsZero() {
+			b = appendBytes(b, 5, timestamp(v.started))
+		}
+		if !v.completed.IsZero() {
+			b = appendBytes(b, 6, timestamp(v.completed))
+		}
+		if v.err != "" {
+			b = appendBytes(b, 7, []byte(v.err))
+		}
+		return b
+	}
+
+	encodeLog := func(digest string, msg string) []byte {
+		var b []byte
+		b = appendBytes(b, 1, []byte(digest))
+		b = protowire.AppendTag(b, 2, protowire.BytesType)
+		b = protowire.AppendBytes(b, timestamp(start))
+		b = protowire.AppendTag(b, 3, protowire.VarintType)
+		b = protowire.AppendVarint(b, 1)
+		b = appendBytes(b, 4, []byte(msg))
+		return b
+	}
+
+	trace := func(fields ...func([]byte) []byte) jsonmessage.JSONMessage {
+		var b []byte
+		for _, f := range fields {
+			b = f(b)
+		}
+
+		aux, err := json.Marshal(b)
+		require.NoError(t, err)
+
+		raw := json.RawMessage(aux)
+		return jsonmessage.JSONMessage{ID: buildTraceID, Aux: &raw}
+	}
+
+	withVertex := func(v vertex) func([]byte) []byte {
+		return func(b []byte) []byte {
+			return appendBytes(b, 1, encodeVertex(v))
+		}
+	}
+
+	withLog := func(digest string, msg string) func([]byte) []byte {
+		return func(b []byte) []byte {
+			return appendBytes(b, 3, encodeLog(digest, msg))
+		}
+	}
+
+	var buf bytes.Buffer
+	progress := newBuildProgress(&buf)
+
+	progress.handle(trace(
+		withVertex(vertex{digest: "sha256:a", name: "[1/2] FROM docker.io/alpine", started: start}),
+		withVertex(vertex{digest: "sha256:b", name: "[2/2] RUN echo hello", started: start}),
+	))
+	progress.handle(trace(
+		withVertex(vertex{digest: "sha256:a", name: "[1/2] FROM docker.io/alpine", cached: true, started: start, completed: start}),
+		withLog("sha256:b", "hello\nworld\n"),
+	))
+	progress.handle(trace(
+		withVertex(vertex{digest: "sha256:b", name: "[2/2] RUN echo hello", started: start, completed: start.Add(1500 * time.Millisecond)}),
+		withVertex(vertex{digest: "sha256:c", name: "[3/3] RUN exit 1", started: start, completed: start, err: "exit code: 1"}),
+	))
+
+	// the ID of the built image and the messages of the legacy builder are ignored
+	imageID := json.RawMessage(`{"ID":"sha256:abc"}`)
+	progress.handle(jsonmessage.JSONMessage{ID: "moby.image.id", Aux: &imageID})
+	progress.handle(jsonmessage.JSONMessage{Aux: &imageID})
+
+	require.Equal(t, `#1 [1/2] FROM docker.io/alpine
+#2 [2/2] RUN echo hello
+#1 CACHED
+#2 hello
+#2 world
+#2 DONE 1.5s
+#3 [3/3] RUN exit 1
+#3 ERROR: exit code: 1
+`, buf.String())
+}
+
+type testBuildLogConsumer struct {
+	logs []Log
+}
+
+func (c *testBuildLogConsumer) Accept(l Log) {
+	c.logs = append(c.logs, l)
+}
+
+func TestLogConsumerWriter(t *testing.T) {
+	consumer := &testBuildLogConsumer{}
+	w := newLogConsumerWriter(consumer)
+
+	_, err := w.Write([]byte("Step 1/2 : FROM alpine\nStep 2/2"))
+	require.NoError(t, err)
+	_, err = w.Write([]byte(" : RUN true\nSuccessfully built"))
+	require.NoError(t, err)
+	w.Flush()
+
+	require.Equal(t, []Log{
+		{LogType: StdoutLog, Content: []byte("Step 1/2 : FROM alpine\n")},
+		{LogType: StdoutLog, Content: []byte("Step 2/2 : RUN true\n")},
+		{LogType: StdoutLog, Content: []byte("Successfully built")},
+	}, consumer.logs)
+}
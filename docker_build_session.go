@@ -0,0 +1,478 @@
+package testcontainers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"sync"
+
+	"github.com/docker/docker/api/types/registry"
+	"github.com/docker/docker/client"
+	"github.com/google/uuid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protowire"
+
+	"github.com/testcontainers/testcontainers-go/internal/core"
+)
+
+// The headers used to attach a session to the BuildKit builder of the Docker daemon,
+// as defined by github.com/moby/buildkit/session.
+const (
+	buildSessionHeaderID        = "X-Docker-Expose-Session-Uuid"
+	buildSessionHeaderName      = "X-Docker-Expose-Session-Name"
+	buildSessionHeaderSharedKey = "X-Docker-Expose-Session-Sharedkey"
+	buildSessionHeaderMethod    = "X-Docker-Expose-Session-Grpc-Method"
+)
+
+// buildSessionSSHIDKey is the gRPC metadata key of the ID of the SSH agent to forward.
+const buildSessionSSHIDKey = "buildkit.ssh.id"
+
+// defaultBuildSSHID is the ID of the SSH agent used when no ID is set in the Dockerfile.
+const defaultBuildSSHID = "default"
+
+// buildSession is a BuildKit session attached to an image build, serving the build secrets,
+// the SSH agents and the registry credentials requested by the builder of the Docker daemon.
+// The builder calls the gRPC services of the session through the connection opened by the
+// session, so it implements the services of BuildKit it needs, using their wire format.
+type buildSession struct {
+	id      string
+	secrets map[string][]byte
+	ssh     map[string]string
+	auths   map[string]registry.AuthConfig
+
+	server *grpc.Server
+}
+
+// newBuildSession returns a session serving the given secrets, SSH agent sockets and registry credentials.
+// An empty path of an SSH agent socket is replaced with the value of the SSH_AUTH_SOCK environment variable.
+func newBuildSession(secrets map[string][]byte, ssh map[string]string, auths map[string]registry.AuthConfig) (*buildSession, error) {
+	agents := make(map[string]string, len(ssh))
+	for id, socket := range ssh {
+		if socket == "" {
+			socket = os.Getenv("SSH_AUTH_SOCK")
+			if socket == "" {
+				return nil, fmt.Errorf("SSH agent %s: SSH_AUTH_SOCK is not set", id)
+			}
+		}
+		agents[id] = socket
+	}
+
+	s := &buildSession{
+		id:      uuid.NewString(),
+		secrets: secrets,
+		ssh:     agents,
+		auths:   auths,
+	}
+
+	s.server = grpc.NewServer(grpc.ForceServerCodec(buildSessionCodec{}))
+	for _, sd := range s.services() {
+		s.server.RegisterService(sd, s)
+	}
+
+	return s, nil
+}
+
+// run attaches the session to the Docker daemon, serving the requests of the builder
+// until the session is closed.
+func (s *buildSession) run(ctx context.Context, cli client.APIClient) error {
+	meta := map[string][]string{
+		buildSessionHeaderID:        {s.id},
+		buildSessionHeaderName:      {"testcontainers"},
+		buildSessionHeaderSharedKey: {s.id},
+	}
+
+	for _, sd := range s.services() {
+		for _, m := range sd.Methods {
+			meta[buildSessionHeaderMethod] = append(meta[buildSessionHeaderMethod], "/"+sd.ServiceName+"/"+m.MethodName)
+		}
+		for _, st := range sd.Streams {
+			meta[buildSessionHeaderMethod] = append(meta[buildSessionHeaderMethod], "/"+sd.ServiceName+"/"+st.StreamName)
+		}
+	}
+
+	conn, err := cli.DialHijack(ctx, "/session", "h2c", meta)
+	if err != nil {
+		return fmt.Errorf("attach build session: %w", err)
+	}
+
+	go func() {
+		// the server closes the connection when it's stopped.
+		_ = s.server.Serve(newSingleConnListener(conn))
+	}()
+
+	return nil
+}
+
+// close stops serving the requests of the builder, closing the connection.
+func (s *buildSession) close() {
+	s.server.Stop()
+}
+
+// services returns the gRPC services of BuildKit implemented by the session.
+func (s *buildSession) services() []*grpc.ServiceDesc {
+	services := []*grpc.ServiceDesc{
+		{
+			ServiceName: "grpc.health.v1.Health",
+			HandlerType: (*any)(nil),
+			Methods: []grpc.MethodDesc{
+				{MethodName: "Check", Handler: unaryBuildSessionHandler(s.healthCheck)},
+			},
+		},
+		{
+			ServiceName: "moby.filesync.v1.Auth",
+			HandlerType: (*any)(nil),
+			Methods: []grpc.MethodDesc{
+				{MethodName: "Credentials", Handler: unaryBuildSessionHandler(s.credentials)},
+			},
+		},
+	}
+
+	if len(s.secrets) > 0 {
+		services = append(services, &grpc.ServiceDesc{
+			ServiceName: "moby.buildkit.secrets.v1.Secrets",
+			HandlerType: (*any)(nil),
+			Methods: []grpc.MethodDesc{
+				{MethodName: "GetSecret", Handler: unaryBuildSessionHandler(s.getSecret)},
+			},
+		})
+	}
+
+	if len(s.ssh) > 0 {
+		services = append(services, &grpc.ServiceDesc{
+			ServiceName: "moby.sshforward.v1.SSH",
+			HandlerType: (*any)(nil),
+			Methods: []grpc.MethodDesc{
+				{MethodName: "CheckAgent", Handler: unaryBuildSessionHandler(s.checkAgent)},
+			},
+			Streams: []grpc.StreamDesc{
+				{
+					StreamName:    "ForwardAgent",
+					ServerStreams: true,
+					ClientStreams: true,
+					Handler: func(_ any, stream grpc.ServerStream) error {
+						return s.forwardAgent(stream)
+					},
+				},
+			},
+		})
+	}
+
+	return services
+}
+
+// healthCheck reports the session as serving, otherwise the builder closes it.
+func (s *buildSession) healthCheck(_ context.Context, _ *buildSessionMessage) (*buildSessionMessage, error) {
+	// grpc.health.v1.HealthCheckResponse_SERVING
+	return &buildSessionMessage{varints: map[protowire.Number]uint64{1: 1}}, nil
+}
+
+// credentials returns the credentials of the registry with the host of the request, if any.
+func (s *buildSession) credentials(_ context.Context, req *buildSessionMessage) (*buildSessionMessage, error) {
+	host := req.string(1)
+
+	if host == "registry-1.docker.io" {
+		// the host of Docker Hub used by BuildKit
+		host = core.IndexDockerIO
+	}
+
+	resp := &buildSessionMessage{}
+
+	auth, ok := getRegistryAuth(host, s.auths)
+	if !ok {
+		return resp, nil
+	}
+
+	if auth.IdentityToken != "" {
+		resp.setString(2, auth.IdentityToken)
+		return resp, nil
+	}
+
+	resp.setString(1, auth.Username)
+	resp.setString(2, auth.Password)
+
+	return resp, nil
+}
+
+// getSecret returns the build secret with the ID of the request.
+func (s *buildSession) getSecret(_ context.Context, req *buildSessionMessage) (*buildSessionMessage, error) {
+	id := req.string(1)
+
+	data, ok := s.secrets[id]
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "secret %s not found", id)
+	}
+
+	resp := &buildSessionMessage{}
+	resp.setBytes(1, data)
+
+	return resp, nil
+}
+
+// checkAgent checks that an SSH agent with the ID of the request is forwarded.
+func (s *buildSession) checkAgent(_ context.Context, req *buildSessionMessage) (*buildSessionMessage, error) {
+	id := req.string(1)
+	if id == "" {
+		id = defaultBuildSSHID
+	}
+
+	if _, ok := s.ssh[id]; !ok {
+		return nil, status.Errorf(codes.NotFound, "SSH agent %s not found", id)
+	}
+
+	return &buildSessionMessage{}, nil
+}
+
+// forwardAgent forwards the stream to the socket of the SSH agent with the ID of the stream metadata.
+func (s *buildSession) forwardAgent(stream grpc.ServerStream) error {
+	id := defaultBuildSSHID
+	if md, ok := metadata.FromIncomingContext(stream.Context()); ok {
+		if ids := md.Get(buildSessionSSHIDKey); len(ids) > 0 && ids[0] != "" {
+			id = ids[0]
+		}
+	}
+
+	socket, ok := s.ssh[id]
+	if !ok {
+		return status.Errorf(codes.NotFound, "SSH agent %s not found", id)
+	}
+
+	conn, err := net.Dial("unix", socket)
+	if err != nil {
+		return status.Errorf(codes.Unavailable, "connect to SSH agent %s: %v", id, err)
+	}
+	defer conn.Close()
+
+	errs := make(chan error, 2)
+
+	go func() {
+		for {
+			msg := &buildSessionMessage{}
+			if err := stream.RecvMsg(msg); err != nil {
+				if errors.Is(err, io.EOF) {
+					err = nil
+				}
+				errs <- err
+				return
+			}
+
+			if _, err := conn.Write(msg.bytes(1)); err != nil {
+				errs <- err
+				return
+			}
+		}
+	}()
+
+	go func() {
+		buf := make([]byte, 32*1024)
+		for {
+			n, err := conn.Read(buf)
+			if n > 0 {
+				msg := &buildSessionMessage{}
+				msg.setBytes(1, buf[:n])
+				if err := stream.SendMsg(msg); err != nil {
+					errs <- err
+					return
+				}
+			}
+
+			if err != nil {
+				if errors.Is(err, io.EOF) {
+					err = nil
+				}
+				errs <- err
+				return
+			}
+		}
+	}()
+
+	return <-errs
+}
+
+// unaryBuildSessionHandler returns the gRPC handler of a unary method of the session.
+func unaryBuildSessionHandler(fn func(context.Context, *buildSessionMessage) (*buildSessionMessage, error)) func(any, context.Context, func(any) error, grpc.UnaryServerInterceptor) (any, error) {
+	return func(_ any, ctx context.Context, dec func(any) error, _ grpc.UnaryServerInterceptor) (any, error) {
+		req := &buildSessionMessage{}
+		if err := dec(req); err != nil {
+			return nil, err
+		}
+
+		return fn(ctx, req)
+	}
+}
+
+// buildSessionMessage is a protobuf message of the services of the session. The messages of those
+// services only have scalar fields, so they are encoded and decoded by field number.
+type buildSessionMessage struct {
+	fields  map[protowire.Number][]byte
+	varints map[protowire.Number]uint64
+}
+
+// string returns the value of the string field with the given number.
+func (m *buildSessionMessage) string(num protowire.Number) string {
+	return string(m.fields[num])
+}
+
+// bytes returns the value of the bytes field with the given number.
+func (m *buildSessionMessage) bytes(num protowire.Number) []byte {
+	return m.fields[num]
+}
+
+// setString sets the value of the string field with the given number.
+func (m *buildSessionMessage) setString(num protowire.Number, v string) {
+	m.setBytes(num, []byte(v))
+}
+
+// setBytes sets the value of the bytes field with the given number.
+func (m *buildSessionMessage) setBytes(num protowire.Number, v []byte) {
+	if m.fields == nil {
+		m.fields = map[protowire.Number][]byte{}
+	}
+	m.fields[num] = v
+}
+
+// marshal encodes the message in the protobuf wire format.
+func (m *buildSessionMessage) marshal() []byte {
+	var b []byte
+	for num, v := range m.varints {
+		b = protowire.AppendTag(b, num, protowire.VarintType)
+		b = protowire.AppendVarint(b, v)
+	}
+
+	for num, v := range m.fields {
+		b = protowire.AppendTag(b, num, protowire.BytesType)
+		b = protowire.AppendBytes(b, v)
+	}
+
+	return b
+}
+
+// unmarshal decodes the message from the protobuf wire format, keeping the scalar fields.
+// Repeated and map fields only keep their last value.
+func (m *buildSessionMessage) unmarshal(b []byte) error {
+	for len(b) > 0 {
+		num, typ, n := protowire.ConsumeTag(b)
+		if n < 0 {
+			return protowire.ParseError(n)
+		}
+		b = b[n:]
+
+		switch typ {
+		case protowire.BytesType:
+			v, n := protowire.ConsumeBytes(b)
+			if n < 0 {
+				return protowire.ParseError(n)
+			}
+			m.setBytes(num, v)
+			b = b[n:]
+		case protowire.VarintType:
+			v, n := protowire.ConsumeVarint(b)
+			if n < 0 {
+				return protowire.ParseError(n)
+			}
+			if m.varints == nil {
+				m.varints = map[protowire.Number]uint64{}
+			}
+			m.varints[num] = v
+			b = b[n:]
+		default:
+			n := protowire.ConsumeFieldValue(num, typ, b)
+			if n < 0 {
+				return protowire.ParseError(n)
+			}
+			b = b[n:]
+		}
+	}
+
+	return nil
+}
+
+// buildSessionCodec is the gRPC codec of the messages of the session.
+type buildSessionCodec struct{}
+
+// Marshal implements encoding.Codec.
+func (buildSessionCodec) Marshal(v any) ([]byte, error) {
+	m, ok := v.(*buildSessionMessage)
+	if !ok {
+		return nil, fmt.Errorf("unexpected message type %T", v)
+	}
+
+	return m.marshal(), nil
+}
+
+// Unmarshal implements encoding.Codec.
+func (buildSessionCodec) Unmarshal(data []byte, v any) error {
+	m, ok := v.(*buildSessionMessage)
+	if !ok {
+		return fmt.Errorf("unexpected message type %T", v)
+	}
+
+	return m.unmarshal(data)
+}
+
+// Name implements encoding.Codec.
+func (buildSessionCodec) Name() string {
+	return "proto"
+}
+
+// singleConnListener is a net.Listener accepting a single connection,
+// which is the connection opened by the session to the Docker daemon.
+type singleConnListener struct {
+	mx     sync.Mutex
+	conn   net.Conn
+	closed chan struct{}
+	once   sync.Once
+}
+
+// newSingleConnListener returns a listener accepting the given connection.
+func newSingleConnListener(conn net.Conn) *singleConnListener {
+	return &singleConnListener{conn: conn, closed: make(chan struct{})}
+}
+
+// Accept returns the connection the first time, then blocks until the listener is closed.
+func (l *singleConnListener) Accept() (net.Conn, error) {
+	l.mx.Lock()
+	conn := l.conn
+	l.conn = nil
+	l.mx.Unlock()
+
+	if conn != nil {
+		return conn, nil
+	}
+
+	<-l.closed
+
+	return nil, net.ErrClosed
+}
+
+// Close closes the listener, but not the accepted connection.
+func (l *singleConnListener) Close() error {
+	l.once.Do(func() {
+		close(l.closed)
+	})
+
+	return nil
+}
+
+// Addr returns the local address of the connection.
+func (l *singleConnListener) Addr() net.Addr {
+	return dummyAddr("build-session")
+}
+
+// dummyAddr is the address of the connection of a build session.
+type dummyAddr string
+
+// Network implements net.Addr.
+func (a dummyAddr) Network() string {
+	return string(a)
+}
+
+// String implements net.Addr.
+func (a dummyAddr) String() string {
+	return string(a)
+}
@@ -0,0 +1,126 @@
+package testcontainers
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/docker/docker/api/types/registry"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	"github.com/testcontainers/testcontainers-go/internal/core"
+)
+
+func TestBuildSession(t *testing.T) {
+	session, err := newBuildSession(
+		map[string][]byte{"token": []byte("s3cr3t")},
+		nil,
+		map[string]registry.AuthConfig{
+			"registry.example.com": {Username: "user", Password: "password"},
+			core.IndexDockerIO:     {IdentityToken: "hub-token"},
+		},
+	)
+	require.NoError(t, err)
+
+	lis := bufconn.Listen(1024 * 1024)
+	go func() {
+		_ = session.server.Serve(lis)
+	}()
+	t.Cleanup(session.close)
+
+	conn, err := grpc.NewClient("passthrough:///session",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithDefaultCallOptions(grpc.ForceCodec(buildSessionCodec{})),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, conn.Close())
+	})
+
+	ctx := context.Background()
+
+	invoke := func(method string, req *buildSessionMessage) (*buildSessionMessage, error) {
+		resp := &buildSessionMessage{}
+		err := conn.Invoke(ctx, method, req, resp)
+		return resp, err
+	}
+
+	t.Run("health", func(t *testing.T) {
+		resp, err := invoke("/grpc.health.v1.Health/Check", &buildSessionMessage{})
+		require.NoError(t, err)
+		require.Equal(t, uint64(1), resp.varints[1])
+	})
+
+	t.Run("secret", func(t *testing.T) {
+		req := &buildSessionMessage{}
+		req.setString(1, "token")
+
+		resp, err := invoke("/moby.buildkit.secrets.v1.Secrets/GetSecret", req)
+		require.NoError(t, err)
+		require.Equal(t, []byte("s3cr3t"), resp.bytes(1))
+	})
+
+	t.Run("secret-not-found", func(t *testing.T) {
+		req := &buildSessionMessage{}
+		req.setString(1, "unknown")
+
+		_, err := invoke("/moby.buildkit.secrets.v1.Secrets/GetSecret", req)
+		require.Equal(t, codes.NotFound, status.Code(err))
+	})
+
+	t.Run("credentials", func(t *testing.T) {
+		req := &buildSessionMessage{}
+		req.setString(1, "registry.example.com")
+
+		resp, err := invoke("/moby.filesync.v1.Auth/Credentials", req)
+		require.NoError(t, err)
+		require.Equal(t, "user", resp.string(1))
+		require.Equal(t, "password", resp.string(2))
+	})
+
+	t.Run("credentials-docker-hub", func(t *testing.T) {
+		req := &buildSessionMessage{}
+		req.setString(1, "registry-1.docker.io")
+
+		resp, err := invoke("/moby.filesync.v1.Auth/Credentials", req)
+		require.NoError(t, err)
+		require.Empty(t, resp.string(1))
+		require.Equal(t, "hub-token", resp.string(2))
+	})
+
+	t.Run("credentials-unknown", func(t *testing.T) {
+		req := &buildSessionMessage{}
+		req.setString(1, "other.example.com")
+
+		resp, err := invoke("/moby.filesync.v1.Auth/Credentials", req)
+		require.NoError(t, err)
+		require.Empty(t, resp.string(1))
+		require.Empty(t, resp.string(2))
+	})
+
+	t.Run("ssh-not-forwarded", func(t *testing.T) {
+		_, err := invoke("/moby.sshforward.v1.SSH/CheckAgent", &buildSessionMessage{})
+		require.Equal(t, codes.Unimplemented, status.Code(err))
+	})
+}
+
+func TestNewBuildSession_sshAuthSock(t *testing.T) {
+	t.Setenv("SSH_AUTH_SOCK", "")
+
+	_, err := newBuildSession(nil, map[string]string{"default": ""}, nil)
+	require.ErrorContains(t, err, "SSH_AUTH_SOCK is not set")
+
+	t.Setenv("SSH_AUTH_SOCK", "/tmp/agent.sock")
+
+	session, err := newBuildSession(nil, map[string]string{"default": ""}, nil)
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{"default": "/tmp/agent.sock"}, session.ssh)
+}
@@ -5,6 +5,7 @@ import (
 	"context"
 	"io"
 	"testing"
+	"time"
 
 	"github.com/docker/docker/pkg/stdcopy"
 	"github.com/stretchr/testify/require"
@@ -134,3 +135,106 @@ func TestExecWithNonMultiplexedResponse(t *testing.T) {
 	require.Equal(t, "stdout\n", stdout.String())
 	require.Equal(t, "stderr\n", stderr.String())
 }
+
+func TestExecSession(t *testing.T) {
+	ctx := context.Background()
+
+	ctr, err := GenericContainer(ctx, GenericContainerRequest{
+		ContainerRequest: ContainerRequest{
+			Image: nginxAlpineImage,
+		},
+		Started: true,
+	})
+	CleanupContainer(t, ctr)
+	require.NoError(t, err)
+
+	t.Run("stdin-and-separate-streams", func(t *testing.T) {
+		session, err := ctr.ExecSession(ctx, []string{"sh", "-c", "read line; echo \"out: $line\"; echo \"err: $line\" >&2; exit 3"})
+		require.NoError(t, err)
+		defer session.Close()
+
+		_, err = io.WriteString(session.Stdin, "hello\n")
+		require.NoError(t, err)
+		require.NoError(t, session.Stdin.Close())
+
+		code, err := session.Wait(ctx)
+		require.NoError(t, err)
+		require.Equal(t, 3, code)
+
+		stdout, err := io.ReadAll(session.Stdout)
+		require.NoError(t, err)
+		require.Equal(t, "out: hello\n", string(stdout))
+
+		stderr, err := io.ReadAll(session.Stderr)
+		require.NoError(t, err)
+		require.Equal(t, "err: hello\n", string(stderr))
+	})
+
+	t.Run("tty", func(t *testing.T) {
+		session, err := ctr.ExecSession(ctx, []string{"sh", "-c", "sleep 1; stty size"}, tcexec.WithTTY())
+		require.NoError(t, err)
+		defer session.Close()
+
+		require.NoError(t, session.Resize(ctx, 40, 100))
+
+		code, err := session.Wait(ctx)
+		require.NoError(t, err)
+		require.Zero(t, code)
+
+		stdout, err := io.ReadAll(session.Stdout)
+		require.NoError(t, err)
+		require.Contains(t, string(stdout), "40 100")
+	})
+
+	t.Run("resize-without-tty", func(t *testing.T) {
+		session, err := ctr.ExecSession(ctx, []string{"true"})
+		require.NoError(t, err)
+		defer session.Close()
+
+		require.Error(t, session.Resize(ctx, 40, 100))
+	})
+
+	t.Run("cancel-kills-process", func(t *testing.T) {
+		sessionCtx, cancel := context.WithCancel(ctx)
+
+		session, err := ctr.ExecSession(sessionCtx, []string{"sleep", "300"})
+		require.NoError(t, err)
+		defer session.Close()
+
+		cancel()
+
+		waitCtx, waitCancel := context.WithTimeout(ctx, 20*time.Second)
+		defer waitCancel()
+
+		code, err := session.Wait(waitCtx)
+		require.NoError(t, err)
+		require.Equal(t, 137, code)
+
+		_, reader, err := ctr.Exec(ctx, []string{"pgrep", "-f", "sleep 300"}, tcexec.Multiplexed())
+		require.NoError(t, err)
+		out, err := io.ReadAll(reader)
+		require.NoError(t, err)
+		require.Empty(t, string(out))
+	})
+}
+
+func TestBufferedPipe(t *testing.T) {
+	p := newBufferedPipe()
+
+	_, err := p.Write([]byte("hello "))
+	require.NoError(t, err)
+	_, err = p.Write([]byte("world"))
+	require.NoError(t, err)
+
+	done := make(chan []byte)
+	go func() {
+		b, _ := io.ReadAll(p)
+		done <- b
+	}()
+
+	p.CloseWithError(nil)
+	require.Equal(t, "hello world", string(<-done))
+
+	_, err = p.Write([]byte("closed"))
+	require.ErrorIs(t, err, io.ErrClosedPipe)
+}
@@ -2,6 +2,7 @@ package testcontainers_test
 
 import (
 	"context"
+	"io/fs"
 	"os"
 	"path/filepath"
 	"testing"
@@ -206,3 +207,89 @@ func TestCopyDirectoryToRunningContainerAsDir(t *testing.T) {
 	require.NoError(t, err)
 	// }
 }
+
+func TestContainerFS(t *testing.T) {
+	ctx, cnl := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cnl()
+
+	ctr, err := testcontainers.GenericContainer(ctx, testcontainers.GenericContainerRequest{
+		ContainerRequest: testcontainers.ContainerRequest{
+			Image: "docker.io/bash:5.2.26",
+			Cmd: []string{"bash", "-c", "mkdir -p /reports/nested && echo passed > /reports/summary.txt && " +
+				"echo nested > /reports/nested/result.txt && ln -s summary.txt /reports/latest && sleep infinity"},
+			WaitingFor: wait.ForExec([]string{"test", "-L", "/reports/latest"}),
+		},
+		Started: true,
+	})
+	testcontainers.CleanupContainer(t, ctr)
+	require.NoError(t, err)
+
+	// containerFS {
+	reports, err := fs.Sub(ctr.FS(ctx), "reports")
+	require.NoError(t, err)
+
+	content, err := fs.ReadFile(reports, "summary.txt")
+	require.NoError(t, err)
+	require.Equal(t, "passed\n", string(content))
+	// }
+
+	info, err := fs.Stat(reports, "nested/result.txt")
+	require.NoError(t, err)
+	require.Equal(t, "result.txt", info.Name())
+	require.Equal(t, int64(len("nested\n")), info.Size())
+
+	entries, err := ctr.FS(ctx).ReadDir("reports")
+	require.NoError(t, err)
+	require.Len(t, entries, 3)
+	require.Equal(t, "latest", entries[0].Name())
+	require.Equal(t, fs.ModeSymlink, entries[0].Type())
+	require.Equal(t, "nested", entries[1].Name())
+	require.True(t, entries[1].IsDir())
+
+	// symlinks are followed
+	content, err = ctr.FS(ctx).ReadFile("reports/latest")
+	require.NoError(t, err)
+	require.Equal(t, "passed\n", string(content))
+
+	_, err = ctr.FS(ctx).Stat("reports/missing.txt")
+	require.ErrorIs(t, err, fs.ErrNotExist)
+}
+
+func TestCopyDirFromContainer(t *testing.T) {
+	ctx, cnl := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cnl()
+
+	ctr, err := testcontainers.GenericContainer(ctx, testcontainers.GenericContainerRequest{
+		ContainerRequest: testcontainers.ContainerRequest{
+			Image: "docker.io/bash:5.2.26",
+			Cmd: []string{"bash", "-c", "mkdir -p /reports/nested && echo '#!/bin/sh' > /reports/run.sh && chmod 750 /reports/run.sh && " +
+				"echo nested > /reports/nested/result.txt && ln -s nested/result.txt /reports/latest && sleep infinity"},
+			WaitingFor: wait.ForExec([]string{"test", "-L", "/reports/latest"}),
+		},
+		Started: true,
+	})
+	testcontainers.CleanupContainer(t, ctr)
+	require.NoError(t, err)
+
+	// copyDirFromContainer {
+	hostDir := filepath.Join(t.TempDir(), "reports")
+
+	err = ctr.CopyDirFromContainer(ctx, "/reports", hostDir)
+	// }
+	require.NoError(t, err)
+
+	content, err := os.ReadFile(filepath.Join(hostDir, "nested", "result.txt"))
+	require.NoError(t, err)
+	require.Equal(t, "nested\n", string(content))
+
+	fi, err := os.Stat(filepath.Join(hostDir, "run.sh"))
+	require.NoError(t, err)
+	require.Equal(t, os.FileMode(0o750), fi.Mode().Perm())
+
+	link, err := os.Readlink(filepath.Join(hostDir, "latest"))
+	require.NoError(t, err)
+	require.Equal(t, "nested/result.txt", link)
+
+	err = ctr.CopyDirFromContainer(ctx, "/reports/run.sh", hostDir)
+	require.EqualError(t, err, "path /reports/run.sh is not a directory")
+}
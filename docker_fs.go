@@ -0,0 +1,346 @@
+package testcontainers
+
+import (
+	"archive/tar"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/errdefs"
+)
+
+// ContainerFS is the read-only filesystem of a container, as returned by [Container.FS].
+// Paths are unrooted and slash-separated, as required by [fs.FS], so the container
+// path /etc/hosts is accessed as "etc/hosts", and "." is the root of the container.
+type ContainerFS interface {
+	fs.ReadDirFS
+	fs.ReadFileFS
+	fs.StatFS
+}
+
+// Implement interfaces
+var (
+	_ ContainerFS    = (*dockerFS)(nil)
+	_ fs.ReadDirFile = (*dockerFSDir)(nil)
+	_ fs.File        = (*dockerFSFile)(nil)
+	_ fs.FileInfo    = (*pathStatInfo)(nil)
+)
+
+// FS returns the filesystem of the container, backed by the archive API of the Docker daemon.
+// All the operations of the returned filesystem use the given context.
+//
+// Every call retrieves the content from the daemon, so reading a directory transfers
+// the whole tree under it: use [fs.Sub] to scope the filesystem to the directory of interest.
+func (c *DockerContainer) FS(ctx context.Context) ContainerFS {
+	return &dockerFS{ctx: ctx, c: c}
+}
+
+// CopyDirFromContainer copies the content of a directory of the container to a directory
+// of the host, which is created if it does not exist. Symlinks, hard links, file modes and
+// modification times are preserved, and so is the ownership of the files, if the current user
+// is allowed to change it. Entries that would be extracted outside hostDirPath are rejected.
+func (c *DockerContainer) CopyDirFromContainer(ctx context.Context, containerDirPath string, hostDirPath string) error {
+	fsys := &dockerFS{ctx: ctx, c: c}
+
+	name := strings.TrimPrefix(path.Clean("/"+containerDirPath), "/")
+	if name == "" {
+		name = "."
+	}
+
+	containerPath, info, err := fsys.resolve("copy", name)
+	if err != nil {
+		return err
+	}
+
+	if !info.IsDir() {
+		// it's not a dir: let the consumer to handle an error
+		return fmt.Errorf("path %s is not a directory", containerDirPath)
+	}
+
+	r, _, err := c.provider.client.CopyFromContainer(ctx, c.ID, containerPath)
+	if err != nil {
+		return fmt.Errorf("copy from container: %w", err)
+	}
+	defer c.provider.Close()
+	defer r.Close()
+
+	return untarDir(r, hostDirPath, path.Base(containerPath))
+}
+
+// dockerFS is the filesystem of a Docker container.
+type dockerFS struct {
+	ctx context.Context
+	c   *DockerContainer
+}
+
+// Open opens the named file or directory, following symlinks.
+// Regular files are streamed from the daemon while they are read.
+func (f *dockerFS) Open(name string) (fs.File, error) {
+	containerPath, info, err := f.resolve("open", name)
+	if err != nil {
+		return nil, err
+	}
+
+	if info.IsDir() {
+		return &dockerFSDir{fsys: f, name: name, info: info}, nil
+	}
+
+	r, _, err := f.c.provider.client.CopyFromContainer(f.ctx, f.c.ID, containerPath)
+	if err != nil {
+		return nil, pathError("open", name, err)
+	}
+	defer f.c.provider.Close()
+
+	tr := tar.NewReader(r)
+	if _, err := tr.Next(); err != nil {
+		r.Close()
+		return nil, pathError("open", name, err)
+	}
+
+	return &dockerFSFile{info: info, r: r, tr: tr}, nil
+}
+
+// ReadFile reads the named file, following symlinks.
+func (f *dockerFS) ReadFile(name string) ([]byte, error) {
+	file, err := f.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	return io.ReadAll(file)
+}
+
+// Stat returns the information of the named file, following symlinks.
+func (f *dockerFS) Stat(name string) (fs.FileInfo, error) {
+	_, info, err := f.resolve("stat", name)
+	return info, err
+}
+
+// ReadDir reads the named directory, following symlinks,
+// returning its entries sorted by filename.
+func (f *dockerFS) ReadDir(name string) ([]fs.DirEntry, error) {
+	containerPath, info, err := f.resolve("readdir", name)
+	if err != nil {
+		return nil, err
+	}
+
+	if !info.IsDir() {
+		return nil, &fs.PathError{Op: "readdir", Path: name, Err: errors.New("not a directory")}
+	}
+
+	r, _, err := f.c.provider.client.CopyFromContainer(f.ctx, f.c.ID, containerPath)
+	if err != nil {
+		return nil, pathError("readdir", name, err)
+	}
+	defer f.c.provider.Close()
+	defer r.Close()
+
+	baseDir := path.Base(containerPath)
+
+	entries := []fs.DirEntry{}
+	tr := tar.NewReader(r)
+	for {
+		header, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, pathError("readdir", name, err)
+		}
+
+		rel, ok := archiveRelPath(header.Name, baseDir)
+		if !ok || rel == "." || strings.Contains(rel, "/") {
+			// only the direct children of the directory are listed
+			continue
+		}
+
+		entries = append(entries, fs.FileInfoToDirEntry(header.FileInfo()))
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].Name() < entries[j].Name()
+	})
+
+	return entries, nil
+}
+
+// resolve returns the container path of the named file, following it if it's a symlink,
+// and its information.
+func (f *dockerFS) resolve(op string, name string) (string, fs.FileInfo, error) {
+	if !fs.ValidPath(name) {
+		return "", nil, &fs.PathError{Op: op, Path: name, Err: fs.ErrInvalid}
+	}
+
+	containerPath := path.Join("/", name)
+
+	stat, err := f.c.provider.client.ContainerStatPath(f.ctx, f.c.ID, containerPath)
+	if err != nil {
+		return "", nil, pathError(op, name, err)
+	}
+	defer f.c.provider.Close()
+
+	if stat.Mode&fs.ModeSymlink != 0 {
+		// the link target reported by the daemon is already fully resolved.
+		containerPath = path.Join("/", stat.LinkTarget)
+
+		stat, err = f.c.provider.client.ContainerStatPath(f.ctx, f.c.ID, containerPath)
+		if err != nil {
+			return "", nil, pathError(op, name, err)
+		}
+	}
+
+	return containerPath, &pathStatInfo{name: path.Base(name), stat: stat}, nil
+}
+
+// pathError wraps an error of the Docker client for the named file,
+// so a missing file is reported as [fs.ErrNotExist].
+func pathError(op string, name string, err error) error {
+	if errdefs.IsNotFound(err) {
+		err = fs.ErrNotExist
+	}
+
+	return &fs.PathError{Op: op, Path: name, Err: err}
+}
+
+// archiveRelPath returns the path of an entry of an archive retrieved from the Docker daemon,
+// relative to the directory the archive was created from, whose base name is baseDir.
+// It returns false if the entry does not belong to the directory.
+func archiveRelPath(name string, baseDir string) (string, bool) {
+	name = strings.TrimPrefix(path.Clean("/"+name), "/")
+
+	if baseDir == "/" {
+		// the entries of an archive of the root directory are not prefixed.
+		if name == "" {
+			return ".", true
+		}
+
+		return name, true
+	}
+
+	if name == baseDir {
+		return ".", true
+	}
+
+	rel, ok := strings.CutPrefix(name, baseDir+"/")
+
+	return rel, ok
+}
+
+// pathStatInfo is the information of a container file, as reported by the Docker daemon.
+type pathStatInfo struct {
+	name string
+	stat container.PathStat
+}
+
+// Name returns the base name of the file.
+func (i *pathStatInfo) Name() string {
+	return i.name
+}
+
+// Size returns the length in bytes of a regular file.
+func (i *pathStatInfo) Size() int64 {
+	return i.stat.Size
+}
+
+// Mode returns the file mode bits.
+func (i *pathStatInfo) Mode() fs.FileMode {
+	return i.stat.Mode
+}
+
+// ModTime returns the modification time.
+func (i *pathStatInfo) ModTime() time.Time {
+	return i.stat.Mtime
+}
+
+// IsDir reports whether the file is a directory.
+func (i *pathStatInfo) IsDir() bool {
+	return i.stat.Mode.IsDir()
+}
+
+// Sys returns the [container.PathStat] reported by the Docker daemon.
+func (i *pathStatInfo) Sys() any {
+	return i.stat
+}
+
+// dockerFSFile is a file of a container, streamed from the archive API.
+type dockerFSFile struct {
+	info fs.FileInfo
+	r    io.ReadCloser
+	tr   *tar.Reader
+}
+
+// Stat returns the information of the file.
+func (f *dockerFSFile) Stat() (fs.FileInfo, error) {
+	return f.info, nil
+}
+
+// Read reads the content of the file.
+func (f *dockerFSFile) Read(b []byte) (int, error) {
+	return f.tr.Read(b)
+}
+
+// Close closes the stream of the file.
+func (f *dockerFSFile) Close() error {
+	return f.r.Close()
+}
+
+// dockerFSDir is a directory of a container. Its entries are read on first use.
+type dockerFSDir struct {
+	fsys    *dockerFS
+	name    string
+	info    fs.FileInfo
+	entries []fs.DirEntry
+	loaded  bool
+	offset  int
+}
+
+// Stat returns the information of the directory.
+func (d *dockerFSDir) Stat() (fs.FileInfo, error) {
+	return d.info, nil
+}
+
+// Read fails, as directories can't be read.
+func (d *dockerFSDir) Read(_ []byte) (int, error) {
+	return 0, &fs.PathError{Op: "read", Path: d.name, Err: errors.New("is a directory")}
+}
+
+// Close is a no-op, as the entries of the directory are read at once.
+func (d *dockerFSDir) Close() error {
+	return nil
+}
+
+// ReadDir returns the next n entries of the directory, following the semantics of [fs.ReadDirFile].
+func (d *dockerFSDir) ReadDir(n int) ([]fs.DirEntry, error) {
+	if !d.loaded {
+		entries, err := d.fsys.ReadDir(d.name)
+		if err != nil {
+			return nil, err
+		}
+
+		d.entries = entries
+		d.loaded = true
+	}
+
+	remaining := d.entries[d.offset:]
+	if n <= 0 {
+		d.offset = len(d.entries)
+		return remaining, nil
+	}
+
+	if len(remaining) == 0 {
+		return nil, io.EOF
+	}
+
+	n = min(n, len(remaining))
+	d.offset += n
+
+	return remaining[:n], nil
+}
@@ -0,0 +1,208 @@
+package testcontainers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/mount"
+	"github.com/docker/go-connections/nat"
+
+	"github.com/testcontainers/testcontainers-go/internal/core"
+)
+
+// restartOptions is a type that holds the options for restarting a container.
+type restartOptions struct {
+	timeout *time.Duration
+}
+
+// RestartOption is a type that represents an option for restarting a container.
+type RestartOption func(*restartOptions)
+
+// RestartTimeout returns a RestartOption that sets the timeout to stop the container.
+// Default: See [Container.Stop].
+func RestartTimeout(timeout time.Duration) RestartOption {
+	return func(o *restartOptions) {
+		o.timeout = &timeout
+	}
+}
+
+// Restart stops the container and starts it again, keeping the host ports it's currently
+// mapped to, so the clients created from [DockerContainer.MappedPort] can reconnect to it.
+//
+// As the Docker daemon assigns new random host ports when a container is started, a container
+// with random host ports is replaced with a new one, with a new ID, created from an image of its
+// filesystem and bound to the same host ports, or to new random ones if they are not available
+// anymore. The new container keeps the name, configuration, networks, network aliases and
+// anonymous volumes of the current one. Otherwise, the container is just stopped and started.
+//
+// All hooks are called in the following order, running the wait strategy again:
+//   - [ContainerLifecycleHooks.PreStops]
+//   - [ContainerLifecycleHooks.PostStops]
+//   - [ContainerLifecycleHooks.PreStarts]
+//   - [ContainerLifecycleHooks.PostStarts]
+//   - [ContainerLifecycleHooks.PostReadies]
+func (c *DockerContainer) Restart(ctx context.Context, opts ...RestartOption) error {
+	options := &restartOptions{}
+	for _, opt := range opts {
+		opt(options)
+	}
+
+	inspect, err := c.inspectRawContainer(ctx)
+	if err != nil {
+		return fmt.Errorf("inspect container: %w", err)
+	}
+
+	err = c.Stop(ctx, options.timeout)
+	if err != nil {
+		return fmt.Errorf("stop container: %w", err)
+	}
+
+	if !hasRandomHostPorts(inspect.HostConfig.PortBindings, inspect.NetworkSettings.Ports) {
+		// starting the container again keeps its host ports
+		return c.Start(ctx)
+	}
+
+	resp, err := c.provider.client.ContainerCommit(ctx, c.ID, container.CommitOptions{
+		Comment: "testcontainers restart of container " + c.ID,
+		Config: &container.Config{
+			Labels: core.DefaultLabels(core.SessionID()),
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("container commit: %w", err)
+	}
+	defer c.provider.Close()
+	c.provider.track(ctx, sweptImage, resp.ID)
+
+	c.restartImages = append(c.restartImages, resp.ID)
+
+	// the anonymous volumes are mounted by name in the new container, so their data is kept,
+	// but then they are not removed with the container anymore.
+	volumes := anonymousVolumeMounts(inspect)
+	for _, v := range volumes {
+		if !slices.Contains(c.restartVolumes, v.Source) {
+			c.restartVolumes = append(c.restartVolumes, v.Source)
+			c.provider.track(ctx, sweptVolume, v.Source)
+		}
+	}
+
+	inspect.HostConfig = withVolumeMounts(inspect.HostConfig, volumes)
+
+	return c.recreate(ctx, inspect, resp.ID, false)
+}
+
+// removeRestartArtifacts removes the images and volumes kept to restart the container.
+func (c *DockerContainer) removeRestartArtifacts(ctx context.Context) error {
+	var errs []error
+
+	// the latest image is removed first, as every image is created from the previous one.
+	for i := len(c.restartImages) - 1; i >= 0; i-- {
+		if err := c.removeSnapshotImage(ctx, c.restartImages[i]); err != nil {
+			errs = append(errs, fmt.Errorf("remove restart image: %w", err))
+		}
+	}
+
+	for _, name := range c.restartVolumes {
+		err := c.provider.client.VolumeRemove(ctx, name, true)
+		if err != nil && !isCleanupSafe(err) {
+			errs = append(errs, fmt.Errorf("remove volume %s: %w", name, err))
+			continue
+		}
+		c.provider.untrack(sweptVolume, name)
+	}
+
+	c.restartImages = nil
+	c.restartVolumes = nil
+
+	return errors.Join(errs...)
+}
+
+// hasRandomHostPorts returns true if any port without an explicit host port
+// in the bindings of a container is currently mapped to a host port.
+func hasRandomHostPorts(bindings nat.PortMap, mapped nat.PortMap) bool {
+	for port, portBindings := range bindings {
+		for _, b := range portBindings {
+			if b.HostPort == "" && len(mapped[port]) > 0 {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// anonymousVolumeMounts returns the anonymous volumes of a container, that is, the volumes
+// which are not referenced by name in its host config, as mounts of the volumes by name.
+func anonymousVolumeMounts(inspect *types.ContainerJSON) []mount.Mount {
+	named := map[string]bool{}
+	for _, bind := range inspect.HostConfig.Binds {
+		if source, _, ok := strings.Cut(bind, ":"); ok {
+			named[source] = true
+		}
+	}
+
+	for _, m := range inspect.HostConfig.Mounts {
+		if m.Source != "" {
+			named[m.Source] = true
+		}
+	}
+
+	var mounts []mount.Mount
+	for _, mp := range inspect.Mounts {
+		if mp.Type != mount.TypeVolume || mp.Name == "" || named[mp.Name] {
+			continue
+		}
+
+		mounts = append(mounts, mount.Mount{
+			Type:     mount.TypeVolume,
+			Source:   mp.Name,
+			Target:   mp.Destination,
+			ReadOnly: !mp.RW,
+		})
+	}
+
+	return mounts
+}
+
+// withVolumeMounts returns a copy of the host config with the given volume mounts,
+// replacing the anonymous volumes declared for the same targets.
+func withVolumeMounts(hostConfig *container.HostConfig, volumes []mount.Mount) *container.HostConfig {
+	hc := *hostConfig
+	if len(volumes) == 0 {
+		return &hc
+	}
+
+	targets := map[string]bool{}
+	for _, v := range volumes {
+		targets[v.Target] = true
+	}
+
+	hc.Binds = make([]string, 0, len(hostConfig.Binds))
+	for _, bind := range hostConfig.Binds {
+		// anonymous volumes are declared with the target only
+		if !strings.Contains(bind, ":") && targets[bind] {
+			continue
+		}
+
+		hc.Binds = append(hc.Binds, bind)
+	}
+
+	hc.Mounts = make([]mount.Mount, 0, len(hostConfig.Mounts)+len(volumes))
+	for _, m := range hostConfig.Mounts {
+		if m.Type == mount.TypeVolume && m.Source == "" && targets[m.Target] {
+			continue
+		}
+
+		hc.Mounts = append(hc.Mounts, m)
+	}
+
+	hc.Mounts = append(hc.Mounts, volumes...)
+
+	return &hc
+}
@@ -0,0 +1,141 @@
+package testcontainers
+
+import (
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/mount"
+	"github.com/docker/go-connections/nat"
+	"github.com/stretchr/testify/require"
+
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+func TestDockerContainerRestart(t *testing.T) {
+	ctx := context.Background()
+
+	var starts, readies int
+
+	// restartContainer {
+	nginx, err := GenericContainer(ctx, GenericContainerRequest{
+		ProviderType: providerType,
+		ContainerRequest: ContainerRequest{
+			Image:        nginxAlpineImage,
+			ExposedPorts: []string{nginxDefaultPort},
+			WaitingFor:   wait.ForListeningPort(nginxDefaultPort),
+			LifecycleHooks: []ContainerLifecycleHooks{
+				{
+					PreStarts: []ContainerHook{
+						func(ctx context.Context, c Container) error {
+							starts++
+							return nil
+						},
+					},
+					PostReadies: []ContainerHook{
+						func(ctx context.Context, c Container) error {
+							readies++
+							return nil
+						},
+					},
+				},
+			},
+		},
+		Started: true,
+	})
+	CleanupContainer(t, nginx)
+	require.NoError(t, err)
+
+	port, err := nginx.MappedPort(ctx, nginxDefaultPort)
+	require.NoError(t, err)
+
+	err = nginx.Restart(ctx, RestartTimeout(5*time.Second))
+	require.NoError(t, err)
+
+	restartedPort, err := nginx.MappedPort(ctx, nginxDefaultPort)
+	require.NoError(t, err)
+	require.Equal(t, port, restartedPort)
+	// }
+
+	require.True(t, nginx.IsRunning())
+	require.Equal(t, 2, starts)
+	require.Equal(t, 2, readies)
+
+	t.Run("keeps-the-filesystem", func(t *testing.T) {
+		require.NoError(t, nginx.CopyToContainer(ctx, []byte("state"), "/tmp/state.txt", 0o644))
+		require.NoError(t, nginx.Restart(ctx))
+
+		r, err := nginx.CopyFileFromContainer(ctx, "/tmp/state.txt")
+		require.NoError(t, err)
+		defer r.Close()
+
+		bs, err := io.ReadAll(r)
+		require.NoError(t, err)
+		require.Equal(t, "state", string(bs))
+	})
+
+	t.Run("removes-the-restart-images", func(t *testing.T) {
+		images := append([]string{}, nginx.(*DockerContainer).restartImages...)
+		require.NotEmpty(t, images)
+
+		require.NoError(t, nginx.Terminate(ctx))
+
+		provider, err := NewDockerProvider()
+		require.NoError(t, err)
+		defer provider.Close()
+
+		for _, imageID := range images {
+			_, _, err = provider.client.ImageInspectWithRaw(ctx, imageID)
+			require.Error(t, err)
+		}
+	})
+}
+
+func TestHasRandomHostPorts(t *testing.T) {
+	mapped := nat.PortMap{
+		"80/tcp":  {{HostIP: "0.0.0.0", HostPort: "32768"}},
+		"443/tcp": {{HostIP: "0.0.0.0", HostPort: "8443"}},
+	}
+
+	require.True(t, hasRandomHostPorts(nat.PortMap{"80/tcp": {{HostPort: ""}}}, mapped))
+	require.False(t, hasRandomHostPorts(nat.PortMap{"443/tcp": {{HostPort: "8443"}}}, mapped))
+	require.False(t, hasRandomHostPorts(nat.PortMap{"80/tcp": {{HostPort: ""}}}, nat.PortMap{}))
+	require.False(t, hasRandomHostPorts(nil, mapped))
+}
+
+func TestAnonymousVolumeMounts(t *testing.T) {
+	inspect := &types.ContainerJSON{
+		ContainerJSONBase: &types.ContainerJSONBase{
+			HostConfig: &container.HostConfig{
+				Binds: []string{"named:/named", "/host:/bind", "/anonymous"},
+				Mounts: []mount.Mount{
+					{Type: mount.TypeVolume, Source: "mounted", Target: "/mounted"},
+				},
+			},
+		},
+		Mounts: []types.MountPoint{
+			{Type: mount.TypeVolume, Name: "named", Destination: "/named", RW: true},
+			{Type: mount.TypeBind, Source: "/host", Destination: "/bind", RW: true},
+			{Type: mount.TypeVolume, Name: "mounted", Destination: "/mounted", RW: true},
+			{Type: mount.TypeVolume, Name: "abc", Destination: "/anonymous", RW: true},
+			{Type: mount.TypeVolume, Name: "def", Destination: "/var/lib/data"},
+		},
+	}
+
+	volumes := anonymousVolumeMounts(inspect)
+	require.Equal(t, []mount.Mount{
+		{Type: mount.TypeVolume, Source: "abc", Target: "/anonymous"},
+		{Type: mount.TypeVolume, Source: "def", Target: "/var/lib/data", ReadOnly: true},
+	}, volumes)
+
+	hostConfig := withVolumeMounts(inspect.HostConfig, volumes)
+	require.Equal(t, []string{"named:/named", "/host:/bind"}, hostConfig.Binds)
+	require.Equal(t, append([]mount.Mount{inspect.HostConfig.Mounts[0]}, volumes...), hostConfig.Mounts)
+
+	// the host config of the container is not modified
+	require.Len(t, inspect.HostConfig.Binds, 3)
+	require.Len(t, inspect.HostConfig.Mounts, 1)
+}
@@ -0,0 +1,461 @@
+package testcontainers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/image"
+	"github.com/docker/docker/api/types/mount"
+	"github.com/docker/docker/api/types/network"
+	"github.com/docker/go-connections/nat"
+
+	"github.com/testcontainers/testcontainers-go/internal/core"
+)
+
+// containerSnapshot is a snapshot of a container: the image its filesystem was committed to,
+// and the archives of its anonymous volumes, which are not committed.
+type containerSnapshot struct {
+	imageID string
+	volumes []snapshotVolume
+}
+
+// snapshotVolume is the content of an anonymous volume of a snapshot.
+type snapshotVolume struct {
+	target  string // path the volume is mounted at in the container
+	archive string // path of the tar archive of the volume on the host
+}
+
+// Snapshot commits the filesystem of the container to a new image, storing it
+// under the given name, so the container can be restored to this state using
+// [DockerContainer.Restore]. Creating a snapshot with the name of an existing
+// one replaces it.
+//
+// As volumes are not committed, the content of the anonymous volumes, e.g. the ones declared
+// by the VOLUME instruction of the image for the data of a database, is archived to temporary
+// files on the host, and copied into the new volumes of the restored container. The volumes
+// mounted by name, and the bind mounts, are shared with the restored container as they are.
+// The container is paused while its filesystem and volumes are captured.
+//
+// The snapshot images are labeled with the session ID, so they are removed by the
+// reaper, and they are also removed when the container is terminated, with the archives.
+func (c *DockerContainer) Snapshot(ctx context.Context, name string) (err error) {
+	if name == "" {
+		return errors.New("snapshot name must not be empty")
+	}
+
+	inspect, err := c.inspectRawContainer(ctx)
+	if err != nil {
+		return fmt.Errorf("inspect container: %w", err)
+	}
+
+	// pause the container, so the volumes and the filesystem are captured at the same point
+	if inspect.State.Running && !inspect.State.Paused {
+		if err := c.provider.client.ContainerPause(ctx, c.ID); err != nil {
+			return fmt.Errorf("container pause: %w", err)
+		}
+
+		defer func() {
+			if unpauseErr := c.provider.client.ContainerUnpause(ctx, c.ID); unpauseErr != nil {
+				err = errors.Join(err, fmt.Errorf("container unpause: %w", unpauseErr))
+			}
+		}()
+	}
+
+	volumes, err := c.archiveVolumes(ctx, snapshotVolumeTargets(inspect, c.restartVolumes))
+	if err != nil {
+		return err
+	}
+
+	resp, err := c.provider.client.ContainerCommit(ctx, c.ID, container.CommitOptions{
+		Comment: fmt.Sprintf("testcontainers snapshot %s of container %s", name, c.ID),
+		Config: &container.Config{
+			Labels: core.DefaultLabels(core.SessionID()),
+		},
+	})
+	if err != nil {
+		return errors.Join(fmt.Errorf("container commit: %w", err), removeVolumeArchives(volumes))
+	}
+	defer c.provider.Close()
+	c.provider.track(ctx, sweptImage, resp.ID)
+
+	if c.snapshots == nil {
+		c.snapshots = make(map[string]containerSnapshot)
+	}
+
+	snapshot := containerSnapshot{imageID: resp.ID, volumes: volumes}
+	if previous, ok := c.snapshots[name]; ok {
+		if err := c.removeSnapshot(ctx, previous, snapshot.imageID); err != nil {
+			return fmt.Errorf("remove previous snapshot %q: %w", name, err)
+		}
+	}
+
+	c.snapshots[name] = snapshot
+
+	return nil
+}
+
+// Restore replaces the container with a new one created from the snapshot with the given name.
+// The new container keeps the name, configuration, networks and network aliases of the current one,
+// and it's bound to the same host ports if they are still available, otherwise to new random ones.
+// Its anonymous volumes are created empty, and the content they had in the snapshot is copied into
+// them before the container is started.
+//
+// The current container is stopped and removed, including its anonymous volumes, so the
+// [ContainerLifecycleHooks.PreStops] and [ContainerLifecycleHooks.PostStops] hooks are called,
+// and then the new container is started, calling the start hooks and the wait strategy.
+func (c *DockerContainer) Restore(ctx context.Context, name string) error {
+	snapshot, ok := c.snapshots[name]
+	if !ok {
+		return fmt.Errorf("snapshot %q not found", name)
+	}
+
+	inspect, err := c.inspectRawContainer(ctx)
+	if err != nil {
+		return fmt.Errorf("inspect container: %w", err)
+	}
+
+	err = c.Stop(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("stop container: %w", err)
+	}
+
+	// the volumes kept by name when the container was restarted hold the current data,
+	// so the new container mounts new anonymous volumes instead, restored from the snapshot.
+	inspect.HostConfig = withoutRestartVolumes(inspect.HostConfig, c.restartVolumes)
+
+	return c.recreate(ctx, inspect, snapshot.imageID, true, snapshot.volumes...)
+}
+
+// recreate removes the stopped container and replaces it with a new one created from the given
+// image, keeping the configuration of the previous container, as returned by inspect, and starts it.
+// The anonymous volumes of the previous container are removed if removeVolumes is true,
+// and the given volumes are copied into the new container before starting it.
+func (c *DockerContainer) recreate(ctx context.Context, previous *types.ContainerJSON, imageID string, removeVolumes bool, volumes ...snapshotVolume) error {
+	err := c.provider.client.ContainerRemove(ctx, c.ID, container.RemoveOptions{
+		RemoveVolumes: removeVolumes,
+		Force:         true,
+	})
+	if err != nil {
+		return fmt.Errorf("container remove: %w", err)
+	}
+	defer c.provider.Close()
+	c.provider.untrack(sweptContainer, c.ID)
+
+	err = c.startingHook(ctx)
+	if err != nil {
+		return fmt.Errorf("starting hook: %w", err)
+	}
+
+	// bind the ports of the new container to the host ports of the previous one,
+	// falling back to the original bindings if any of them is not available anymore.
+	pinnedPorts := pinnedPortBindings(previous.HostConfig.PortBindings, previous.NetworkSettings.Ports)
+
+	id, err := c.createAndStartFrom(ctx, previous, imageID, pinnedPorts, volumes)
+	if err != nil {
+		c.logger.Printf("🔄 Could not reuse the host ports of container %s, using new ones: %v", c.ID[:12], err)
+
+		id, err = c.createAndStartFrom(ctx, previous, imageID, previous.HostConfig.PortBindings, volumes)
+		if err != nil {
+			return err
+		}
+	}
+
+	c.ID = id
+
+	err = c.startedHook(ctx)
+	if err != nil {
+		return fmt.Errorf("started hook: %w", err)
+	}
+
+	c.isRunning.Store(true)
+
+	err = c.readiedHook(ctx)
+	if err != nil {
+		return fmt.Errorf("readied hook: %w", err)
+	}
+
+	return nil
+}
+
+// createAndStartFrom creates a container from the given image, using the configuration of
+// a previous container and the given port bindings, attaches it to the networks of the
+// previous container, copies the content of the given volumes into it and starts it, returning its ID.
+// The created container is removed if it can't be started.
+func (c *DockerContainer) createAndStartFrom(ctx context.Context, previous *types.ContainerJSON, imageID string, portBindings nat.PortMap, volumes []snapshotVolume) (string, error) {
+	cfg := *previous.Config
+	cfg.Image = imageID
+
+	hostConfig := *previous.HostConfig
+	hostConfig.PortBindings = portBindings
+
+	shortID := previous.ID[:12]
+
+	endpoints := map[string]*network.EndpointSettings{}
+	if !hostConfig.NetworkMode.IsContainer() && !hostConfig.NetworkMode.IsHost() {
+		for name, settings := range previous.NetworkSettings.Networks {
+			endpoints[name] = &network.EndpointSettings{
+				Aliases:    withoutAlias(settings.Aliases, shortID),
+				Links:      settings.Links,
+				DriverOpts: settings.DriverOpts,
+			}
+		}
+	}
+
+	// #248: Docker allows only one network to be specified during container creation,
+	// so the container is created in the network of its network mode, and attached to the rest later.
+	networkingConfig := &network.NetworkingConfig{}
+	if len(endpoints) > 0 {
+		primary := string(hostConfig.NetworkMode)
+		if _, ok := endpoints[primary]; !ok {
+			names := make([]string, 0, len(endpoints))
+			for name := range endpoints {
+				names = append(names, name)
+			}
+			sort.Strings(names)
+
+			primary = names[0]
+			hostConfig.NetworkMode = container.NetworkMode(primary)
+		}
+
+		networkingConfig.EndpointsConfig = map[string]*network.EndpointSettings{
+			primary: endpoints[primary],
+		}
+	}
+
+	resp, err := c.provider.client.ContainerCreate(ctx, &cfg, &hostConfig, networkingConfig, nil, strings.TrimPrefix(previous.Name, "/"))
+	if err != nil {
+		return "", fmt.Errorf("container create: %w", err)
+	}
+	c.provider.track(ctx, sweptContainer, resp.ID)
+
+	start := func() error {
+		for name, settings := range endpoints {
+			if _, ok := networkingConfig.EndpointsConfig[name]; ok {
+				continue
+			}
+
+			if err := c.provider.client.NetworkConnect(ctx, name, resp.ID, settings); err != nil {
+				return fmt.Errorf("network connect: %w", err)
+			}
+		}
+
+		for _, v := range volumes {
+			if err := c.restoreVolume(ctx, resp.ID, v); err != nil {
+				return err
+			}
+		}
+
+		if err := c.provider.client.ContainerStart(ctx, resp.ID, container.StartOptions{}); err != nil {
+			return fmt.Errorf("container start: %w", err)
+		}
+
+		return nil
+	}
+
+	if err := start(); err != nil {
+		removeErr := c.provider.client.ContainerRemove(ctx, resp.ID, container.RemoveOptions{
+			RemoveVolumes: true,
+			Force:         true,
+		})
+		if removeErr == nil {
+			c.provider.untrack(sweptContainer, resp.ID)
+		}
+
+		return "", errors.Join(err, removeErr)
+	}
+
+	return resp.ID, nil
+}
+
+// removeSnapshotImage removes the image of a snapshot.
+func (c *DockerContainer) removeSnapshotImage(ctx context.Context, imageID string) error {
+	_, err := c.provider.client.ImageRemove(ctx, imageID, image.RemoveOptions{
+		Force:         true,
+		PruneChildren: true,
+	})
+	if err != nil && !isCleanupSafe(err) {
+		return err
+	}
+	c.provider.untrack(sweptImage, imageID)
+
+	return nil
+}
+
+// removeSnapshot removes the image and the volume archives of a snapshot.
+// The image is kept if it's the given one, e.g. the image of the snapshot replacing it,
+// as committing an unchanged container returns the same image.
+func (c *DockerContainer) removeSnapshot(ctx context.Context, snapshot containerSnapshot, keepImageID string) error {
+	var errs []error
+	if snapshot.imageID != keepImageID {
+		errs = append(errs, c.removeSnapshotImage(ctx, snapshot.imageID))
+	}
+
+	errs = append(errs, removeVolumeArchives(snapshot.volumes))
+
+	return errors.Join(errs...)
+}
+
+// removeSnapshots removes the images and the volume archives of all the snapshots of the container.
+func (c *DockerContainer) removeSnapshots(ctx context.Context) error {
+	errs := make([]error, 0, len(c.snapshots))
+	for name, snapshot := range c.snapshots {
+		if err := c.removeSnapshot(ctx, snapshot, ""); err != nil {
+			errs = append(errs, fmt.Errorf("remove snapshot %q: %w", name, err))
+		}
+	}
+
+	c.snapshots = nil
+
+	return errors.Join(errs...)
+}
+
+// archiveVolumes archives the content of the volumes mounted at the given targets
+// to temporary files. The archives are removed if any of the volumes can't be archived.
+func (c *DockerContainer) archiveVolumes(ctx context.Context, targets []string) ([]snapshotVolume, error) {
+	volumes := make([]snapshotVolume, 0, len(targets))
+	for _, target := range targets {
+		archive, err := c.archiveVolume(ctx, target)
+		if err != nil {
+			return nil, errors.Join(fmt.Errorf("archive volume %s: %w", target, err), removeVolumeArchives(volumes))
+		}
+
+		volumes = append(volumes, snapshotVolume{target: target, archive: archive})
+	}
+
+	return volumes, nil
+}
+
+// archiveVolume copies the content of the volume mounted at the given target to
+// a temporary tar archive on the host, returning its path.
+func (c *DockerContainer) archiveVolume(ctx context.Context, target string) (string, error) {
+	rc, _, err := c.provider.client.CopyFromContainer(ctx, c.ID, target)
+	if err != nil {
+		return "", fmt.Errorf("copy from container: %w", err)
+	}
+	defer rc.Close()
+
+	f, err := os.CreateTemp("", "testcontainers-snapshot-*.tar")
+	if err != nil {
+		return "", fmt.Errorf("create archive: %w", err)
+	}
+
+	if _, err := io.Copy(f, rc); err != nil {
+		return "", errors.Join(fmt.Errorf("write archive: %w", err), f.Close(), os.Remove(f.Name()))
+	}
+
+	if err := f.Close(); err != nil {
+		return "", errors.Join(fmt.Errorf("close archive: %w", err), os.Remove(f.Name()))
+	}
+
+	return f.Name(), nil
+}
+
+// restoreVolume copies the archived content of a volume into the volume mounted
+// at the same target in the given container, which must be created but not started.
+func (c *DockerContainer) restoreVolume(ctx context.Context, containerID string, v snapshotVolume) error {
+	f, err := os.Open(v.archive)
+	if err != nil {
+		return fmt.Errorf("open archive of volume %s: %w", v.target, err)
+	}
+	defer f.Close()
+
+	// the archive contains the directory of the volume, so it's extracted into its parent
+	err = c.provider.client.CopyToContainer(ctx, containerID, path.Dir(v.target), f, container.CopyToContainerOptions{})
+	if err != nil {
+		return fmt.Errorf("restore volume %s: %w", v.target, err)
+	}
+
+	return nil
+}
+
+// removeVolumeArchives removes the archives of the given volumes.
+func removeVolumeArchives(volumes []snapshotVolume) error {
+	var errs []error
+	for _, v := range volumes {
+		if err := os.Remove(v.archive); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			errs = append(errs, fmt.Errorf("remove archive of volume %s: %w", v.target, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// snapshotVolumeTargets returns the targets of the volumes of a container which are part of its
+// snapshots: the anonymous volumes, and the ones kept by name when the container was restarted.
+func snapshotVolumeTargets(inspect *types.ContainerJSON, restartVolumes []string) []string {
+	var targets []string
+	for _, m := range anonymousVolumeMounts(inspect) {
+		targets = append(targets, m.Target)
+	}
+
+	for _, m := range inspect.HostConfig.Mounts {
+		if m.Type == mount.TypeVolume && slices.Contains(restartVolumes, m.Source) {
+			targets = append(targets, m.Target)
+		}
+	}
+
+	return targets
+}
+
+// withoutRestartVolumes returns a copy of the host config mounting new anonymous volumes
+// instead of the volumes kept by name when the container was restarted.
+func withoutRestartVolumes(hostConfig *container.HostConfig, restartVolumes []string) *container.HostConfig {
+	hc := *hostConfig
+	if len(restartVolumes) == 0 {
+		return &hc
+	}
+
+	hc.Mounts = make([]mount.Mount, 0, len(hostConfig.Mounts))
+	for _, m := range hostConfig.Mounts {
+		if m.Type == mount.TypeVolume && slices.Contains(restartVolumes, m.Source) {
+			m = mount.Mount{Type: mount.TypeVolume, Target: m.Target, ReadOnly: m.ReadOnly}
+		}
+
+		hc.Mounts = append(hc.Mounts, m)
+	}
+
+	return &hc
+}
+
+// pinnedPortBindings returns the port bindings of a container, binding each port
+// without an explicit host port to the host port it's currently mapped to.
+func pinnedPortBindings(bindings nat.PortMap, mapped nat.PortMap) nat.PortMap {
+	pinned := make(nat.PortMap, len(bindings))
+	for port, portBindings := range bindings {
+		current := mapped[port]
+
+		pinned[port] = make([]nat.PortBinding, 0, len(portBindings))
+		for _, b := range portBindings {
+			if b.HostPort == "" && len(current) > 0 {
+				b.HostPort = current[0].HostPort
+			}
+
+			pinned[port] = append(pinned[port], b)
+		}
+	}
+
+	return pinned
+}
+
+// withoutAlias returns the aliases without the given one, which is
+// used to remove the short ID alias Docker adds to the containers.
+func withoutAlias(aliases []string, alias string) []string {
+	filtered := make([]string, 0, len(aliases))
+	for _, a := range aliases {
+		if a != alias {
+			filtered = append(filtered, a)
+		}
+	}
+
+	return filtered
+}
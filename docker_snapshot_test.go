@@ -0,0 +1,127 @@
+package testcontainers
+
+import (
+	"context"
+	"io"
+	"testing"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/mount"
+	"github.com/stretchr/testify/require"
+
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+func TestDockerContainerSnapshot(t *testing.T) {
+	ctx := context.Background()
+
+	provider, err := NewDockerProvider()
+	require.NoError(t, err)
+	defer provider.Close()
+
+	networkName := "snapshot-network"
+	net, err := provider.CreateNetwork(ctx, NetworkRequest{
+		Name: networkName,
+	})
+	CleanupNetwork(t, net)
+	require.NoError(t, err)
+
+	nginx, err := GenericContainer(ctx, GenericContainerRequest{
+		ProviderType: providerType,
+		ContainerRequest: ContainerRequest{
+			Image:        nginxAlpineImage,
+			ExposedPorts: []string{nginxDefaultPort},
+			Networks:     []string{networkName},
+			NetworkAliases: map[string][]string{
+				networkName: {"web"},
+			},
+			HostConfigModifier: func(hc *container.HostConfig) {
+				// an anonymous volume, which is not committed to the snapshot image
+				hc.Binds = append(hc.Binds, "/data")
+			},
+			WaitingFor: wait.ForListeningPort(nginxDefaultPort),
+		},
+		Started: true,
+	})
+	CleanupContainer(t, nginx)
+	require.NoError(t, err)
+
+	readFile := func(t *testing.T, path string) string {
+		t.Helper()
+
+		r, err := nginx.CopyFileFromContainer(ctx, path)
+		require.NoError(t, err)
+		defer r.Close()
+
+		bs, err := io.ReadAll(r)
+		require.NoError(t, err)
+
+		return string(bs)
+	}
+
+	require.NoError(t, nginx.CopyToContainer(ctx, []byte("initial"), "/tmp/state.txt", 0o644))
+	require.NoError(t, nginx.CopyToContainer(ctx, []byte("initial"), "/data/state.txt", 0o644))
+	require.NoError(t, nginx.Snapshot(ctx, "initial"))
+
+	port, err := nginx.MappedPort(ctx, nginxDefaultPort)
+	require.NoError(t, err)
+
+	previousID := nginx.GetContainerID()
+
+	require.NoError(t, nginx.CopyToContainer(ctx, []byte("modified"), "/tmp/state.txt", 0o644))
+	require.NoError(t, nginx.CopyToContainer(ctx, []byte("modified"), "/data/state.txt", 0o644))
+	require.Equal(t, "modified", readFile(t, "/tmp/state.txt"))
+	require.Equal(t, "modified", readFile(t, "/data/state.txt"))
+
+	require.NoError(t, nginx.Restore(ctx, "initial"))
+	require.True(t, nginx.IsRunning())
+	require.NotEqual(t, previousID, nginx.GetContainerID())
+	require.Equal(t, "initial", readFile(t, "/tmp/state.txt"))
+	require.Equal(t, "initial", readFile(t, "/data/state.txt"))
+
+	restoredPort, err := nginx.MappedPort(ctx, nginxDefaultPort)
+	require.NoError(t, err)
+	require.Equal(t, port, restoredPort)
+
+	aliases, err := nginx.NetworkAliases(ctx)
+	require.NoError(t, err)
+	require.Contains(t, aliases[networkName], "web")
+
+	err = nginx.Restore(ctx, "unknown")
+	require.EqualError(t, err, `snapshot "unknown" not found`)
+
+	snapshot := nginx.(*DockerContainer).snapshots["initial"]
+	require.Len(t, snapshot.volumes, 1)
+	require.NoError(t, nginx.Terminate(ctx))
+
+	_, _, err = provider.client.ImageInspectWithRaw(ctx, snapshot.imageID)
+	require.Error(t, err)
+	require.NoFileExists(t, snapshot.volumes[0].archive)
+}
+
+func TestSnapshotVolumeTargets(t *testing.T) {
+	inspect := &types.ContainerJSON{
+		ContainerJSONBase: &types.ContainerJSONBase{
+			HostConfig: &container.HostConfig{
+				Binds: []string{"named:/named", "/anonymous"},
+				Mounts: []mount.Mount{
+					{Type: mount.TypeVolume, Source: "restarted", Target: "/restarted"},
+				},
+			},
+		},
+		Mounts: []types.MountPoint{
+			{Type: mount.TypeVolume, Name: "named", Destination: "/named", RW: true},
+			{Type: mount.TypeVolume, Name: "abc", Destination: "/anonymous", RW: true},
+			{Type: mount.TypeVolume, Name: "restarted", Destination: "/restarted", RW: true},
+		},
+	}
+
+	require.Equal(t, []string{"/anonymous"}, snapshotVolumeTargets(inspect, nil))
+	require.Equal(t, []string{"/anonymous", "/restarted"}, snapshotVolumeTargets(inspect, []string{"restarted"}))
+
+	// the volumes kept by name when the container was restarted are replaced with anonymous ones
+	hostConfig := withoutRestartVolumes(inspect.HostConfig, []string{"restarted"})
+	require.Equal(t, []mount.Mount{{Type: mount.TypeVolume, Target: "/restarted"}}, hostConfig.Mounts)
+	require.Equal(t, "restarted", inspect.HostConfig.Mounts[0].Source)
+}
@@ -315,6 +315,106 @@ func TestContainerStateAfterTermination(t *testing.T) {
 	})
 }
 
+func TestContainerPauseUnpauseKill(t *testing.T) {
+	ctx := context.Background()
+
+	var hooks []string
+	hook := func(name string) ContainerHook {
+		return func(_ context.Context, _ Container) error {
+			hooks = append(hooks, name)
+			return nil
+		}
+	}
+
+	nginx, err := GenericContainer(ctx, GenericContainerRequest{
+		ProviderType: providerType,
+		ContainerRequest: ContainerRequest{
+			Image:        nginxAlpineImage,
+			ExposedPorts: []string{nginxDefaultPort},
+			WaitingFor:   wait.ForListeningPort(nginxDefaultPort),
+			LogConsumerCfg: &LogConsumerConfig{
+				Consumers: []LogConsumer{&StdoutLogConsumer{}},
+			},
+			LifecycleHooks: []ContainerLifecycleHooks{
+				{
+					PrePauses:    []ContainerHook{hook("pre-pause")},
+					PostPauses:   []ContainerHook{hook("post-pause")},
+					PreUnpauses:  []ContainerHook{hook("pre-unpause")},
+					PostUnpauses: []ContainerHook{hook("post-unpause")},
+					PreKills:     []ContainerHook{hook("pre-kill")},
+					PostKills:    []ContainerHook{hook("post-kill")},
+				},
+			},
+		},
+		Started: true,
+	})
+	CleanupContainer(t, nginx)
+	require.NoError(t, err)
+
+	require.NoError(t, nginx.Pause(ctx))
+	require.True(t, nginx.IsRunning())
+
+	state, err := nginx.State(ctx)
+	require.NoError(t, err)
+	require.True(t, state.Paused)
+
+	require.NoError(t, nginx.Unpause(ctx))
+
+	state, err = nginx.State(ctx)
+	require.NoError(t, err)
+	require.False(t, state.Paused)
+	require.True(t, state.Running)
+
+	// SIGHUP reloads the nginx configuration, so the container keeps running
+	require.NoError(t, nginx.Kill(ctx, "SIGHUP"))
+	require.True(t, nginx.IsRunning())
+
+	require.NoError(t, nginx.Kill(ctx, "SIGKILL"))
+	require.False(t, nginx.IsRunning())
+
+	state, err = nginx.State(ctx)
+	require.NoError(t, err)
+	require.False(t, state.Running)
+	require.Equal(t, 137, state.ExitCode)
+
+	require.NoError(t, nginx.Terminate(ctx))
+
+	require.Equal(t, []string{
+		"pre-pause", "post-pause", "pre-unpause", "post-unpause",
+		"pre-kill", "post-kill", "pre-kill", "post-kill",
+	}, hooks)
+}
+
+func TestDockerContainerKillWithTerminatingSignal(t *testing.T) {
+	ctx := context.Background()
+
+	nginx, err := GenericContainer(ctx, GenericContainerRequest{
+		ProviderType: providerType,
+		ContainerRequest: ContainerRequest{
+			Image:        nginxAlpineImage,
+			ExposedPorts: []string{nginxDefaultPort},
+			WaitingFor:   wait.ForListeningPort(nginxDefaultPort),
+			LogConsumerCfg: &LogConsumerConfig{
+				Consumers: []LogConsumer{&StdoutLogConsumer{}},
+			},
+		},
+		Started: true,
+	})
+	CleanupContainer(t, nginx)
+	require.NoError(t, err)
+
+	// nginx exits gracefully on SIGTERM, so the container is not running anymore
+	require.NoError(t, nginx.Kill(ctx, "SIGTERM"))
+	require.False(t, nginx.IsRunning())
+
+	state, err := nginx.State(ctx)
+	require.NoError(t, err)
+	require.False(t, state.Running)
+
+	// the log production was stopped, so terminating the container does not block
+	require.NoError(t, nginx.Terminate(ctx))
+}
+
 func TestContainerTerminationRemovesDockerImage(t *testing.T) {
 	t.Run("if not built from Dockerfile", func(t *testing.T) {
 		ctx := context.Background()
@@ -2254,10 +2354,17 @@ func TestDockerProvider_attemptToPullImage_retries(t *testing.T) {
 			// give a chance to retry
 			ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
 			defer cancel()
-			_ = p.attemptToPullImage(ctx, "someTag", image.PullOptions{})
+			var retries int
+			listener := PullProgressListenerFunc(func(p PullProgress) {
+				if p.Err != nil {
+					retries++
+				}
+			})
+			_ = p.attemptToPullImage(ctx, "someTag", image.PullOptions{}, listener)
 
 			assert.Positive(t, m.imagePullCount)
 			assert.Equal(t, tt.shouldRetry, m.imagePullCount > 1)
+			assert.Equal(t, m.imagePullCount-1, retries)
 		})
 	}
 }
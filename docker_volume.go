@@ -0,0 +1,274 @@
+package testcontainers
+
+import (
+	"archive/tar"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/volume"
+
+	"github.com/testcontainers/testcontainers-go/internal/core"
+)
+
+const (
+	// volumeHelperImage is the image of the helper containers used to copy data to and from a volume.
+	// The helper containers are created but never started, so the image only needs to exist.
+	volumeHelperImage = "docker.io/alpine:3.20"
+
+	// volumeHelperMountPath is the path where the volume is mounted in the helper containers.
+	volumeHelperMountPath = "/volume"
+)
+
+// DockerVolume represents a volume created using Docker
+type DockerVolume struct {
+	Name              string // Volume name from Docker
+	Driver            string
+	provider          *DockerProvider
+	terminationSignal chan bool
+}
+
+// CreateVolume creates a volume with the given options, adding the labels used by the reaper
+// to remove the volume when the session ends, unless the reaper is disabled.
+func (p *DockerProvider) CreateVolume(ctx context.Context, options volume.CreateOptions) (*DockerVolume, error) {
+	var err error
+
+	// defer the close of the Docker client connection the soonest
+	defer p.Close()
+
+	labels := make(map[string]string, len(options.Labels))
+	for k, v := range options.Labels {
+		labels[k] = v
+	}
+	options.Labels = labels
+
+	sessionID := core.SessionID()
+
+	var termSignal chan bool
+	if !p.config.RyukDisabled {
+		r, err := reuseOrCreateReaper(context.WithValue(ctx, core.DockerHostContextKey, p.host), sessionID, p)
+		if err != nil {
+			return nil, fmt.Errorf("%w: creating volume reaper failed", err)
+		}
+		termSignal, err = r.Connect()
+		if err != nil {
+			return nil, fmt.Errorf("%w: connecting to volume reaper failed", err)
+		}
+	}
+
+	// add the labels that the reaper will use to remove the volume to the request
+	for k, v := range core.DefaultLabels(sessionID) {
+		options.Labels[k] = v
+	}
+
+	// Cleanup on error, otherwise set termSignal to nil before successful return.
+	defer func() {
+		if termSignal != nil {
+			termSignal <- true
+		}
+	}()
+
+	response, err := p.client.VolumeCreate(ctx, options)
+	if err != nil {
+		return nil, err
+	}
+
+	p.track(ctx, sweptVolume, response.Name)
+
+	v := &DockerVolume{
+		Name:              response.Name,
+		Driver:            response.Driver,
+		terminationSignal: termSignal,
+		provider:          p,
+	}
+
+	// Disable cleanup on success
+	termSignal = nil
+
+	return v, nil
+}
+
+// Remove removes the volume, which must not be used by any container.
+func (v *DockerVolume) Remove(ctx context.Context) error {
+	select {
+	// close reaper if it was created
+	case v.terminationSignal <- true:
+	default:
+	}
+
+	defer v.provider.Close()
+
+	if err := v.provider.client.VolumeRemove(ctx, v.Name, false); err != nil {
+		return err
+	}
+	v.provider.untrack(sweptVolume, v.Name)
+
+	return nil
+}
+
+// CopyDirToVolume copies the content of a directory of the host to the root of the volume,
+// preserving the file modes. Only directories and regular files are supported.
+func (v *DockerVolume) CopyDirToVolume(ctx context.Context, hostDirPath string) error {
+	dir, err := isDir(hostDirPath)
+	if err != nil {
+		return err
+	}
+
+	if !dir {
+		// it's not a dir: let the consumer to handle an error
+		return fmt.Errorf("path %s is not a directory", hostDirPath)
+	}
+
+	pr, pw := io.Pipe()
+	go func() {
+		tw := tar.NewWriter(pw)
+		err := tw.AddFS(os.DirFS(hostDirPath))
+		if err == nil {
+			err = tw.Close()
+		}
+		pw.CloseWithError(err)
+	}()
+	defer pr.Close()
+
+	return v.CopyArchiveToVolume(ctx, pr)
+}
+
+// CopyArchiveToVolume extracts a tar archive, optionally compressed, to the root of the volume.
+func (v *DockerVolume) CopyArchiveToVolume(ctx context.Context, archive io.Reader) error {
+	helper, err := v.newHelper(ctx)
+	if err != nil {
+		return err
+	}
+
+	err = v.provider.client.CopyToContainer(ctx, helper.GetContainerID(), volumeHelperMountPath, archive, container.CopyToContainerOptions{})
+	if err != nil {
+		err = fmt.Errorf("copy to volume %s: %w", v.Name, err)
+	}
+
+	return errors.Join(err, helper.Terminate(ctx))
+}
+
+// ExportArchive returns an uncompressed tar archive of the content of the volume, where the paths
+// of the entries are relative to the root of the volume. The archive must be closed after reading it.
+func (v *DockerVolume) ExportArchive(ctx context.Context) (io.ReadCloser, error) {
+	helper, err := v.newHelper(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	r, _, err := v.provider.client.CopyFromContainer(ctx, helper.GetContainerID(), volumeHelperMountPath)
+	if err != nil {
+		return nil, errors.Join(fmt.Errorf("copy from volume %s: %w", v.Name, err), helper.Terminate(ctx))
+	}
+
+	// the entries of the archive are prefixed with the mount path of the volume, which is removed.
+	pr, pw := io.Pipe()
+	go func() {
+		pw.CloseWithError(rebaseArchive(pw, r, path.Base(volumeHelperMountPath)))
+	}()
+
+	return &volumeArchive{
+		ReadCloser: pr,
+		close: func() error {
+			return errors.Join(r.Close(), helper.Terminate(ctx))
+		},
+	}, nil
+}
+
+// ExportDir copies the content of the volume to a directory of the host, which is created if it
+// does not exist. Symlinks, hard links, file modes and modification times are preserved, and so
+// is the ownership of the files, if the current user is allowed to change it.
+func (v *DockerVolume) ExportDir(ctx context.Context, hostDirPath string) error {
+	helper, err := v.newHelper(ctx)
+	if err != nil {
+		return err
+	}
+
+	r, _, err := v.provider.client.CopyFromContainer(ctx, helper.GetContainerID(), volumeHelperMountPath)
+	if err != nil {
+		return errors.Join(fmt.Errorf("copy from volume %s: %w", v.Name, err), helper.Terminate(ctx))
+	}
+	defer r.Close()
+
+	err = untarDir(r, hostDirPath, path.Base(volumeHelperMountPath))
+
+	return errors.Join(err, helper.Terminate(ctx))
+}
+
+// newHelper creates a container mounting the volume, which is never started,
+// to copy data to and from the volume through the archive API of the Docker daemon.
+func (v *DockerVolume) newHelper(ctx context.Context) (Container, error) {
+	helper, err := v.provider.CreateContainer(ctx, ContainerRequest{
+		Image:  volumeHelperImage,
+		Mounts: ContainerMounts{VolumeMount(v.Name, volumeHelperMountPath)},
+	})
+	if err != nil {
+		return nil, errors.Join(fmt.Errorf("create volume helper container: %w", err), TerminateContainer(helper))
+	}
+
+	return helper, nil
+}
+
+// volumeArchive is the archive of a volume, which terminates
+// the helper container reading it once it's closed.
+type volumeArchive struct {
+	io.ReadCloser
+	close func() error
+}
+
+// Close implements io.Closer.
+func (a *volumeArchive) Close() error {
+	return errors.Join(a.ReadCloser.Close(), a.close())
+}
+
+// rebaseArchive writes to w the entries of the tar archive r under the directory baseDir,
+// with their paths relative to it, skipping the directory itself.
+func rebaseArchive(w io.Writer, r io.Reader, baseDir string) error {
+	tr := tar.NewReader(r)
+	tw := tar.NewWriter(w)
+
+	for {
+		header, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("error reading tar file: %w", err)
+		}
+
+		rel, ok := archiveRelPath(header.Name, baseDir)
+		if !ok {
+			return fmt.Errorf("entry %s is outside of directory %s", header.Name, baseDir)
+		}
+
+		if rel == "." {
+			continue
+		}
+
+		header.Name = rel
+		if header.Typeflag == tar.TypeDir {
+			header.Name += "/"
+		}
+
+		if header.Typeflag == tar.TypeLink {
+			// hard links reference other entries of the archive
+			if linkRel, ok := archiveRelPath(header.Linkname, baseDir); ok {
+				header.Linkname = linkRel
+			}
+		}
+
+		if err := tw.WriteHeader(header); err != nil {
+			return fmt.Errorf("error writing header: %w", err)
+		}
+
+		if _, err := io.Copy(tw, tr); err != nil {
+			return fmt.Errorf("error copying file: %w", err)
+		}
+	}
+
+	return tw.Close()
+}
@@ -0,0 +1,70 @@
+package testcontainers
+
+import (
+	"archive/tar"
+	"bytes"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRebaseArchive(t *testing.T) {
+	var src bytes.Buffer
+	tw := tar.NewWriter(&src)
+
+	entries := []*tar.Header{
+		{Name: "volume/", Typeflag: tar.TypeDir, Mode: 0o755},
+		{Name: "volume/conf/", Typeflag: tar.TypeDir, Mode: 0o755},
+		{Name: "volume/conf/app.properties", Typeflag: tar.TypeReg, Mode: 0o644, Size: 10},
+		{Name: "volume/link", Typeflag: tar.TypeLink, Linkname: "volume/conf/app.properties"},
+	}
+	for _, h := range entries {
+		require.NoError(t, tw.WriteHeader(h))
+		if h.Size > 0 {
+			_, err := tw.Write([]byte("port=8080\n"))
+			require.NoError(t, err)
+		}
+	}
+	require.NoError(t, tw.Close())
+
+	var dst bytes.Buffer
+	require.NoError(t, rebaseArchive(&dst, &src, "volume"))
+
+	type entry struct {
+		name     string
+		linkname string
+		content  string
+	}
+
+	var got []entry
+	tr := tar.NewReader(&dst)
+	for {
+		h, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		require.NoError(t, err)
+
+		bs, err := io.ReadAll(tr)
+		require.NoError(t, err)
+
+		got = append(got, entry{name: h.Name, linkname: h.Linkname, content: string(bs)})
+	}
+
+	require.Equal(t, []entry{
+		{name: "conf/"},
+		{name: "conf/app.properties", content: "port=8080\n"},
+		{name: "link", linkname: "conf/app.properties"},
+	}, got)
+
+	t.Run("outside", func(t *testing.T) {
+		var src bytes.Buffer
+		tw := tar.NewWriter(&src)
+		require.NoError(t, tw.WriteHeader(&tar.Header{Name: "other/file", Typeflag: tar.TypeReg}))
+		require.NoError(t, tw.Close())
+
+		require.Error(t, rebaseArchive(io.Discard, &src, "volume"))
+	})
+}
@@ -0,0 +1,264 @@
+package testcontainers
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/events"
+	"github.com/docker/docker/api/types/filters"
+	"github.com/docker/docker/pkg/stdcopy"
+
+	"github.com/testcontainers/testcontainers-go/internal/core"
+)
+
+// exitLogsTail is the number of lines of the logs of a container included in a [ContainerExit].
+const exitLogsTail = 50
+
+// ContainerEvent is an event of a container created by Testcontainers in the current test session.
+type ContainerEvent struct {
+	ContainerID string            // ID of the container
+	Name        string            // name of the container
+	Image       string            // image of the container
+	Action      string            // action of the event, as reported by Docker: create, start, die, oom, kill, stop, destroy...
+	ExitCode    int               // exit code of the container, only set for the die events
+	Time        time.Time         // time of the event
+	Attributes  map[string]string // attributes of the event, including the labels of the container
+}
+
+// ContainerExit holds the details of an unexpected exit of a container,
+// passed to the PostDies and PostOOMKills lifecycle hooks.
+type ContainerExit struct {
+	ExitCode  int    // exit code of the main process of the container
+	OOMKilled bool   // whether the container was killed for running out of memory
+	Logs      string // last lines of the logs of the container, both stdout and stderr
+}
+
+// ContainerEventsProvider is implemented by the providers which can subscribe to the events
+// of the containers of the current test session, like [DockerProvider] and [FakeProvider].
+// It's not part of [ContainerProvider], so the providers implemented outside of this package
+// don't have to implement it.
+type ContainerEventsProvider interface {
+	Events(ctx context.Context) (<-chan ContainerEvent, <-chan error)
+}
+
+var (
+	_ ContainerEventsProvider = (*DockerProvider)(nil)
+	_ ContainerEventsProvider = (*FakeProvider)(nil)
+)
+
+// Events subscribes to the events of the containers of the current test session, that is,
+// the containers labeled with the default Testcontainers labels of the session. It allows test
+// harnesses to fail fast when a dependency dies in the middle of a test.
+//
+// The events channel is closed when the context is done or the subscription fails,
+// in which case the error is sent to the error channel.
+func (p *DockerProvider) Events(ctx context.Context) (<-chan ContainerEvent, <-chan error) {
+	args := filters.NewArgs(filters.Arg("type", string(events.ContainerEventType)))
+	for k, v := range core.DefaultLabels(core.SessionID()) {
+		args.Add("label", k+"="+v)
+	}
+
+	return p.containerEvents(ctx, args)
+}
+
+// containerEvents subscribes to the container events matching the given filters,
+// converting them to [ContainerEvent].
+func (p *DockerProvider) containerEvents(ctx context.Context, args filters.Args) (<-chan ContainerEvent, <-chan error) {
+	out := make(chan ContainerEvent)
+	outErrs := make(chan error, 1)
+
+	msgs, errs := p.client.Events(ctx, events.ListOptions{Filters: args})
+
+	go func() {
+		defer close(out)
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case err := <-errs:
+				if ctx.Err() == nil {
+					outErrs <- fmt.Errorf("container events: %w", err)
+				}
+				return
+			case msg := <-msgs:
+				select {
+				case out <- newContainerEvent(msg):
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+
+	return out, outErrs
+}
+
+// newContainerEvent converts a Docker event message.
+func newContainerEvent(msg events.Message) ContainerEvent {
+	event := ContainerEvent{
+		ContainerID: msg.Actor.ID,
+		Name:        msg.Actor.Attributes["name"],
+		Image:       msg.Actor.Attributes["image"],
+		Action:      string(msg.Action),
+		Time:        time.Unix(0, msg.TimeNano),
+		Attributes:  msg.Actor.Attributes,
+	}
+
+	if code, ok := msg.Actor.Attributes["exitCode"]; ok {
+		event.ExitCode, _ = strconv.Atoi(code)
+	}
+
+	return event
+}
+
+// exitHooksKey is the key of the context passed to the exit hooks of a container, holding the
+// container, so stopping the exit watcher from the hooks, e.g. terminating the container, doesn't
+// wait for the hooks to finish.
+type exitHooksKey struct{}
+
+// startExitWatcher starts watching the container for unexpected exits, calling the PostOOMKills
+// and PostDies hooks when it happens. The watcher runs until stopExitWatcher is called or
+// the container exits, regardless of the given context being cancelled.
+func (c *DockerContainer) startExitWatcher(ctx context.Context) error {
+	// restart the watcher if the container is already being watched,
+	// or release the previous one, if the container exited.
+	c.stopExitWatcher(ctx)
+
+	ctx = context.WithoutCancel(ctx)
+	watchCtx, cancel := context.WithCancel(ctx)
+
+	args := filters.NewArgs(
+		filters.Arg("type", string(events.ContainerEventType)),
+		filters.Arg("container", c.ID),
+		filters.Arg("event", string(events.ActionOOM)),
+		filters.Arg("event", string(events.ActionDie)),
+	)
+
+	evts, errs := c.provider.containerEvents(watchCtx, args)
+
+	done := make(chan struct{})
+
+	c.exitWatcherMutex.Lock()
+	c.exitWatcherCancel = cancel
+	c.exitWatcherDone = done
+	c.exitWatcherMutex.Unlock()
+
+	go func() {
+		// closed once the exit hooks have returned, so stopping the watcher waits for them.
+		defer close(done)
+
+		oomKilled := false
+		for evt := range evts {
+			if evt.Action == string(events.ActionOOM) {
+				oomKilled = true
+				continue
+			}
+
+			if watchCtx.Err() != nil {
+				// the container was stopped on purpose at the same time
+				return
+			}
+
+			c.handleExit(context.WithValue(ctx, exitHooksKey{}, c), evt.ExitCode, oomKilled)
+			return
+		}
+
+		if err := <-errs; err != nil {
+			c.logger.Printf("failed watching container %s: %v", c.ID[:12], err)
+		}
+	}()
+
+	return nil
+}
+
+// stopExitWatcher stops watching the container for unexpected exits, waiting for the exit hooks
+// to return if the container exited, unless it's called from the exit hooks of the container.
+// It's safe to call it multiple times and concurrently.
+func (c *DockerContainer) stopExitWatcher(ctx context.Context) {
+	c.exitWatcherMutex.Lock()
+	cancel, done := c.exitWatcherCancel, c.exitWatcherDone
+	c.exitWatcherMutex.Unlock()
+
+	if cancel == nil {
+		// the container was never watched, or it's not watched anymore
+		return
+	}
+
+	cancel()
+
+	if ctx.Value(exitHooksKey{}) == c {
+		// the hooks can't wait for themselves
+		return
+	}
+
+	<-done
+
+	c.exitWatcherMutex.Lock()
+	if c.exitWatcherDone == done {
+		c.exitWatcherCancel = nil
+		c.exitWatcherDone = nil
+	}
+	c.exitWatcherMutex.Unlock()
+}
+
+// handleExit calls the exit hooks of the container once it has exited unexpectedly.
+func (c *DockerContainer) handleExit(ctx context.Context, exitCode int, oomKilled bool) {
+	c.isRunning.Store(false)
+
+	exit := ContainerExit{
+		ExitCode:  exitCode,
+		OOMKilled: oomKilled,
+	}
+
+	inspect, err := c.inspectRawContainer(ctx)
+	if err == nil && inspect.State != nil {
+		exit.ExitCode = inspect.State.ExitCode
+		exit.OOMKilled = exit.OOMKilled || inspect.State.OOMKilled
+	}
+
+	exit.Logs, err = c.logsTail(ctx, inspect)
+	if err != nil {
+		c.logger.Printf("failed reading the logs of container %s: %v", c.ID[:12], err)
+	}
+
+	var errs []error
+	if exit.OOMKilled {
+		errs = append(errs, c.oomKilledHook(ctx, exit))
+	}
+	errs = append(errs, c.diedHook(ctx, exit))
+
+	if err := errors.Join(errs...); err != nil {
+		c.logger.Printf("failed running the exit hooks of container %s: %v", c.ID[:12], err)
+	}
+}
+
+// logsTail returns the last lines of the logs of the container, both stdout and stderr.
+func (c *DockerContainer) logsTail(ctx context.Context, inspect *types.ContainerJSON) (string, error) {
+	rc, err := c.provider.client.ContainerLogs(ctx, c.ID, container.LogsOptions{
+		ShowStdout: true,
+		ShowStderr: true,
+		Tail:       strconv.Itoa(exitLogsTail),
+	})
+	if err != nil {
+		return "", err
+	}
+	defer c.provider.Close()
+	defer rc.Close()
+
+	var buf bytes.Buffer
+	if inspect != nil && inspect.Config != nil && inspect.Config.Tty {
+		_, err = io.Copy(&buf, rc)
+	} else {
+		_, err = stdcopy.StdCopy(&buf, &buf, rc)
+	}
+
+	return buf.String(), err
+}
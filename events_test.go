@@ -0,0 +1,264 @@
+package testcontainers
+
+import (
+	"context"
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/events"
+	"github.com/docker/docker/client"
+	"github.com/stretchr/testify/require"
+
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+func TestLifecycleHooks_Died(t *testing.T) {
+	ctx := context.Background()
+
+	exits := make(chan ContainerExit, 1)
+	oomKills := make(chan ContainerExit, 1)
+
+	// reqWithDiedHook {
+	req := ContainerRequest{
+		Image:      nginxAlpineImage,
+		Cmd:        []string{"sh", "-c", "echo started; sleep 2; echo crashing >&2; exit 3"},
+		WaitingFor: wait.ForLog("started"),
+		LifecycleHooks: []ContainerLifecycleHooks{
+			{
+				PostDies: []ContainerExitHook{
+					func(ctx context.Context, c Container, exit ContainerExit) error {
+						exits <- exit
+						return nil
+					},
+				},
+				PostOOMKills: []ContainerExitHook{
+					func(ctx context.Context, c Container, exit ContainerExit) error {
+						oomKills <- exit
+						return nil
+					},
+				},
+			},
+		},
+	}
+	// }
+
+	ctr, err := GenericContainer(ctx, GenericContainerRequest{
+		ContainerRequest: req,
+		ProviderType:     providerType,
+		Started:          true,
+	})
+	CleanupContainer(t, ctr)
+	require.NoError(t, err)
+
+	select {
+	case exit := <-exits:
+		require.Equal(t, 3, exit.ExitCode)
+		require.False(t, exit.OOMKilled)
+		require.Equal(t, "started\ncrashing\n", exit.Logs)
+	case <-time.After(10 * time.Second):
+		t.Fatal("the died hook was not called")
+	}
+
+	require.False(t, ctr.IsRunning())
+	require.Empty(t, oomKills)
+}
+
+func TestLifecycleHooks_DiedNotCalledOnPurpose(t *testing.T) {
+	ctx := context.Background()
+
+	exits := make(chan ContainerExit, 1)
+
+	ctr, err := GenericContainer(ctx, GenericContainerRequest{
+		ContainerRequest: ContainerRequest{
+			Image: nginxAlpineImage,
+			LifecycleHooks: []ContainerLifecycleHooks{
+				{
+					PostDies: []ContainerExitHook{
+						func(ctx context.Context, c Container, exit ContainerExit) error {
+							exits <- exit
+							return nil
+						},
+					},
+				},
+			},
+		},
+		ProviderType: providerType,
+		Started:      true,
+	})
+	CleanupContainer(t, ctr)
+	require.NoError(t, err)
+
+	require.NoError(t, ctr.Stop(ctx, nil))
+	require.NoError(t, ctr.Start(ctx))
+	require.NoError(t, ctr.Kill(ctx, "SIGKILL"))
+
+	time.Sleep(time.Second)
+	require.Empty(t, exits)
+}
+
+func TestLifecycleHooks_OOMKilled(t *testing.T) {
+	ctx := context.Background()
+
+	exits := make(chan ContainerExit, 1)
+
+	ctr, err := GenericContainer(ctx, GenericContainerRequest{
+		ContainerRequest: ContainerRequest{
+			Image:      nginxAlpineImage,
+			Cmd:        []string{"sh", "-c", "echo started; sleep 1; tail /dev/zero"},
+			WaitingFor: wait.ForLog("started"),
+			HostConfigModifier: func(hc *container.HostConfig) {
+				hc.Memory = 16 * 1024 * 1024
+				hc.MemorySwap = hc.Memory
+			},
+			LifecycleHooks: []ContainerLifecycleHooks{
+				{
+					PostOOMKills: []ContainerExitHook{
+						func(ctx context.Context, c Container, exit ContainerExit) error {
+							exits <- exit
+							return nil
+						},
+					},
+				},
+			},
+		},
+		ProviderType: providerType,
+		Started:      true,
+	})
+	CleanupContainer(t, ctr)
+	require.NoError(t, err)
+
+	select {
+	case exit := <-exits:
+		require.True(t, exit.OOMKilled)
+		require.Equal(t, 137, exit.ExitCode)
+	case <-time.After(20 * time.Second):
+		t.Fatal("the OOM killed hook was not called")
+	}
+}
+
+func TestProviderEvents(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	provider, err := NewDockerProvider()
+	require.NoError(t, err)
+	defer provider.Close()
+
+	// providerEvents {
+	events, errs := provider.Events(ctx)
+	// }
+
+	ctr, err := GenericContainer(ctx, GenericContainerRequest{
+		ContainerRequest: ContainerRequest{
+			Image:      nginxAlpineImage,
+			Cmd:        []string{"sh", "-c", "echo started; sleep 1; exit 2"},
+			WaitingFor: wait.ForLog("started"),
+		},
+		ProviderType: providerType,
+		Started:      true,
+	})
+	CleanupContainer(t, ctr)
+	require.NoError(t, err)
+
+	timeout := time.After(10 * time.Second)
+	for {
+		select {
+		case evt := <-events:
+			if evt.ContainerID != ctr.GetContainerID() || evt.Action != "die" {
+				continue
+			}
+
+			require.Equal(t, 2, evt.ExitCode)
+			return
+		case err := <-errs:
+			t.Fatal(err)
+		case <-timeout:
+			t.Fatal("the die event was not received")
+		}
+	}
+}
+
+// exitMockCli is a mock implementation of client.APIClient, reporting
+// the death of the container as soon as its events are requested.
+type exitMockCli struct {
+	client.APIClient
+}
+
+func (m *exitMockCli) Events(_ context.Context, _ events.ListOptions) (<-chan events.Message, <-chan error) {
+	msgs := make(chan events.Message, 1)
+	msgs <- events.Message{
+		Action: events.ActionDie,
+		Actor:  events.Actor{ID: "container-id", Attributes: map[string]string{"exitCode": "1"}},
+	}
+
+	return msgs, make(chan error)
+}
+
+func (m *exitMockCli) ContainerInspect(_ context.Context, _ string) (types.ContainerJSON, error) {
+	return types.ContainerJSON{}, errors.New("inspect failed")
+}
+
+func (m *exitMockCli) ContainerLogs(_ context.Context, _ string, _ container.LogsOptions) (io.ReadCloser, error) {
+	return nil, errors.New("logs failed")
+}
+
+func (m *exitMockCli) Close() error {
+	return nil
+}
+
+func TestStopExitWatcherWaitsForHooks(t *testing.T) {
+	ctx := context.Background()
+
+	started := make(chan struct{})
+	release := make(chan struct{})
+
+	ctr := &DockerContainer{
+		ID:       "container-id",
+		provider: &DockerProvider{client: &exitMockCli{}},
+		logger:   TestLogger(t),
+		lifecycleHooks: []ContainerLifecycleHooks{
+			{
+				PostDies: []ContainerExitHook{
+					func(ctx context.Context, c Container, exit ContainerExit) error {
+						close(started)
+
+						// stopping the watcher from the hooks doesn't wait for them
+						c.(*DockerContainer).stopExitWatcher(ctx)
+
+						<-release
+						return nil
+					},
+				},
+			},
+		},
+	}
+	ctr.isRunning.Store(true)
+
+	require.NoError(t, ctr.startExitWatcher(ctx))
+	<-started
+	require.False(t, ctr.IsRunning())
+
+	stopped := make(chan struct{})
+	go func() {
+		ctr.stopExitWatcher(ctx)
+		close(stopped)
+	}()
+
+	select {
+	case <-stopped:
+		t.Fatal("the exit watcher was stopped before the hooks returned")
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	close(release)
+
+	select {
+	case <-stopped:
+	case <-time.After(5 * time.Second):
+		t.Fatal("the exit watcher was not stopped after the hooks returned")
+	}
+}
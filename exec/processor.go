@@ -60,6 +60,14 @@ func WithEnv(env []string) ProcessOption {
 	})
 }
 
+// WithTTY returns a [ProcessOption] that allocates a pseudo-TTY for the command.
+// When a TTY is allocated, stdout and stderr are combined into a single raw stream.
+func WithTTY() ProcessOption {
+	return ProcessOptionFunc(func(opts *ProcessOptions) {
+		opts.ExecConfig.Tty = true
+	})
+}
+
 // Multiplexed returns a [ProcessOption] that configures the command execution
 // to combine stdout and stderr into a single stream without Docker's multiplexing headers.
 func Multiplexed() ProcessOption {
@@ -0,0 +1,291 @@
+package testcontainers
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/pkg/stdcopy"
+	"github.com/google/uuid"
+
+	tcexec "github.com/testcontainers/testcontainers-go/exec"
+)
+
+// execSessionEnv is the environment variable used to identify the processes of an exec session,
+// so they can be killed when the context of the session is cancelled.
+const execSessionEnv = "TESTCONTAINERS_EXEC_SESSION"
+
+// ExecSession is a command running interactively in a container, created with
+// [DockerContainer.ExecSession].
+//
+// The output of the command is buffered, so Stdout and Stderr can be read
+// independently, in any order, without blocking the command.
+type ExecSession struct {
+	// Stdin is the standard input of the command. Closing it sends EOF to the command.
+	Stdin io.WriteCloser
+
+	// Stdout is the standard output of the command. If a TTY was allocated,
+	// it contains the combined output of the command.
+	Stdout io.Reader
+
+	// Stderr is the standard error of the command. If a TTY was allocated,
+	// it's always empty, as the output is combined in Stdout.
+	Stderr io.Reader
+
+	resize func(ctx context.Context, height, width uint) error
+	close  func() error
+
+	done     chan struct{}
+	exitCode int
+	err      error
+}
+
+// Resize changes the size of the TTY of the command.
+// It fails if no TTY was allocated, see [tcexec.WithTTY].
+func (s *ExecSession) Resize(ctx context.Context, height, width uint) error {
+	if s.resize == nil {
+		return errors.New("resize exec session: no TTY allocated")
+	}
+
+	return s.resize(ctx, height, width)
+}
+
+// Done returns a channel that's closed when the command has exited.
+func (s *ExecSession) Done() <-chan struct{} {
+	return s.done
+}
+
+// Wait waits for the command to exit, returning its exit code,
+// or the context error if the context is done first.
+func (s *ExecSession) Wait(ctx context.Context) (int, error) {
+	select {
+	case <-ctx.Done():
+		return 0, ctx.Err()
+	case <-s.done:
+		return s.exitCode, s.err
+	}
+}
+
+// Close closes the connection to the command. It does not wait for the command to exit.
+func (s *ExecSession) Close() error {
+	if s.close == nil {
+		return nil
+	}
+
+	return s.close()
+}
+
+// ExecSession starts a command in the container, attaching its standard input and
+// returning its standard output and error as separate streams.
+// Use [tcexec.WithTTY] to allocate a pseudo-TTY for the command.
+//
+// When the context is cancelled before the command exits, the command, and the processes
+// started by it, are killed. This relies on sh, tr, grep and kill being available in the
+// container, so the processes with the session environment variable can be found.
+func (c *DockerContainer) ExecSession(ctx context.Context, cmd []string, options ...tcexec.ProcessOption) (*ExecSession, error) {
+	cli := c.provider.client
+
+	processOptions := tcexec.NewProcessOptions(cmd)
+	for _, o := range options {
+		o.Apply(processOptions)
+	}
+
+	sessionID := uuid.NewString()
+
+	execConfig := processOptions.ExecConfig
+	execConfig.AttachStdin = true
+	execConfig.AttachStdout = true
+	execConfig.AttachStderr = true
+	execConfig.Detach = false
+	execConfig.Env = append(append([]string{}, execConfig.Env...), execSessionEnv+"="+sessionID)
+
+	response, err := cli.ContainerExecCreate(ctx, c.ID, execConfig)
+	if err != nil {
+		return nil, fmt.Errorf("container exec create: %w", err)
+	}
+
+	hijack, err := cli.ContainerExecAttach(ctx, response.ID, container.ExecAttachOptions{
+		Tty:         execConfig.Tty,
+		ConsoleSize: execConfig.ConsoleSize,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("container exec attach: %w", err)
+	}
+
+	stdout := newBufferedPipe()
+	stderr := newBufferedPipe()
+
+	session := &ExecSession{
+		Stdin:  &execSessionStdin{hijack: hijack.Conn, closeWrite: hijack.CloseWrite},
+		Stdout: stdout,
+		Stderr: stderr,
+		close: func() error {
+			hijack.Close()
+			return nil
+		},
+		done: make(chan struct{}),
+	}
+
+	if execConfig.Tty {
+		session.resize = func(ctx context.Context, height, width uint) error {
+			return cli.ContainerExecResize(ctx, response.ID, container.ResizeOptions{Height: height, Width: width})
+		}
+	}
+
+	go func() {
+		defer close(session.done)
+
+		var err error
+		if execConfig.Tty {
+			_, err = io.Copy(stdout, hijack.Reader)
+		} else {
+			_, err = stdcopy.StdCopy(stdout, stderr, hijack.Reader)
+		}
+		if errors.Is(err, net.ErrClosed) {
+			// the connection was closed because the session was closed or cancelled.
+			err = nil
+		}
+		stdout.CloseWithError(err)
+		stderr.CloseWithError(err)
+		hijack.Close()
+
+		// the stream is closed once the command exits, but the exit code may not be available yet.
+		// Use a new context, as the session context could have been cancelled to kill the command.
+		inspectCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		for {
+			execResp, err := cli.ContainerExecInspect(inspectCtx, response.ID)
+			if err != nil {
+				session.err = fmt.Errorf("container exec inspect: %w", err)
+				return
+			}
+
+			if !execResp.Running {
+				session.exitCode = execResp.ExitCode
+				return
+			}
+
+			select {
+			case <-inspectCtx.Done():
+				session.err = fmt.Errorf("container exec inspect: %w", inspectCtx.Err())
+				return
+			case <-time.After(100 * time.Millisecond):
+			}
+		}
+	}()
+
+	go func() {
+		select {
+		case <-session.done:
+		case <-ctx.Done():
+			c.killExecSession(sessionID)
+			hijack.Close()
+		}
+	}()
+
+	return session, nil
+}
+
+// killExecSession kills the processes started by the exec session with the given ID.
+func (c *DockerContainer) killExecSession(sessionID string) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	script := fmt.Sprintf(
+		`for p in /proc/[0-9]*; do if tr '\0' '\n' < "$p/environ" 2>/dev/null | grep -qx '%s=%s'; then kill -9 "${p#/proc/}" 2>/dev/null; fi; done`,
+		execSessionEnv, sessionID,
+	)
+
+	_, _, err := c.Exec(ctx, []string{"sh", "-c", script}, tcexec.WithUser("0"))
+	if err != nil {
+		c.logger.Printf("failed to kill exec session %s: %v", sessionID, err)
+	}
+}
+
+// execSessionStdin is the standard input of an exec session, closing
+// the write side of the connection when it's closed.
+type execSessionStdin struct {
+	hijack     io.Writer
+	closeWrite func() error
+}
+
+// Write writes p to the standard input of the command.
+func (s *execSessionStdin) Write(p []byte) (int, error) {
+	return s.hijack.Write(p)
+}
+
+// Close closes the standard input of the command.
+func (s *execSessionStdin) Close() error {
+	return s.closeWrite()
+}
+
+// bufferedPipe is an in-memory pipe with an unbounded buffer, so writes never block.
+type bufferedPipe struct {
+	mtx  sync.Mutex
+	cond *sync.Cond
+	buf  bytes.Buffer
+	err  error
+}
+
+// newBufferedPipe returns an empty buffered pipe.
+func newBufferedPipe() *bufferedPipe {
+	p := &bufferedPipe{}
+	p.cond = sync.NewCond(&p.mtx)
+
+	return p
+}
+
+// Write appends b to the buffer, waking up the pending readers.
+func (p *bufferedPipe) Write(b []byte) (int, error) {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+
+	if p.err != nil {
+		return 0, io.ErrClosedPipe
+	}
+
+	n, err := p.buf.Write(b)
+	p.cond.Broadcast()
+
+	return n, err
+}
+
+// Read reads from the buffer, blocking until there is data available or the pipe is closed.
+func (p *bufferedPipe) Read(b []byte) (int, error) {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+
+	for p.buf.Len() == 0 && p.err == nil {
+		p.cond.Wait()
+	}
+
+	if p.buf.Len() > 0 {
+		return p.buf.Read(b)
+	}
+
+	return 0, p.err
+}
+
+// CloseWithError closes the pipe, so readers get the error once the buffer is drained.
+// A nil error is reported as io.EOF.
+func (p *bufferedPipe) CloseWithError(err error) {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+
+	if err == nil {
+		err = io.EOF
+	}
+
+	if p.err == nil {
+		p.err = err
+	}
+
+	p.cond.Broadcast()
+}
@@ -0,0 +1,1533 @@
+package testcontainers
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing/fstest"
+	"time"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/network"
+	"github.com/docker/docker/errdefs"
+	"github.com/docker/go-connections/nat"
+
+	tcexec "github.com/testcontainers/testcontainers-go/exec"
+	"github.com/testcontainers/testcontainers-go/internal/core"
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+// Implement interfaces
+var (
+	_ Container           = (*FakeContainer)(nil)
+	_ wait.StrategyTarget = (*FakeContainer)(nil)
+)
+
+// fakeFile is a file stored in a fake container.
+type fakeFile struct {
+	content []byte
+	mode    int64
+}
+
+// FakeContainer is an in-memory [Container] created by a [FakeProvider].
+// It keeps the state of the container, its files and its logs in memory, so the
+// wiring of a request can be asserted through [FakeContainer.Inspect].
+//
+// The responses of the container can be scripted using the exported function fields,
+// which take precedence over the in-memory state when set.
+type FakeContainer struct {
+	// MappedPortFunc, if set, returns the mapped port for the given container port.
+	MappedPortFunc func(ctx context.Context, port nat.Port) (nat.Port, error)
+
+	// ExecFunc, if set, is called for every executed command. The command is recorded in any case.
+	ExecFunc func(ctx context.Context, cmd []string, options ...tcexec.ProcessOption) (int, io.Reader, error)
+
+	// LogsFunc, if set, returns the logs of the container.
+	LogsFunc func(ctx context.Context) (io.ReadCloser, error)
+
+	// CopyFileFromContainerFunc, if set, returns the content of a file in the container.
+	CopyFileFromContainerFunc func(ctx context.Context, filePath string) (io.ReadCloser, error)
+
+	// StartFunc, if set, is called when the container is started, before any of the PostStarts hooks.
+	// Returning an error makes the start fail, as if the container runtime failed.
+	StartFunc func(ctx context.Context) error
+
+	// WaitingFor is the wait strategy executed once the container is started.
+	WaitingFor wait.Strategy
+
+	// Image is the name of the image, after applying the image substitutors.
+	Image string
+
+	id             string
+	name           string
+	created        time.Time
+	sessionID      string
+	request        ContainerRequest
+	provider       *FakeProvider
+	logger         Logging
+	lifecycleHooks []ContainerLifecycleHooks
+	exposedPorts   []string
+
+	mtx        sync.Mutex
+	config     *container.Config
+	hostConfig *container.HostConfig
+	endpoints  map[string]*network.EndpointSettings
+	ports      nat.PortMap
+	ipAddress  string
+	status     string
+	running    bool
+	exitCode   int
+	paused     bool
+	removed    bool
+	files      map[string]fakeFile
+	snapshots  map[string]map[string]fakeFile
+	logs       []Log
+	consumers  []LogConsumer
+	stats      []StatsConsumer
+	execs      [][]string
+	events     []string
+}
+
+// newFakeContainer returns a created fake container for the request, using the configuration
+// built by the pre-create hooks.
+func newFakeContainer(req ContainerRequest, cfg *container.Config, hostConfig *container.HostConfig, networkingConfig *network.NetworkingConfig, p *FakeProvider) *FakeContainer {
+	id := randomFakeID()
+
+	name := req.Name
+	if name == "" {
+		name = "fake_" + id[:12]
+	}
+
+	endpoints := map[string]*network.EndpointSettings{}
+	for n, settings := range networkingConfig.EndpointsConfig {
+		endpoints[n] = settings
+	}
+
+	// attach the container to the rest of the networks, as the Docker provider does
+	if len(req.Networks) > 1 {
+		for _, n := range req.Networks[1:] {
+			endpoints[n] = &network.EndpointSettings{
+				Aliases: req.NetworkAliases[n],
+			}
+		}
+	}
+
+	if len(endpoints) == 0 && !hostConfig.NetworkMode.IsContainer() && !hostConfig.NetworkMode.IsHost() {
+		endpoints[Bridge] = &network.EndpointSettings{}
+	}
+
+	ports := nat.PortMap{}
+	for port, bindings := range hostConfig.PortBindings {
+		ports[port] = append([]nat.PortBinding{}, bindings...)
+	}
+
+	c := &FakeContainer{
+		WaitingFor:     req.WaitingFor,
+		Image:          cfg.Image,
+		id:             id,
+		name:           name,
+		created:        time.Now(),
+		sessionID:      core.SessionID(),
+		request:        req,
+		provider:       p,
+		logger:         p.logger,
+		lifecycleHooks: req.LifecycleHooks,
+		exposedPorts:   req.ExposedPorts,
+		config:         cfg,
+		hostConfig:     hostConfig,
+		endpoints:      endpoints,
+		ports:          ports,
+		status:         "created",
+		files:          map[string]fakeFile{},
+	}
+	c.recordEvents("create")
+
+	return c
+}
+
+// fakePortBinding returns a binding of a container port to the given host port.
+func fakePortBinding(hostPort int) nat.PortBinding {
+	return nat.PortBinding{HostIP: "0.0.0.0", HostPort: fmt.Sprintf("%d", hostPort)}
+}
+
+// Request returns the container request used to create the container,
+// including the lifecycle hooks combined with the default ones.
+func (c *FakeContainer) Request() ContainerRequest {
+	return c.request
+}
+
+// Execs returns the commands executed in the container, in order.
+func (c *FakeContainer) Execs() [][]string {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	return append([][]string{}, c.execs...)
+}
+
+// Lifecycle returns the events of the container, in order, using the names of
+// the Docker events: create, start, pause, unpause, kill, die, stop and destroy.
+func (c *FakeContainer) Lifecycle() []string {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	return append([]string{}, c.events...)
+}
+
+// Files returns the paths of the files copied to the container, sorted.
+func (c *FakeContainer) Files() []string {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	paths := make([]string, 0, len(c.files))
+	for p := range c.files {
+		paths = append(paths, p)
+	}
+	sort.Strings(paths)
+
+	return paths
+}
+
+// SetFile stores a file in the container, so it can be read with
+// [FakeContainer.CopyFileFromContainer].
+func (c *FakeContainer) SetFile(containerFilePath string, content []byte, fileMode int64) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	c.files[containerFilePath] = fakeFile{content: content, mode: fileMode}
+}
+
+// WriteLogs appends content to the logs of the container. If the container is producing logs
+// for log consumers, the content is sent to them too. The log type is either [StdoutLog] or [StderrLog].
+func (c *FakeContainer) WriteLogs(logType string, content []byte) {
+	c.mtx.Lock()
+	l := Log{LogType: logType, Content: content}
+	c.logs = append(c.logs, l)
+	consumers := append([]LogConsumer{}, c.consumers...)
+	c.mtx.Unlock()
+
+	for _, consumer := range consumers {
+		consumer.Accept(l)
+	}
+}
+
+// WriteStats sends a stats sample to the stats consumers of the container, if it's producing stats.
+// The ContainerID of the sample is set to the ID of the container.
+func (c *FakeContainer) WriteStats(s Stats) {
+	c.mtx.Lock()
+	s.ContainerID = c.id
+	consumers := append([]StatsConsumer{}, c.stats...)
+	c.mtx.Unlock()
+
+	for _, consumer := range consumers {
+		consumer.Accept(s)
+	}
+}
+
+// Exit simulates the main process of the container exiting with the given code.
+// The PostStops hooks are not executed, as the container was not stopped on purpose,
+// but the PostDies hooks are, as the Docker container does for unexpected exits.
+func (c *FakeContainer) Exit(exitCode int) {
+	c.exit(exitCode, false)
+}
+
+// OOMKill simulates the container being killed for running out of memory,
+// running the PostOOMKills and PostDies hooks.
+func (c *FakeContainer) OOMKill() {
+	c.exit(137, true)
+}
+
+// exit marks the container as exited, running the exit hooks if it was running.
+func (c *FakeContainer) exit(exitCode int, oomKilled bool) {
+	c.mtx.Lock()
+	wasRunning := c.running
+	c.running = false
+	c.paused = false
+	c.status = "exited"
+	c.exitCode = exitCode
+	if oomKilled {
+		c.recordEvents("oom")
+	}
+	c.recordEvents("die")
+
+	exit := ContainerExit{
+		ExitCode:  exitCode,
+		OOMKilled: oomKilled,
+		Logs:      c.logsTail(),
+	}
+	c.mtx.Unlock()
+
+	if !wasRunning {
+		return
+	}
+
+	ctx := context.Background()
+
+	var errs []error
+	if oomKilled {
+		errs = append(errs, c.applyExitHooks(ctx, exit, func(lifecycleHooks ContainerLifecycleHooks) []ContainerExitHook {
+			return lifecycleHooks.PostOOMKills
+		}))
+	}
+	errs = append(errs, c.applyExitHooks(ctx, exit, func(lifecycleHooks ContainerLifecycleHooks) []ContainerExitHook {
+		return lifecycleHooks.PostDies
+	}))
+
+	if err := errors.Join(errs...); err != nil {
+		c.logger.Printf("failed running the exit hooks of container %s: %v", c.id[:12], err)
+	}
+}
+
+// logsTail returns the last lines of the logs of the container. It must be called with the lock held.
+func (c *FakeContainer) logsTail() string {
+	var buf bytes.Buffer
+	for _, l := range c.logs {
+		buf.Write(l.Content)
+	}
+
+	lines := strings.SplitAfter(buf.String(), "\n")
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+
+	return strings.Join(lines[max(0, len(lines)-exitLogsTail):], "")
+}
+
+// recordEvents records the events of the container, publishing them to the subscribers
+// of the provider events. It must be called with the lock held.
+func (c *FakeContainer) recordEvents(actions ...string) {
+	c.events = append(c.events, actions...)
+
+	for _, action := range actions {
+		attributes := map[string]string{
+			"name":  c.name,
+			"image": c.Image,
+		}
+		for k, v := range c.config.Labels {
+			attributes[k] = v
+		}
+
+		event := ContainerEvent{
+			ContainerID: c.id,
+			Name:        c.name,
+			Image:       c.Image,
+			Action:      action,
+			Time:        time.Now(),
+			Attributes:  attributes,
+		}
+
+		if action == "die" {
+			event.ExitCode = c.exitCode
+			attributes["exitCode"] = strconv.Itoa(c.exitCode)
+		}
+
+		c.provider.publish(event)
+	}
+}
+
+// GetContainerID returns the random ID assigned to the fake container.
+func (c *FakeContainer) GetContainerID() string {
+	return c.id
+}
+
+// Endpoint gets proto://host:port string for the lowest numbered exposed port
+// Will returns just host:port if proto is ""
+func (c *FakeContainer) Endpoint(ctx context.Context, proto string) (string, error) {
+	inspect, err := c.Inspect(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	// Get lowest numbered bound port.
+	var lowestPort nat.Port
+	for port := range inspect.NetworkSettings.Ports {
+		if lowestPort == "" || port.Int() < lowestPort.Int() {
+			lowestPort = port
+		}
+	}
+
+	return c.PortEndpoint(ctx, lowestPort, proto)
+}
+
+// PortEndpoint gets proto://host:port string for the given exposed port
+// Will returns just host:port if proto is ""
+func (c *FakeContainer) PortEndpoint(ctx context.Context, port nat.Port, proto string) (string, error) {
+	host, err := c.Host(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	outerPort, err := c.MappedPort(ctx, port)
+	if err != nil {
+		return "", err
+	}
+
+	protoFull := ""
+	if proto != "" {
+		protoFull = fmt.Sprintf("%s://", proto)
+	}
+
+	return fmt.Sprintf("%s%s:%s", protoFull, host, outerPort.Port()), nil
+}
+
+// Host always returns localhost for the fake container.
+func (c *FakeContainer) Host(_ context.Context) (string, error) {
+	return "localhost", nil
+}
+
+// Inspect returns the container info built from the in-memory state, which reflects
+// the configuration applied by the request and its modifiers.
+// It returns a not found error once the container has been terminated.
+func (c *FakeContainer) Inspect(_ context.Context) (*types.ContainerJSON, error) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	if c.removed {
+		return nil, c.notFound()
+	}
+
+	ports := nat.PortMap{}
+	for port, bindings := range c.ports {
+		ports[port] = append([]nat.PortBinding{}, bindings...)
+	}
+
+	networks := map[string]*network.EndpointSettings{}
+	for n, settings := range c.endpoints {
+		s := *settings
+		s.IPAddress = c.ipAddress
+		networks[n] = &s
+	}
+
+	var ipAddress string
+	if _, ok := networks[Bridge]; ok {
+		ipAddress = c.ipAddress
+	}
+
+	return &types.ContainerJSON{
+		ContainerJSONBase: &types.ContainerJSONBase{
+			ID:         c.id,
+			Created:    c.created.Format(time.RFC3339Nano),
+			Name:       "/" + c.name,
+			Image:      c.Image,
+			State:      c.state(),
+			HostConfig: c.hostConfig,
+		},
+		Config: c.config,
+		NetworkSettings: &types.NetworkSettings{
+			NetworkSettingsBase: types.NetworkSettingsBase{
+				Ports: ports,
+			},
+			DefaultNetworkSettings: types.DefaultNetworkSettings{
+				IPAddress: ipAddress,
+			},
+			Networks: networks,
+		},
+	}, nil
+}
+
+// MappedPort gets externally mapped port for a container port.
+func (c *FakeContainer) MappedPort(ctx context.Context, port nat.Port) (nat.Port, error) {
+	if c.MappedPortFunc != nil {
+		return c.MappedPortFunc(ctx, port)
+	}
+
+	inspect, err := c.Inspect(ctx)
+	if err != nil {
+		return "", err
+	}
+	if inspect.ContainerJSONBase.HostConfig.NetworkMode == "host" {
+		return port, nil
+	}
+
+	for k, p := range inspect.NetworkSettings.Ports {
+		if k.Port() != port.Port() {
+			continue
+		}
+		if port.Proto() != "" && k.Proto() != port.Proto() {
+			continue
+		}
+		if len(p) == 0 {
+			continue
+		}
+		return nat.NewPort(k.Proto(), p[0].HostPort)
+	}
+
+	return "", errors.New("port not found")
+}
+
+// Deprecated: use c.Inspect(ctx).NetworkSettings.Ports instead.
+// Ports gets the exposed ports for the container.
+func (c *FakeContainer) Ports(ctx context.Context) (nat.PortMap, error) {
+	inspect, err := c.Inspect(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return inspect.NetworkSettings.Ports, nil
+}
+
+// SessionID gets the current session id
+func (c *FakeContainer) SessionID() string {
+	return c.sessionID
+}
+
+// IsRunning returns true if the container is running, false otherwise.
+func (c *FakeContainer) IsRunning() bool {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	return c.running
+}
+
+// Start runs the start hooks of the container, marking it as running,
+// and waits for it to be ready using its wait strategy.
+func (c *FakeContainer) Start(ctx context.Context) error {
+	err := c.startingHook(ctx)
+	if err != nil {
+		return fmt.Errorf("starting hook: %w", err)
+	}
+
+	if err := c.start(ctx); err != nil {
+		return fmt.Errorf("container start: %w", err)
+	}
+
+	err = c.startedHook(ctx)
+	if err != nil {
+		return fmt.Errorf("started hook: %w", err)
+	}
+
+	err = c.readiedHook(ctx)
+	if err != nil {
+		return fmt.Errorf("readied hook: %w", err)
+	}
+
+	return nil
+}
+
+// start marks the container as running, unless StartFunc fails.
+func (c *FakeContainer) start(ctx context.Context) error {
+	if c.StartFunc != nil {
+		if err := c.StartFunc(ctx); err != nil {
+			return err
+		}
+	}
+
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	if c.removed {
+		return c.notFound()
+	}
+
+	if !c.running {
+		c.running = true
+		c.status = "running"
+		c.exitCode = 0
+		c.recordEvents("start")
+	}
+
+	return nil
+}
+
+// Stop runs the stop hooks of the container, marking it as exited.
+// If the container is already stopped, only the hooks are executed.
+func (c *FakeContainer) Stop(ctx context.Context, _ *time.Duration) error {
+	err := c.stoppingHook(ctx)
+	if err != nil {
+		return fmt.Errorf("stopping hook: %w", err)
+	}
+
+	if err := c.stop(); err != nil {
+		return fmt.Errorf("container stop: %w", err)
+	}
+
+	err = c.stoppedHook(ctx)
+	if err != nil {
+		return fmt.Errorf("stopped hook: %w", err)
+	}
+
+	return nil
+}
+
+// stop marks the container as exited.
+func (c *FakeContainer) stop() error {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	if c.removed {
+		return c.notFound()
+	}
+
+	if c.running {
+		c.running = false
+		c.paused = false
+		c.status = "exited"
+		c.recordEvents("stop")
+	}
+
+	return nil
+}
+
+// Restart stops and starts the container, running the stop and start hooks and the wait strategy.
+// The host ports of the fake container never change, so it's never replaced, unlike the Docker container.
+func (c *FakeContainer) Restart(ctx context.Context, opts ...RestartOption) error {
+	options := &restartOptions{}
+	for _, opt := range opts {
+		opt(options)
+	}
+
+	err := c.Stop(ctx, options.timeout)
+	if err != nil {
+		return fmt.Errorf("stop container: %w", err)
+	}
+
+	return c.Start(ctx)
+}
+
+// Pause runs the pause hooks of the container, marking it as paused.
+// As with Docker, a paused container is still running.
+func (c *FakeContainer) Pause(ctx context.Context) error {
+	err := c.pausingHook(ctx)
+	if err != nil {
+		return fmt.Errorf("pausing hook: %w", err)
+	}
+
+	if err := c.setPaused(true); err != nil {
+		return fmt.Errorf("container pause: %w", err)
+	}
+
+	err = c.pausedHook(ctx)
+	if err != nil {
+		return fmt.Errorf("paused hook: %w", err)
+	}
+
+	return nil
+}
+
+// Unpause runs the unpause hooks of the container, marking it as running again.
+func (c *FakeContainer) Unpause(ctx context.Context) error {
+	err := c.unpausingHook(ctx)
+	if err != nil {
+		return fmt.Errorf("unpausing hook: %w", err)
+	}
+
+	if err := c.setPaused(false); err != nil {
+		return fmt.Errorf("container unpause: %w", err)
+	}
+
+	err = c.unpausedHook(ctx)
+	if err != nil {
+		return fmt.Errorf("unpaused hook: %w", err)
+	}
+
+	return nil
+}
+
+// setPaused pauses or unpauses the container, failing as the Docker daemon does
+// if the container is not running, or not paused.
+func (c *FakeContainer) setPaused(paused bool) error {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	if c.removed {
+		return c.notFound()
+	}
+
+	if !c.running {
+		return errdefs.Conflict(fmt.Errorf("Container %s is not running", c.id))
+	}
+
+	if paused {
+		if c.paused {
+			return errdefs.Conflict(fmt.Errorf("Container %s is already paused", c.id))
+		}
+
+		c.paused = true
+		c.status = "paused"
+		c.recordEvents("pause")
+
+		return nil
+	}
+
+	if !c.paused {
+		return errdefs.Conflict(fmt.Errorf("Container %s is not paused", c.id))
+	}
+
+	c.paused = false
+	c.status = "running"
+	c.recordEvents("unpause")
+
+	return nil
+}
+
+// Kill runs the kill hooks of the container. SIGKILL, which is sent if the signal is empty,
+// makes the container exit with code 137, while the rest of the signals are only recorded.
+// Use [FakeContainer.Exit] to simulate the container exiting after receiving a signal.
+func (c *FakeContainer) Kill(ctx context.Context, signal string) error {
+	err := c.killingHook(ctx)
+	if err != nil {
+		return fmt.Errorf("killing hook: %w", err)
+	}
+
+	if err := c.kill(signal); err != nil {
+		return fmt.Errorf("container kill: %w", err)
+	}
+
+	err = c.killedHook(ctx)
+	if err != nil {
+		return fmt.Errorf("killed hook: %w", err)
+	}
+
+	return nil
+}
+
+// kill sends the signal to the container, failing as the Docker daemon does
+// if the container is not running.
+func (c *FakeContainer) kill(signal string) error {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	if c.removed {
+		return c.notFound()
+	}
+
+	if !c.running {
+		return errdefs.Conflict(fmt.Errorf("Container %s is not running", c.id))
+	}
+
+	c.recordEvents("kill")
+
+	if isKillSignal(signal) {
+		c.running = false
+		c.paused = false
+		c.status = "exited"
+		c.exitCode = 137
+		c.recordEvents("die")
+	}
+
+	return nil
+}
+
+// Snapshot stores a copy of the files of the container under the given name.
+func (c *FakeContainer) Snapshot(_ context.Context, name string) error {
+	if name == "" {
+		return errors.New("snapshot name must not be empty")
+	}
+
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	if c.removed {
+		return fmt.Errorf("container commit: %w", c.notFound())
+	}
+
+	if c.snapshots == nil {
+		c.snapshots = make(map[string]map[string]fakeFile)
+	}
+
+	files := make(map[string]fakeFile, len(c.files))
+	for p, f := range c.files {
+		files[p] = f
+	}
+	c.snapshots[name] = files
+
+	return nil
+}
+
+// Restore replaces the container with a new one, with a new ID, the same host ports
+// and the files stored in the snapshot with the given name. As the Docker container does,
+// the stop hooks are called for the current container, and the start hooks for the new one.
+func (c *FakeContainer) Restore(ctx context.Context, name string) error {
+	c.mtx.Lock()
+	files, ok := c.snapshots[name]
+	c.mtx.Unlock()
+
+	if !ok {
+		return fmt.Errorf("snapshot %q not found", name)
+	}
+
+	err := c.Stop(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("stop container: %w", err)
+	}
+
+	err = c.startingHook(ctx)
+	if err != nil {
+		return fmt.Errorf("starting hook: %w", err)
+	}
+
+	c.mtx.Lock()
+	c.id = randomFakeID()
+	c.files = make(map[string]fakeFile, len(files))
+	for p, f := range files {
+		c.files[p] = f
+	}
+	c.logs = nil
+	c.recordEvents("destroy", "create")
+	c.mtx.Unlock()
+
+	if err := c.start(ctx); err != nil {
+		return fmt.Errorf("container start: %w", err)
+	}
+
+	err = c.startedHook(ctx)
+	if err != nil {
+		return fmt.Errorf("started hook: %w", err)
+	}
+
+	err = c.readiedHook(ctx)
+	if err != nil {
+		return fmt.Errorf("readied hook: %w", err)
+	}
+
+	return nil
+}
+
+// Terminate stops the container and removes it, running the terminate hooks
+// as the Docker container does. Once terminated, the container is not found anymore.
+func (c *FakeContainer) Terminate(ctx context.Context) error {
+	timeout := 10 * time.Second
+	err := c.Stop(ctx, &timeout)
+	if err != nil && !isCleanupSafe(err) {
+		return fmt.Errorf("stop: %w", err)
+	}
+
+	errs := []error{
+		c.terminatingHook(ctx),
+		c.remove(),
+		c.terminatedHook(ctx),
+	}
+
+	c.sessionID = ""
+
+	c.mtx.Lock()
+	c.snapshots = nil
+	c.mtx.Unlock()
+
+	return errors.Join(errs...)
+}
+
+// remove marks the container as removed.
+func (c *FakeContainer) remove() error {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	if c.removed {
+		return c.notFound()
+	}
+
+	c.removed = true
+	c.running = false
+	c.status = "removing"
+	c.recordEvents("destroy")
+
+	return nil
+}
+
+// isTerminated returns true if the container has been removed.
+func (c *FakeContainer) isTerminated() bool {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	return c.removed
+}
+
+// Logs returns the logs written to the container, without the multiplexing headers,
+// as the Docker container does.
+func (c *FakeContainer) Logs(ctx context.Context) (io.ReadCloser, error) {
+	if c.LogsFunc != nil {
+		return c.LogsFunc(ctx)
+	}
+
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	if c.removed {
+		return nil, c.notFound()
+	}
+
+	var buf bytes.Buffer
+	for _, l := range c.logs {
+		buf.Write(l.Content)
+	}
+
+	return io.NopCloser(&buf), nil
+}
+
+// Deprecated: it will be removed in the next major release.
+// FollowOutput adds a LogConsumer to be sent logs from the container's
+// STDOUT and STDERR
+func (c *FakeContainer) FollowOutput(consumer LogConsumer) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	c.consumers = append(c.consumers, consumer)
+}
+
+// Deprecated: use the ContainerRequest.LogConsumerConfig field instead.
+// StartLogProducer sends the logs already written to the container to the log consumers.
+func (c *FakeContainer) StartLogProducer(_ context.Context, _ ...LogProductionOption) error {
+	c.mtx.Lock()
+	logs := append([]Log{}, c.logs...)
+	consumers := append([]LogConsumer{}, c.consumers...)
+	c.mtx.Unlock()
+
+	for _, l := range logs {
+		for _, consumer := range consumers {
+			consumer.Accept(l)
+		}
+	}
+
+	return nil
+}
+
+// Deprecated: it will be removed in the next major release.
+// StopLogProducer stops sending logs to the log consumers.
+func (c *FakeContainer) StopLogProducer() error {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	c.consumers = nil
+
+	return nil
+}
+
+// Deprecated: use c.Inspect(ctx).Name instead.
+// Name gets the name of the container.
+func (c *FakeContainer) Name(ctx context.Context) (string, error) {
+	inspect, err := c.Inspect(ctx)
+	if err != nil {
+		return "", err
+	}
+	return inspect.Name, nil
+}
+
+// State returns container's running state.
+func (c *FakeContainer) State(ctx context.Context) (*types.ContainerState, error) {
+	inspect, err := c.Inspect(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return inspect.State, nil
+}
+
+// Networks gets the names of the networks the container is attached to.
+func (c *FakeContainer) Networks(ctx context.Context) ([]string, error) {
+	inspect, err := c.Inspect(ctx)
+	if err != nil {
+		return []string{}, err
+	}
+
+	n := []string{}
+	for k := range inspect.NetworkSettings.Networks {
+		n = append(n, k)
+	}
+
+	return n, nil
+}
+
+// NetworkAliases gets the aliases of the container for the networks it is attached to.
+func (c *FakeContainer) NetworkAliases(ctx context.Context) (map[string][]string, error) {
+	inspect, err := c.Inspect(ctx)
+	if err != nil {
+		return map[string][]string{}, err
+	}
+
+	a := map[string][]string{}
+	for k, settings := range inspect.NetworkSettings.Networks {
+		a[k] = settings.Aliases
+	}
+
+	return a, nil
+}
+
+// Exec records the command and returns the response of ExecFunc, if set.
+// Otherwise it returns a zero exit code and an empty output.
+func (c *FakeContainer) Exec(ctx context.Context, cmd []string, options ...tcexec.ProcessOption) (int, io.Reader, error) {
+	c.mtx.Lock()
+	if c.removed {
+		c.mtx.Unlock()
+		return 0, nil, fmt.Errorf("container exec create: %w", c.notFound())
+	}
+	c.execs = append(c.execs, append([]string{}, cmd...))
+	c.mtx.Unlock()
+
+	if c.ExecFunc != nil {
+		return c.ExecFunc(ctx, cmd, options...)
+	}
+
+	processOptions := tcexec.NewProcessOptions(cmd)
+	for _, o := range options {
+		o.Apply(processOptions)
+	}
+
+	processOptions.Reader = bytes.NewReader(nil)
+	for _, o := range options {
+		o.Apply(processOptions)
+	}
+
+	return 0, processOptions.Reader, nil
+}
+
+// ExecSession runs the command as [FakeContainer.Exec] does, returning a session that has already
+// exited, with the output of the command as its standard output and an empty standard error.
+// Anything written to the standard input of the session is discarded.
+func (c *FakeContainer) ExecSession(ctx context.Context, cmd []string, options ...tcexec.ProcessOption) (*ExecSession, error) {
+	exitCode, r, err := c.Exec(ctx, cmd, options...)
+	if err != nil {
+		return nil, err
+	}
+
+	if r == nil {
+		r = bytes.NewReader(nil)
+	}
+
+	session := &ExecSession{
+		Stdin:    nopWriteCloser{Writer: io.Discard},
+		Stdout:   r,
+		Stderr:   bytes.NewReader(nil),
+		done:     make(chan struct{}),
+		exitCode: exitCode,
+	}
+	close(session.done)
+
+	return session, nil
+}
+
+// nopWriteCloser is an io.WriteCloser with a no-op Close method.
+type nopWriteCloser struct {
+	io.Writer
+}
+
+// Close does nothing.
+func (nopWriteCloser) Close() error {
+	return nil
+}
+
+// ContainerIP gets the IP address of the primary network within the container.
+func (c *FakeContainer) ContainerIP(ctx context.Context) (string, error) {
+	inspect, err := c.Inspect(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	ip := inspect.NetworkSettings.IPAddress
+	if ip == "" {
+		// use IP from "Networks" if only single network defined
+		networks := inspect.NetworkSettings.Networks
+		if len(networks) == 1 {
+			for _, v := range networks {
+				ip = v.IPAddress
+			}
+		}
+	}
+
+	return ip, nil
+}
+
+// ContainerIPs gets the IP addresses of all the networks within the container.
+func (c *FakeContainer) ContainerIPs(ctx context.Context) ([]string, error) {
+	inspect, err := c.Inspect(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	ips := make([]string, 0, len(inspect.NetworkSettings.Networks))
+	for _, nw := range inspect.NetworkSettings.Networks {
+		ips = append(ips, nw.IPAddress)
+	}
+
+	return ips, nil
+}
+
+// CopyToContainer stores fileContent as a file in the container.
+func (c *FakeContainer) CopyToContainer(_ context.Context, fileContent []byte, containerFilePath string, fileMode int64) error {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	if c.removed {
+		return c.notFound()
+	}
+
+	c.files[containerFilePath] = fakeFile{content: append([]byte{}, fileContent...), mode: fileMode}
+
+	return nil
+}
+
+// CopyDirToContainer stores the files of a host directory in the container, under the
+// parent of containerParentPath, as the Docker container does.
+func (c *FakeContainer) CopyDirToContainer(ctx context.Context, hostDirPath string, containerParentPath string, fileMode int64) error {
+	dir, err := isDir(hostDirPath)
+	if err != nil {
+		return err
+	}
+
+	if !dir {
+		// it's not a dir: let the consumer to handle an error
+		return fmt.Errorf("path %s is not a directory", hostDirPath)
+	}
+
+	parent := filepath.Dir(containerParentPath)
+	baseDir := filepath.Base(hostDirPath)
+
+	return filepath.Walk(hostDirPath, func(file string, fi os.FileInfo, errFn error) error {
+		if errFn != nil {
+			return fmt.Errorf("error traversing the file system: %w", errFn)
+		}
+
+		if !fi.Mode().IsRegular() {
+			return nil
+		}
+
+		rel, err := filepath.Rel(hostDirPath, file)
+		if err != nil {
+			return err
+		}
+
+		content, err := os.ReadFile(file)
+		if err != nil {
+			return err
+		}
+
+		containerFilePath := filepath.ToSlash(filepath.Join(parent, baseDir, rel))
+
+		return c.CopyToContainer(ctx, content, containerFilePath, fileMode)
+	})
+}
+
+// CopyFileToContainer stores the content of a host file in the container.
+func (c *FakeContainer) CopyFileToContainer(ctx context.Context, hostFilePath string, containerFilePath string, fileMode int64) error {
+	dir, err := isDir(hostFilePath)
+	if err != nil {
+		return err
+	}
+
+	if dir {
+		return c.CopyDirToContainer(ctx, hostFilePath, containerFilePath, fileMode)
+	}
+
+	content, err := os.ReadFile(hostFilePath)
+	if err != nil {
+		return err
+	}
+
+	return c.CopyToContainer(ctx, content, containerFilePath, fileMode)
+}
+
+// CopyFileFromContainer returns the content of a file copied to the container,
+// or the response of CopyFileFromContainerFunc, if set.
+func (c *FakeContainer) CopyFileFromContainer(ctx context.Context, filePath string) (io.ReadCloser, error) {
+	if c.CopyFileFromContainerFunc != nil {
+		return c.CopyFileFromContainerFunc(ctx, filePath)
+	}
+
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	if c.removed {
+		return nil, c.notFound()
+	}
+
+	f, ok := c.files[filePath]
+	if !ok {
+		return nil, errdefs.NotFound(fmt.Errorf("Could not find the file %s in container %s", filePath, c.id))
+	}
+
+	return io.NopCloser(bytes.NewReader(f.content)), nil
+}
+
+// FS returns an in-memory filesystem with the files stored in the container
+// when it's called. Later changes to the files of the container are not reflected.
+func (c *FakeContainer) FS(_ context.Context) ContainerFS {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	fsys := fstest.MapFS{}
+	for p, f := range c.files {
+		fsys[strings.TrimPrefix(path.Clean("/"+p), "/")] = &fstest.MapFile{
+			Data: append([]byte{}, f.content...),
+			Mode: fs.FileMode(f.mode) & fs.ModePerm,
+		}
+	}
+
+	return fsys
+}
+
+// CopyDirFromContainer writes the files stored in the container under containerDirPath
+// to hostDirPath, keeping their relative paths and modes.
+func (c *FakeContainer) CopyDirFromContainer(_ context.Context, containerDirPath string, hostDirPath string) error {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	if c.removed {
+		return c.notFound()
+	}
+
+	dir := path.Clean("/" + containerDirPath)
+
+	found := false
+	for p, f := range c.files {
+		rel, ok := strings.CutPrefix(path.Clean("/"+p), strings.TrimSuffix(dir, "/")+"/")
+		if !ok {
+			continue
+		}
+		found = true
+
+		target := filepath.Join(hostDirPath, filepath.FromSlash(rel))
+		if err := os.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+			return err
+		}
+
+		if err := os.WriteFile(target, f.content, fs.FileMode(f.mode)&fs.ModePerm); err != nil {
+			return err
+		}
+	}
+
+	if !found {
+		return errdefs.NotFound(fmt.Errorf("Could not find the directory %s in container %s", containerDirPath, c.id))
+	}
+
+	return nil
+}
+
+// GetLogProductionErrorChannel returns a channel that never receives errors,
+// as the fake container does not read its logs from a stream.
+func (c *FakeContainer) GetLogProductionErrorChannel() <-chan error {
+	return nil
+}
+
+// state returns the state of the container. It must be called with the lock held.
+func (c *FakeContainer) state() *types.ContainerState {
+	return &types.ContainerState{
+		Status:   c.status,
+		Running:  c.running,
+		Paused:   c.paused,
+		ExitCode: c.exitCode,
+	}
+}
+
+// notFound returns the error the Docker daemon returns for a removed container.
+func (c *FakeContainer) notFound() error {
+	return errdefs.NotFound(fmt.Errorf("No such container: %s", c.id))
+}
+
+// createdHook is a hook that will be called after a container is created.
+func (c *FakeContainer) createdHook(ctx context.Context) error {
+	return c.applyLifecycleHooks(ctx, func(lifecycleHooks ContainerLifecycleHooks) []ContainerHook {
+		return lifecycleHooks.PostCreates
+	})
+}
+
+// startingHook is a hook that will be called before a container is started.
+func (c *FakeContainer) startingHook(ctx context.Context) error {
+	return c.applyLifecycleHooks(ctx, func(lifecycleHooks ContainerLifecycleHooks) []ContainerHook {
+		return lifecycleHooks.PreStarts
+	})
+}
+
+// startedHook is a hook that will be called after a container is started.
+func (c *FakeContainer) startedHook(ctx context.Context) error {
+	return c.applyLifecycleHooks(ctx, func(lifecycleHooks ContainerLifecycleHooks) []ContainerHook {
+		return lifecycleHooks.PostStarts
+	})
+}
+
+// readiedHook is a hook that will be called after a container is ready.
+func (c *FakeContainer) readiedHook(ctx context.Context) error {
+	return c.applyLifecycleHooks(ctx, func(lifecycleHooks ContainerLifecycleHooks) []ContainerHook {
+		return lifecycleHooks.PostReadies
+	})
+}
+
+// stoppingHook is a hook that will be called before a container is stopped.
+func (c *FakeContainer) stoppingHook(ctx context.Context) error {
+	return c.applyLifecycleHooks(ctx, func(lifecycleHooks ContainerLifecycleHooks) []ContainerHook {
+		return lifecycleHooks.PreStops
+	})
+}
+
+// stoppedHook is a hook that will be called after a container is stopped.
+func (c *FakeContainer) stoppedHook(ctx context.Context) error {
+	return c.applyLifecycleHooks(ctx, func(lifecycleHooks ContainerLifecycleHooks) []ContainerHook {
+		return lifecycleHooks.PostStops
+	})
+}
+
+// pausingHook is a hook that will be called before a container is paused.
+func (c *FakeContainer) pausingHook(ctx context.Context) error {
+	return c.applyLifecycleHooks(ctx, func(lifecycleHooks ContainerLifecycleHooks) []ContainerHook {
+		return lifecycleHooks.PrePauses
+	})
+}
+
+// pausedHook is a hook that will be called after a container is paused.
+func (c *FakeContainer) pausedHook(ctx context.Context) error {
+	return c.applyLifecycleHooks(ctx, func(lifecycleHooks ContainerLifecycleHooks) []ContainerHook {
+		return lifecycleHooks.PostPauses
+	})
+}
+
+// unpausingHook is a hook that will be called before a container is unpaused.
+func (c *FakeContainer) unpausingHook(ctx context.Context) error {
+	return c.applyLifecycleHooks(ctx, func(lifecycleHooks ContainerLifecycleHooks) []ContainerHook {
+		return lifecycleHooks.PreUnpauses
+	})
+}
+
+// unpausedHook is a hook that will be called after a container is unpaused.
+func (c *FakeContainer) unpausedHook(ctx context.Context) error {
+	return c.applyLifecycleHooks(ctx, func(lifecycleHooks ContainerLifecycleHooks) []ContainerHook {
+		return lifecycleHooks.PostUnpauses
+	})
+}
+
+// killingHook is a hook that will be called before a container is killed.
+func (c *FakeContainer) killingHook(ctx context.Context) error {
+	return c.applyLifecycleHooks(ctx, func(lifecycleHooks ContainerLifecycleHooks) []ContainerHook {
+		return lifecycleHooks.PreKills
+	})
+}
+
+// killedHook is a hook that will be called after a container is killed.
+func (c *FakeContainer) killedHook(ctx context.Context) error {
+	return c.applyLifecycleHooks(ctx, func(lifecycleHooks ContainerLifecycleHooks) []ContainerHook {
+		return lifecycleHooks.PostKills
+	})
+}
+
+// terminatingHook is a hook that will be called before a container is terminated.
+func (c *FakeContainer) terminatingHook(ctx context.Context) error {
+	return c.applyLifecycleHooks(ctx, func(lifecycleHooks ContainerLifecycleHooks) []ContainerHook {
+		return lifecycleHooks.PreTerminates
+	})
+}
+
+// terminatedHook is a hook that will be called after a container is terminated.
+func (c *FakeContainer) terminatedHook(ctx context.Context) error {
+	return c.applyLifecycleHooks(ctx, func(lifecycleHooks ContainerLifecycleHooks) []ContainerHook {
+		return lifecycleHooks.PostTerminates
+	})
+}
+
+// applyExitHooks applies the exit hooks selected by the hooks function.
+func (c *FakeContainer) applyExitHooks(ctx context.Context, exit ContainerExit, hooks func(lifecycleHooks ContainerLifecycleHooks) []ContainerExitHook) error {
+	errs := make([]error, len(c.lifecycleHooks))
+	for i, lifecycleHooks := range c.lifecycleHooks {
+		errs[i] = containerExitHookFn(ctx, hooks(lifecycleHooks))(c, exit)
+	}
+
+	return errors.Join(errs...)
+}
+
+// applyLifecycleHooks applies all lifecycle hooks selected by the hooks function.
+func (c *FakeContainer) applyLifecycleHooks(ctx context.Context, hooks func(lifecycleHooks ContainerLifecycleHooks) []ContainerHook) error {
+	errs := make([]error, len(c.lifecycleHooks))
+	for i, lifecycleHooks := range c.lifecycleHooks {
+		errs[i] = containerHookFn(ctx, hooks(lifecycleHooks))(c)
+	}
+
+	return errors.Join(errs...)
+}
+
+// fakePreCreateHook is the fake counterpart of defaultPreCreateHook, applying the modifiers
+// of the request to the configuration of the container. As there is no image to inspect,
+// only the exposed ports of the request are bound.
+var fakePreCreateHook = func(dockerInput *container.Config, hostConfig *container.HostConfig, networkingConfig *network.NetworkingConfig) ContainerLifecycleHooks {
+	return ContainerLifecycleHooks{
+		PreCreates: []ContainerRequestHook{
+			func(ctx context.Context, req ContainerRequest) error {
+				hostConfig.Mounts = mapToDockerMounts(req.Mounts)
+
+				endpointSettings := map[string]*network.EndpointSettings{}
+				if len(req.Networks) > 0 {
+					attachContainerTo := req.Networks[0]
+
+					aliases := []string{}
+					if _, ok := req.NetworkAliases[attachContainerTo]; ok {
+						aliases = req.NetworkAliases[attachContainerTo]
+					}
+					endpointSettings[attachContainerTo] = &network.EndpointSettings{
+						Aliases: aliases,
+					}
+				}
+
+				if req.ConfigModifier != nil {
+					req.ConfigModifier(dockerInput)
+				}
+
+				if req.HostConfigModifier == nil {
+					req.HostConfigModifier = defaultHostConfigModifier(req)
+				}
+				req.HostConfigModifier(hostConfig)
+
+				if req.EndpointSettingsModifier != nil {
+					req.EndpointSettingsModifier(endpointSettings)
+				} else if req.EnpointSettingsModifier != nil {
+					req.EnpointSettingsModifier(endpointSettings)
+				}
+
+				networkingConfig.EndpointsConfig = endpointSettings
+
+				exposedPortSet, exposedPortMap, err := nat.ParsePortSpecs(req.ExposedPorts)
+				if err != nil {
+					return err
+				}
+
+				dockerInput.ExposedPorts = exposedPortSet
+				hostConfig.PortBindings = mergePortBindings(hostConfig.PortBindings, exposedPortMap, req.ExposedPorts)
+
+				return nil
+			},
+		},
+	}
+}
+
+// fakeLogConsumersHook is the fake counterpart of defaultLogConsumersHook, sending the logs
+// written to the container to the log consumers while the container is running.
+var fakeLogConsumersHook = func(cfg *LogConsumerConfig) ContainerLifecycleHooks {
+	return ContainerLifecycleHooks{
+		PostStarts: []ContainerHook{
+			func(ctx context.Context, c Container) error {
+				if cfg == nil || len(cfg.Consumers) == 0 {
+					return nil
+				}
+
+				fakeContainer := c.(*FakeContainer)
+				fakeContainer.mtx.Lock()
+				fakeContainer.consumers = append(fakeContainer.consumers[:0], cfg.Consumers...)
+				fakeContainer.mtx.Unlock()
+
+				return fakeContainer.StartLogProducer(ctx, cfg.Opts...)
+			},
+		},
+		PostStops: []ContainerHook{
+			func(ctx context.Context, c Container) error {
+				if cfg == nil || len(cfg.Consumers) == 0 {
+					return nil
+				}
+
+				return c.(*FakeContainer).StopLogProducer()
+			},
+		},
+		PostKills: []ContainerHook{
+			func(ctx context.Context, c Container) error {
+				if cfg == nil || len(cfg.Consumers) == 0 || c.IsRunning() {
+					return nil
+				}
+
+				return c.(*FakeContainer).StopLogProducer()
+			},
+		},
+	}
+}
+
+// fakeStatsConsumersHook is the fake counterpart of defaultStatsConsumersHook, sending the stats
+// written to the container to the stats consumers while the container is running.
+var fakeStatsConsumersHook = func(cfg *StatsConsumerConfig) ContainerLifecycleHooks {
+	// stopStats stops sending the stats to the consumers.
+	stopStats := func(c Container) {
+		fakeContainer := c.(*FakeContainer)
+		fakeContainer.mtx.Lock()
+		fakeContainer.stats = nil
+		fakeContainer.mtx.Unlock()
+	}
+
+	return ContainerLifecycleHooks{
+		PostStarts: []ContainerHook{
+			func(ctx context.Context, c Container) error {
+				if cfg == nil || len(cfg.Consumers) == 0 {
+					return nil
+				}
+
+				fakeContainer := c.(*FakeContainer)
+				fakeContainer.mtx.Lock()
+				fakeContainer.stats = append(fakeContainer.stats[:0], cfg.Consumers...)
+				fakeContainer.mtx.Unlock()
+
+				return nil
+			},
+		},
+		PostStops: []ContainerHook{
+			func(ctx context.Context, c Container) error {
+				stopStats(c)
+				return nil
+			},
+		},
+		PostKills: []ContainerHook{
+			func(ctx context.Context, c Container) error {
+				if !c.IsRunning() {
+					stopStats(c)
+				}
+				return nil
+			},
+		},
+		PreTerminates: []ContainerHook{
+			func(ctx context.Context, c Container) error {
+				if cfg == nil || len(cfg.Consumers) == 0 {
+					return nil
+				}
+
+				stopStats(c)
+
+				for _, r := range statsReporters(cfg.Consumers) {
+					r.Report(c.GetContainerID())
+				}
+
+				return nil
+			},
+		},
+	}
+}
+
+// fakeReadinessHook is the fake counterpart of defaultReadinessHook, checking that the
+// exposed ports are mapped and running the wait strategy of the container.
+var fakeReadinessHook = func() ContainerLifecycleHooks {
+	return ContainerLifecycleHooks{
+		PostStarts: []ContainerHook{
+			func(ctx context.Context, c Container) error {
+				fakeContainer := c.(*FakeContainer)
+
+				inspect, err := fakeContainer.Inspect(ctx)
+				if err != nil {
+					return err
+				}
+
+				if err := checkPortsMapped(inspect.NetworkSettings.Ports, fakeContainer.exposedPorts); err != nil {
+					return fmt.Errorf("all exposed ports, %s, were not mapped: %w", fakeContainer.exposedPorts, err)
+				}
+
+				if fakeContainer.WaitingFor != nil {
+					fakeContainer.logger.Printf(
+						"⏳ Waiting for container id %s image: %s. Waiting for: %+v",
+						fakeContainer.id[:12], fakeContainer.Image, fakeContainer.WaitingFor,
+					)
+					if err := fakeContainer.WaitingFor.WaitUntilReady(ctx, c); err != nil {
+						return fmt.Errorf("wait until ready: %w", err)
+					}
+				}
+
+				return nil
+			},
+		},
+	}
+}
+
+// fakeEnv returns the environment of the request in the KEY=VALUE form, sorted by key.
+func fakeEnv(env map[string]string) []string {
+	vars := make([]string, 0, len(env))
+	for k, v := range env {
+		vars = append(vars, k+"="+v)
+	}
+	sort.Strings(vars)
+
+	return vars
+}
@@ -0,0 +1,527 @@
+package testcontainers
+
+import (
+	"archive/tar"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/network"
+	"github.com/docker/docker/errdefs"
+	"github.com/docker/go-connections/nat"
+
+	"github.com/testcontainers/testcontainers-go/internal/core"
+)
+
+// Implement interfaces
+var (
+	_ GenericProvider     = (*FakeProvider)(nil)
+	_ ContainerCustomizer = (*FakeProvider)(nil)
+	_ Network             = (*fakeNetwork)(nil)
+)
+
+// fakeProviderTypeBase is the first ProviderType value assigned to fake providers.
+// It's far away from the built-in provider types, so they never collide.
+const fakeProviderTypeBase ProviderType = 1 << 16
+
+var (
+	fakeProvidersMx      sync.Mutex
+	fakeProviders        = map[ProviderType]*FakeProvider{}
+	lastFakeProviderType = fakeProviderTypeBase
+)
+
+// lookupFakeProvider returns the fake provider registered for the given provider type, if any.
+func lookupFakeProvider(pt ProviderType) (*FakeProvider, bool) {
+	fakeProvidersMx.Lock()
+	defer fakeProvidersMx.Unlock()
+
+	p, ok := fakeProviders[pt]
+	return p, ok
+}
+
+// FakeProvider is an in-memory [GenericProvider] that does not need a Docker daemon.
+// It records the container requests it receives, runs the lifecycle hooks of the
+// containers in the same order as the Docker provider does, and returns
+// [FakeContainer] instances whose responses can be scripted.
+//
+// It's meant to unit-test code built on top of Testcontainers for Go, such as
+// customizers, wait strategies or the options of a module, without starting any
+// container. Select it by setting the ProviderType of the GenericContainerRequest
+// to the value returned by [FakeProvider.ProviderType], or by passing the provider
+// itself as a [ContainerCustomizer] to a module's Run function.
+type FakeProvider struct {
+	// OnCreate, if set, is called for every new container before any of its
+	// PostCreates hooks is executed. Use it to script the container responses.
+	OnCreate func(req ContainerRequest, c *FakeContainer)
+
+	providerType ProviderType
+	logger       Logging
+
+	mtx           sync.Mutex
+	requests      []ContainerRequest
+	containers    []*FakeContainer
+	networks      map[string]network.Inspect
+	images        []string
+	nextHostPort  int
+	nextIPAddress int
+
+	subscribersMx sync.Mutex
+	subscribers   map[chan ContainerEvent]struct{}
+}
+
+// NewFakeProvider creates a new fake provider, registering it so it can be selected
+// by its provider type. The provider is unregistered when the test finishes.
+func NewFakeProvider(tb testing.TB) *FakeProvider {
+	tb.Helper()
+
+	fakeProvidersMx.Lock()
+	lastFakeProviderType++
+	p := &FakeProvider{
+		providerType:  lastFakeProviderType,
+		logger:        TestLogger(tb),
+		networks:      map[string]network.Inspect{},
+		subscribers:   map[chan ContainerEvent]struct{}{},
+		nextHostPort:  32768,
+		nextIPAddress: 2,
+	}
+	fakeProviders[p.providerType] = p
+	fakeProvidersMx.Unlock()
+
+	tb.Cleanup(func() {
+		fakeProvidersMx.Lock()
+		defer fakeProvidersMx.Unlock()
+
+		delete(fakeProviders, p.providerType)
+	})
+
+	return p
+}
+
+// ProviderType returns the provider type that selects this fake provider.
+func (p *FakeProvider) ProviderType() ProviderType {
+	return p.providerType
+}
+
+// Customize implements ContainerCustomizer, selecting the fake provider for the request.
+func (p *FakeProvider) Customize(req *GenericContainerRequest) error {
+	req.ProviderType = p.providerType
+
+	return nil
+}
+
+// Requests returns the container requests received by the provider, in order.
+func (p *FakeProvider) Requests() []ContainerRequest {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+
+	return append([]ContainerRequest{}, p.requests...)
+}
+
+// Containers returns the containers created by the provider, in order.
+func (p *FakeProvider) Containers() []*FakeContainer {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+
+	return append([]*FakeContainer{}, p.containers...)
+}
+
+// Close implements ContainerProvider. It's a no-op for the fake provider.
+func (p *FakeProvider) Close() error {
+	return nil
+}
+
+// CreateContainer records the request and returns a new fake container, without starting it.
+// The PreCreates and PostCreates hooks are executed as the Docker provider does.
+func (p *FakeProvider) CreateContainer(ctx context.Context, req ContainerRequest) (Container, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	imageName := req.Image
+	if req.ShouldBuildImage() {
+		imageName = fmt.Sprintf("%s:%s", req.GetRepo(), req.GetTag())
+	}
+
+	for _, is := range req.ImageSubstitutors {
+		modifiedTag, err := is.Substitute(imageName)
+		if err != nil {
+			return nil, fmt.Errorf("failed to substitute image %s with %s: %w", imageName, is.Description(), err)
+		}
+
+		imageName = modifiedTag
+	}
+
+	if req.Labels == nil {
+		req.Labels = make(map[string]string)
+	}
+
+	for k, v := range core.DefaultLabels(core.SessionID()) {
+		req.Labels[k] = v
+	}
+
+	dockerInput := &container.Config{
+		Entrypoint: req.Entrypoint,
+		Image:      imageName,
+		Env:        fakeEnv(req.Env),
+		Labels:     req.Labels,
+		Cmd:        req.Cmd,
+		Hostname:   req.Hostname,
+		User:       req.User,
+		WorkingDir: req.WorkingDir,
+	}
+
+	hostConfig := &container.HostConfig{
+		Privileged: req.Privileged,
+		ShmSize:    req.ShmSize,
+		Tmpfs:      req.Tmpfs,
+	}
+
+	networkingConfig := &network.NetworkingConfig{}
+
+	defaultHooks := []ContainerLifecycleHooks{
+		DefaultLoggingHook(p.logger),
+		fakePreCreateHook(dockerInput, hostConfig, networkingConfig),
+		defaultCopyFileToContainerHook(req.Files),
+		fakeLogConsumersHook(req.LogConsumerCfg),
+		fakeStatsConsumersHook(req.StatsConsumerCfg),
+		fakeReadinessHook(),
+	}
+
+	req.LifecycleHooks = []ContainerLifecycleHooks{combineContainerHooks(defaultHooks, req.LifecycleHooks)}
+
+	p.mtx.Lock()
+	p.requests = append(p.requests, req)
+	p.mtx.Unlock()
+
+	if err := req.creatingHook(ctx); err != nil {
+		return nil, err
+	}
+
+	c := p.newContainer(req, dockerInput, hostConfig, networkingConfig)
+
+	if p.OnCreate != nil {
+		p.OnCreate(req, c)
+	}
+
+	if err := c.createdHook(ctx); err != nil {
+		return c, err
+	}
+
+	return c, nil
+}
+
+// ReuseOrCreateContainer returns the fake container with the same name as the request, or named after
+// the hash of the request if it has no name, if it exists and it's not terminated, otherwise it creates
+// a new one. A container created from a different request is not reused, as the Docker provider does.
+func (p *FakeProvider) ReuseOrCreateContainer(ctx context.Context, req ContainerRequest) (Container, error) {
+	hash, err := req.prepareReuse()
+	if err != nil {
+		return nil, err
+	}
+
+	p.mtx.Lock()
+	for _, c := range p.containers {
+		if c.request.Name == req.Name && !c.isTerminated() {
+			p.mtx.Unlock()
+
+			if err := checkReuseHash(req.Name, c.request.Labels, hash); err != nil {
+				return nil, err
+			}
+
+			return c, nil
+		}
+	}
+	p.mtx.Unlock()
+
+	return p.CreateContainer(ctx, req)
+}
+
+// RunContainer creates a fake container and starts it.
+func (p *FakeProvider) RunContainer(ctx context.Context, req ContainerRequest) (Container, error) {
+	c, err := p.CreateContainer(ctx, req)
+	if err != nil {
+		return c, err
+	}
+
+	if err := c.Start(ctx); err != nil {
+		return c, fmt.Errorf("%w: could not start container", err)
+	}
+
+	return c, nil
+}
+
+// Health implements ContainerProvider. The fake provider is always healthy.
+func (p *FakeProvider) Health(_ context.Context) error {
+	return nil
+}
+
+// Config implements ContainerProvider, returning the configuration read from
+// the properties file and the environment.
+func (p *FakeProvider) Config() TestcontainersConfig {
+	return ReadConfig()
+}
+
+// Events subscribes to the events of the fake containers, which are named after the Docker events.
+// The events are dropped if the channel is not read fast enough, and the channel is closed
+// when the context is done. The error channel never receives errors.
+func (p *FakeProvider) Events(ctx context.Context) (<-chan ContainerEvent, <-chan error) {
+	ch := make(chan ContainerEvent, 100)
+
+	p.subscribersMx.Lock()
+	p.subscribers[ch] = struct{}{}
+	p.subscribersMx.Unlock()
+
+	go func() {
+		<-ctx.Done()
+
+		p.subscribersMx.Lock()
+		defer p.subscribersMx.Unlock()
+
+		delete(p.subscribers, ch)
+		close(ch)
+	}()
+
+	return ch, make(chan error)
+}
+
+// publish sends the event to the subscribers of the provider events, without blocking.
+func (p *FakeProvider) publish(event ContainerEvent) {
+	p.subscribersMx.Lock()
+	defer p.subscribersMx.Unlock()
+
+	for ch := range p.subscribers {
+		select {
+		case ch <- event:
+		default:
+		}
+	}
+}
+
+// CreateNetwork records a fake network, returning it.
+func (p *FakeProvider) CreateNetwork(_ context.Context, req NetworkRequest) (Network, error) {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+
+	if _, ok := p.networks[req.Name]; ok {
+		return nil, errdefs.Conflict(fmt.Errorf("network with name %s already exists", req.Name))
+	}
+
+	p.networks[req.Name] = network.Inspect{
+		Name:       req.Name,
+		ID:         randomFakeID(),
+		Driver:     req.Driver,
+		Internal:   req.Internal,
+		Attachable: req.Attachable,
+		Labels:     req.Labels,
+	}
+
+	return &fakeNetwork{name: req.Name, provider: p}, nil
+}
+
+// GetNetwork returns a fake network created with CreateNetwork.
+func (p *FakeProvider) GetNetwork(_ context.Context, req NetworkRequest) (network.Inspect, error) {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+
+	nw, ok := p.networks[req.Name]
+	if !ok {
+		return network.Inspect{}, errdefs.NotFound(fmt.Errorf("network %s not found", req.Name))
+	}
+
+	return nw, nil
+}
+
+// ListImages returns the images used by the created containers and the pulled ones.
+func (p *FakeProvider) ListImages(_ context.Context) ([]ImageInfo, error) {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+
+	images := make([]ImageInfo, 0, len(p.images))
+	for _, img := range p.images {
+		images = append(images, ImageInfo{ID: img, Name: img})
+	}
+
+	return images, nil
+}
+
+// SaveImages writes an empty tar archive to the output, failing if any of
+// the images is not known by the provider.
+func (p *FakeProvider) SaveImages(ctx context.Context, output string, images ...string) error {
+	known, err := p.ListImages(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, img := range images {
+		found := false
+		for _, k := range known {
+			if k.Name == img {
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			return errdefs.NotFound(fmt.Errorf("image %s not found", img))
+		}
+	}
+
+	f, err := os.Create(output)
+	if err != nil {
+		return fmt.Errorf("opening output file %w", err)
+	}
+	defer f.Close()
+
+	return tar.NewWriter(f).Close()
+}
+
+// LoadImages records the images listed in the archive, as created by SaveImages
+// or docker save, as pulled.
+func (p *FakeProvider) LoadImages(_ context.Context, input string) error {
+	tags, err := imageArchiveTags(input)
+	if err != nil {
+		return err
+	}
+
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+
+	for _, tag := range tags {
+		p.addImage(tag)
+	}
+
+	return nil
+}
+
+// PullImage records the image as pulled.
+func (p *FakeProvider) PullImage(_ context.Context, img string) error {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+
+	p.addImage(img)
+
+	return nil
+}
+
+// InspectImage returns the details of a known image, which only include its name
+// as ID and tag.
+func (p *FakeProvider) InspectImage(_ context.Context, img string) (ImageDetails, error) {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+
+	if !slices.Contains(p.images, img) {
+		return ImageDetails{}, errdefs.NotFound(fmt.Errorf("image %s not found", img))
+	}
+
+	return ImageDetails{ID: img, RepoTags: []string{img}}, nil
+}
+
+// TagImage records the target as a known image, failing if the source is not known.
+func (p *FakeProvider) TagImage(_ context.Context, source string, target string) error {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+
+	if !slices.Contains(p.images, source) {
+		return errdefs.NotFound(fmt.Errorf("image %s not found", source))
+	}
+
+	p.addImage(target)
+
+	return nil
+}
+
+// RemoveImage forgets a known image. The options are ignored, as the fake images are never used.
+func (p *FakeProvider) RemoveImage(_ context.Context, img string, _ ...RemoveImageOption) error {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+
+	i := slices.Index(p.images, img)
+	if i < 0 {
+		return errdefs.NotFound(fmt.Errorf("image %s not found", img))
+	}
+
+	p.images = slices.Delete(p.images, i, i+1)
+
+	return nil
+}
+
+// PruneImages removes nothing, as the fake provider does not build images.
+func (p *FakeProvider) PruneImages(_ context.Context, _ time.Duration) ([]string, error) {
+	return nil, nil
+}
+
+// addImage adds the image to the list of known images, if not present.
+// It must be called with the lock held.
+func (p *FakeProvider) addImage(img string) {
+	for _, i := range p.images {
+		if i == img {
+			return
+		}
+	}
+
+	p.images = append(p.images, img)
+}
+
+// newContainer builds a fake container for the request, binding its exposed ports
+// to host ports and assigning it a fake IP address.
+func (p *FakeProvider) newContainer(req ContainerRequest, cfg *container.Config, hostConfig *container.HostConfig, networkingConfig *network.NetworkingConfig) *FakeContainer {
+	c := newFakeContainer(req, cfg, hostConfig, networkingConfig, p)
+
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+
+	for port, bindings := range c.ports {
+		if len(bindings) > 0 && bindings[0].HostPort != "" {
+			continue
+		}
+
+		c.ports[port] = []nat.PortBinding{fakePortBinding(p.nextHostPort)}
+		p.nextHostPort++
+	}
+
+	c.ipAddress = fmt.Sprintf("172.17.0.%d", p.nextIPAddress)
+	p.nextIPAddress++
+
+	p.addImage(cfg.Image)
+	p.containers = append(p.containers, c)
+
+	return c
+}
+
+// fakeNetwork is the network created by the fake provider.
+type fakeNetwork struct {
+	name     string
+	provider *FakeProvider
+}
+
+// Remove removes the fake network from its provider.
+func (n *fakeNetwork) Remove(_ context.Context) error {
+	n.provider.mtx.Lock()
+	defer n.provider.mtx.Unlock()
+
+	if _, ok := n.provider.networks[n.name]; !ok {
+		return errdefs.NotFound(errors.New("network not found"))
+	}
+
+	delete(n.provider.networks, n.name)
+
+	return nil
+}
+
+// randomFakeID returns a random identifier with the same format as Docker's.
+func randomFakeID() string {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		panic(fmt.Sprintf("reading random bytes: %v", err))
+	}
+
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,609 @@
+package testcontainers
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/errdefs"
+	"github.com/docker/go-connections/nat"
+	"github.com/stretchr/testify/require"
+
+	tcexec "github.com/testcontainers/testcontainers-go/exec"
+	"github.com/testcontainers/testcontainers-go/internal/config"
+	"github.com/testcontainers/testcontainers-go/internal/core"
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+func TestFakeProvider(t *testing.T) {
+	t.Run("records-request-and-applies-modifiers", func(t *testing.T) {
+		p := NewFakeProvider(t)
+		ctx := context.Background()
+
+		ctr, err := GenericContainer(ctx, GenericContainerRequest{
+			ContainerRequest: ContainerRequest{
+				Image:        "nginx:alpine",
+				ExposedPorts: []string{"80/tcp", "8080:81/tcp"},
+				Env:          map[string]string{"FOO": "bar"},
+				ConfigModifier: func(cfg *container.Config) {
+					cfg.Hostname = "fake-host"
+				},
+				HostConfigModifier: func(hc *container.HostConfig) {
+					hc.Privileged = true
+				},
+				Files: []ContainerFile{
+					{Reader: strings.NewReader("hello"), ContainerFilePath: "/tmp/hello.txt", FileMode: 0o644},
+				},
+			},
+			ProviderType: p.ProviderType(),
+			Started:      true,
+		})
+		CleanupContainer(t, ctr)
+		require.NoError(t, err)
+		require.True(t, ctr.IsRunning())
+
+		require.Len(t, p.Requests(), 1)
+		require.Equal(t, "nginx:alpine", p.Requests()[0].Image)
+
+		inspect, err := ctr.Inspect(ctx)
+		require.NoError(t, err)
+		require.Equal(t, "fake-host", inspect.Config.Hostname)
+		require.True(t, inspect.HostConfig.Privileged)
+		require.Contains(t, inspect.Config.Env, "FOO=bar")
+		require.Equal(t, core.SessionID(), inspect.Config.Labels[core.LabelSessionID])
+		require.True(t, inspect.State.Running)
+
+		port, err := ctr.MappedPort(ctx, "80/tcp")
+		require.NoError(t, err)
+		require.Equal(t, "32768", port.Port())
+
+		port, err = ctr.MappedPort(ctx, "81/tcp")
+		require.NoError(t, err)
+		require.Equal(t, "8080", port.Port())
+
+		endpoint, err := ctr.PortEndpoint(ctx, "80/tcp", "http")
+		require.NoError(t, err)
+		require.Equal(t, "http://localhost:32768", endpoint)
+
+		r, err := ctr.CopyFileFromContainer(ctx, "/tmp/hello.txt")
+		require.NoError(t, err)
+		content, err := io.ReadAll(r)
+		require.NoError(t, err)
+		require.Equal(t, "hello", string(content))
+
+		require.NoError(t, ctr.Terminate(ctx))
+
+		_, err = ctr.Inspect(ctx)
+		require.True(t, errdefs.IsNotFound(err))
+
+		fakeCtr := p.Containers()[0]
+		require.Equal(t, []string{"create", "start", "stop", "destroy"}, fakeCtr.Lifecycle())
+	})
+
+	t.Run("runs-hooks-in-order", func(t *testing.T) {
+		p := NewFakeProvider(t)
+		ctx := context.Background()
+
+		var calls []string
+		hook := func(name string) ContainerHook {
+			return func(_ context.Context, _ Container) error {
+				calls = append(calls, name)
+				return nil
+			}
+		}
+
+		req := GenericContainerRequest{
+			ContainerRequest: ContainerRequest{
+				Image: "nginx:alpine",
+				LifecycleHooks: []ContainerLifecycleHooks{
+					{
+						PreCreates: []ContainerRequestHook{
+							func(_ context.Context, _ ContainerRequest) error {
+								calls = append(calls, "pre-create")
+								return nil
+							},
+						},
+						PostCreates:    []ContainerHook{hook("post-create")},
+						PreStarts:      []ContainerHook{hook("pre-start")},
+						PostStarts:     []ContainerHook{hook("post-start")},
+						PostReadies:    []ContainerHook{hook("post-ready")},
+						PreStops:       []ContainerHook{hook("pre-stop")},
+						PostStops:      []ContainerHook{hook("post-stop")},
+						PrePauses:      []ContainerHook{hook("pre-pause")},
+						PostPauses:     []ContainerHook{hook("post-pause")},
+						PreUnpauses:    []ContainerHook{hook("pre-unpause")},
+						PostUnpauses:   []ContainerHook{hook("post-unpause")},
+						PreKills:       []ContainerHook{hook("pre-kill")},
+						PostKills:      []ContainerHook{hook("post-kill")},
+						PreTerminates:  []ContainerHook{hook("pre-terminate")},
+						PostTerminates: []ContainerHook{hook("post-terminate")},
+					},
+				},
+			},
+			Started: true,
+		}
+		require.NoError(t, p.Customize(&req))
+
+		ctr, err := GenericContainer(ctx, req)
+		CleanupContainer(t, ctr)
+		require.NoError(t, err)
+		require.NoError(t, ctr.Pause(ctx))
+		require.True(t, ctr.IsRunning())
+		require.NoError(t, ctr.Unpause(ctx))
+		require.NoError(t, ctr.Kill(ctx, "SIGKILL"))
+		require.False(t, ctr.IsRunning())
+		require.NoError(t, ctr.Terminate(ctx))
+
+		require.Equal(t, []string{
+			"pre-create", "post-create", "pre-start", "post-start", "post-ready",
+			"pre-pause", "post-pause", "pre-unpause", "post-unpause", "pre-kill", "post-kill",
+			"pre-stop", "post-stop", "pre-terminate", "post-terminate",
+		}, calls)
+
+		require.Equal(t, []string{"create", "start", "pause", "unpause", "kill", "die", "destroy"}, p.Containers()[0].Lifecycle())
+	})
+
+	t.Run("scripted-responses", func(t *testing.T) {
+		p := NewFakeProvider(t)
+		p.OnCreate = func(_ ContainerRequest, c *FakeContainer) {
+			c.WriteLogs(StdoutLog, []byte("database system is ready to accept connections\n"))
+			c.ExecFunc = func(_ context.Context, cmd []string, _ ...tcexec.ProcessOption) (int, io.Reader, error) {
+				return 1, strings.NewReader("failed: " + strings.Join(cmd, " ")), nil
+			}
+			c.MappedPortFunc = func(_ context.Context, port nat.Port) (nat.Port, error) {
+				return nat.NewPort(port.Proto(), "15432")
+			}
+		}
+		ctx := context.Background()
+
+		req := GenericContainerRequest{
+			ContainerRequest: ContainerRequest{
+				Image:        "postgres:16-alpine",
+				ExposedPorts: []string{"5432/tcp"},
+				WaitingFor:   wait.ForLog("database system is ready to accept connections").WithStartupTimeout(time.Second),
+			},
+			Started: true,
+		}
+		require.NoError(t, p.Customize(&req))
+
+		ctr, err := GenericContainer(ctx, req)
+		CleanupContainer(t, ctr)
+		require.NoError(t, err)
+
+		port, err := ctr.MappedPort(ctx, "5432/tcp")
+		require.NoError(t, err)
+		require.Equal(t, "15432", port.Port())
+
+		code, r, err := ctr.Exec(ctx, []string{"pg_isready"})
+		require.NoError(t, err)
+		require.Equal(t, 1, code)
+		out, err := io.ReadAll(r)
+		require.NoError(t, err)
+		require.Equal(t, "failed: pg_isready", string(out))
+
+		fakeCtr := p.Containers()[0]
+		require.Equal(t, [][]string{{"pg_isready"}}, fakeCtr.Execs())
+	})
+
+	t.Run("wait-strategy-failure", func(t *testing.T) {
+		p := NewFakeProvider(t)
+		ctx := context.Background()
+
+		req := GenericContainerRequest{
+			ContainerRequest: ContainerRequest{
+				Image:      "nginx:alpine",
+				WaitingFor: wait.ForLog("never").WithStartupTimeout(200 * time.Millisecond).WithPollInterval(10 * time.Millisecond),
+			},
+			Started: true,
+		}
+		require.NoError(t, p.Customize(&req))
+
+		ctr, err := GenericContainer(ctx, req)
+		CleanupContainer(t, ctr)
+		require.ErrorContains(t, err, "wait until ready")
+	})
+
+	t.Run("log-consumers", func(t *testing.T) {
+		p := NewFakeProvider(t)
+		p.OnCreate = func(_ ContainerRequest, c *FakeContainer) {
+			c.WriteLogs(StdoutLog, []byte("first\n"))
+		}
+		ctx := context.Background()
+
+		consumer := &fakeTestLogConsumer{}
+		req := GenericContainerRequest{
+			ContainerRequest: ContainerRequest{
+				Image: "nginx:alpine",
+				LogConsumerCfg: &LogConsumerConfig{
+					Consumers: []LogConsumer{consumer},
+				},
+			},
+			Started: true,
+		}
+		require.NoError(t, p.Customize(&req))
+
+		ctr, err := GenericContainer(ctx, req)
+		CleanupContainer(t, ctr)
+		require.NoError(t, err)
+
+		p.Containers()[0].WriteLogs(StderrLog, []byte("second\n"))
+		require.NoError(t, ctr.Stop(ctx, nil))
+		p.Containers()[0].WriteLogs(StdoutLog, []byte("third\n"))
+
+		require.Equal(t, "first\nsecond\n", consumer.buf.String())
+	})
+
+	t.Run("snapshots", func(t *testing.T) {
+		p := NewFakeProvider(t)
+		ctx := context.Background()
+
+		req := GenericContainerRequest{
+			ContainerRequest: ContainerRequest{
+				Image:        "nginx:alpine",
+				ExposedPorts: []string{"80/tcp"},
+			},
+			Started: true,
+		}
+		require.NoError(t, p.Customize(&req))
+
+		ctr, err := GenericContainer(ctx, req)
+		CleanupContainer(t, ctr)
+		require.NoError(t, err)
+
+		require.NoError(t, ctr.CopyToContainer(ctx, []byte("initial"), "/tmp/state.txt", 0o644))
+		require.NoError(t, ctr.Snapshot(ctx, "initial"))
+		require.NoError(t, ctr.CopyToContainer(ctx, []byte("modified"), "/tmp/state.txt", 0o644))
+
+		port, err := ctr.MappedPort(ctx, "80/tcp")
+		require.NoError(t, err)
+
+		require.NoError(t, ctr.Restore(ctx, "initial"))
+		require.True(t, ctr.IsRunning())
+
+		r, err := ctr.CopyFileFromContainer(ctx, "/tmp/state.txt")
+		require.NoError(t, err)
+		content, err := io.ReadAll(r)
+		require.NoError(t, err)
+		require.Equal(t, "initial", string(content))
+
+		restoredPort, err := ctr.MappedPort(ctx, "80/tcp")
+		require.NoError(t, err)
+		require.Equal(t, port, restoredPort)
+
+		require.EqualError(t, ctr.Restore(ctx, "unknown"), `snapshot "unknown" not found`)
+	})
+
+	t.Run("restart", func(t *testing.T) {
+		p := NewFakeProvider(t)
+		ctx := context.Background()
+
+		req := GenericContainerRequest{
+			ContainerRequest: ContainerRequest{
+				Image:        "nginx:alpine",
+				ExposedPorts: []string{"80/tcp"},
+			},
+			Started: true,
+		}
+		require.NoError(t, p.Customize(&req))
+
+		ctr, err := GenericContainer(ctx, req)
+		CleanupContainer(t, ctr)
+		require.NoError(t, err)
+
+		port, err := ctr.MappedPort(ctx, "80/tcp")
+		require.NoError(t, err)
+
+		require.NoError(t, ctr.Restart(ctx, RestartTimeout(time.Second)))
+		require.True(t, ctr.IsRunning())
+
+		restartedPort, err := ctr.MappedPort(ctx, "80/tcp")
+		require.NoError(t, err)
+		require.Equal(t, port, restartedPort)
+
+		fake := ctr.(*FakeContainer)
+		require.Equal(t, []string{"create", "start", "stop", "start"}, fake.Lifecycle())
+	})
+
+	t.Run("reserved-host-port", func(t *testing.T) {
+		p := NewFakeProvider(t)
+		ctx := context.Background()
+
+		req := GenericContainerRequest{
+			ContainerRequest: ContainerRequest{
+				Image:        "nginx:alpine",
+				ExposedPorts: []string{"80/tcp"},
+				Env:          map[string]string{"PUBLIC_URL": `http://localhost:{{hostPort "80"}}`},
+			},
+			Started: true,
+		}
+		require.NoError(t, p.Customize(&req))
+		require.NoError(t, WithReservedHostPort("80/tcp").Customize(&req))
+
+		ctr, err := GenericContainer(ctx, req)
+		CleanupContainer(t, ctr)
+		require.NoError(t, err)
+
+		hostPort := req.ReservedHostPorts["80/tcp"]
+
+		port, err := ctr.MappedPort(ctx, "80/tcp")
+		require.NoError(t, err)
+		require.Equal(t, hostPort, port.Port())
+
+		inspect, err := ctr.Inspect(ctx)
+		require.NoError(t, err)
+		require.Contains(t, inspect.Config.Env, "PUBLIC_URL=http://localhost:"+hostPort)
+	})
+
+	t.Run("filesystem", func(t *testing.T) {
+		p := NewFakeProvider(t)
+		ctx := context.Background()
+
+		req := GenericContainerRequest{
+			ContainerRequest: ContainerRequest{
+				Image: "nginx:alpine",
+				Files: []ContainerFile{
+					{Reader: strings.NewReader("passed"), ContainerFilePath: "/reports/summary.txt", FileMode: 0o644},
+					{Reader: strings.NewReader("nested"), ContainerFilePath: "/reports/nested/result.txt", FileMode: 0o600},
+				},
+			},
+			Started: true,
+		}
+		require.NoError(t, p.Customize(&req))
+
+		ctr, err := GenericContainer(ctx, req)
+		CleanupContainer(t, ctr)
+		require.NoError(t, err)
+
+		fsys := ctr.FS(ctx)
+		require.NoError(t, fstest.TestFS(fsys, "reports/summary.txt", "reports/nested/result.txt"))
+
+		content, err := fs.ReadFile(fsys, "reports/summary.txt")
+		require.NoError(t, err)
+		require.Equal(t, "passed", string(content))
+
+		hostDir := t.TempDir()
+		require.NoError(t, ctr.CopyDirFromContainer(ctx, "/reports", hostDir))
+
+		content, err = os.ReadFile(filepath.Join(hostDir, "nested", "result.txt"))
+		require.NoError(t, err)
+		require.Equal(t, "nested", string(content))
+
+		err = ctr.CopyDirFromContainer(ctx, "/missing", hostDir)
+		require.True(t, errdefs.IsNotFound(err))
+	})
+
+	t.Run("stats-consumers", func(t *testing.T) {
+		p := NewFakeProvider(t)
+		ctx := context.Background()
+
+		consumer := &testStatsConsumer{}
+		logger := &testStatsLogger{}
+		aggregator := NewStatsAggregator(logger)
+
+		req := GenericContainerRequest{
+			ContainerRequest: ContainerRequest{
+				Image: "nginx:alpine",
+			},
+			Started: true,
+		}
+		require.NoError(t, WithStatsConsumers(consumer, aggregator)(&req))
+		require.NoError(t, p.Customize(&req))
+
+		ctr, err := GenericContainer(ctx, req)
+		CleanupContainer(t, ctr)
+		require.NoError(t, err)
+
+		fakeCtr := p.Containers()[0]
+		fakeCtr.WriteStats(Stats{MemoryUsage: 100})
+		fakeCtr.WriteStats(Stats{MemoryUsage: 300})
+		require.NoError(t, ctr.Stop(ctx, nil))
+		fakeCtr.WriteStats(Stats{MemoryUsage: 500})
+
+		require.Len(t, consumer.Samples(), 2)
+		require.Equal(t, ctr.GetContainerID(), consumer.Samples()[0].ContainerID)
+
+		require.NoError(t, ctr.Terminate(ctx))
+
+		summary, ok := aggregator.Summary(ctr.GetContainerID())
+		require.True(t, ok)
+		require.Equal(t, uint64(300), summary.PeakMemoryUsage)
+		require.Equal(t, uint64(200), summary.AverageMemoryUsage)
+		require.Len(t, logger.msgs, 1)
+	})
+
+	t.Run("exit-hooks-and-events", func(t *testing.T) {
+		p := NewFakeProvider(t)
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		events, _ := p.Events(ctx)
+
+		var exits, oomKills []ContainerExit
+		req := GenericContainerRequest{
+			ContainerRequest: ContainerRequest{
+				Image: "nginx:alpine",
+				LifecycleHooks: []ContainerLifecycleHooks{
+					{
+						PostDies: []ContainerExitHook{
+							func(_ context.Context, _ Container, exit ContainerExit) error {
+								exits = append(exits, exit)
+								return nil
+							},
+						},
+						PostOOMKills: []ContainerExitHook{
+							func(_ context.Context, _ Container, exit ContainerExit) error {
+								oomKills = append(oomKills, exit)
+								return nil
+							},
+						},
+					},
+				},
+			},
+			Started: true,
+		}
+		require.NoError(t, p.Customize(&req))
+
+		ctr, err := GenericContainer(ctx, req)
+		CleanupContainer(t, ctr)
+		require.NoError(t, err)
+
+		fakeCtr := p.Containers()[0]
+		fakeCtr.WriteLogs(StdoutLog, []byte("starting\n"))
+		fakeCtr.WriteLogs(StderrLog, []byte("panic: boom\n"))
+		fakeCtr.Exit(2)
+		require.False(t, ctr.IsRunning())
+
+		require.Equal(t, []ContainerExit{{ExitCode: 2, Logs: "starting\npanic: boom\n"}}, exits)
+		require.Empty(t, oomKills)
+
+		require.NoError(t, ctr.Start(ctx))
+		fakeCtr.OOMKill()
+
+		require.Len(t, exits, 2)
+		require.True(t, exits[1].OOMKilled)
+		require.Equal(t, 137, exits[1].ExitCode)
+		require.Len(t, oomKills, 1)
+
+		var actions []string
+		for len(events) > 0 {
+			evt := <-events
+			require.Equal(t, ctr.GetContainerID(), evt.ContainerID)
+			require.Equal(t, core.SessionID(), evt.Attributes[core.LabelSessionID])
+			actions = append(actions, evt.Action)
+		}
+		require.Equal(t, []string{"create", "start", "die", "start", "oom", "die"}, actions)
+	})
+
+	t.Run("load-images", func(t *testing.T) {
+		p := NewFakeProvider(t)
+		ctx := context.Background()
+
+		archive := filepath.Join(t.TempDir(), "images.tar")
+		writeImageArchive(t, archive, map[string]string{
+			"manifest.json": `[{"RepoTags":["redis:7.2","nginx:alpine"]}]`,
+		})
+
+		require.NoError(t, p.LoadImages(ctx, archive))
+
+		images, err := p.ListImages(ctx)
+		require.NoError(t, err)
+		require.Equal(t, []ImageInfo{{ID: "redis:7.2", Name: "redis:7.2"}, {ID: "nginx:alpine", Name: "nginx:alpine"}}, images)
+
+		require.NoError(t, p.SaveImages(ctx, filepath.Join(t.TempDir(), "saved.tar"), "redis:7.2"))
+	})
+
+	t.Run("image-management", func(t *testing.T) {
+		p := NewFakeProvider(t)
+		ctx := context.Background()
+
+		require.NoError(t, p.PullImage(ctx, "redis:7.2"))
+		require.NoError(t, p.TagImage(ctx, "redis:7.2", "registry.example.com/redis:7.2"))
+
+		details, err := p.InspectImage(ctx, "registry.example.com/redis:7.2")
+		require.NoError(t, err)
+		require.Equal(t, []string{"registry.example.com/redis:7.2"}, details.RepoTags)
+
+		require.NoError(t, p.RemoveImage(ctx, "redis:7.2"))
+
+		_, err = p.InspectImage(ctx, "redis:7.2")
+		require.True(t, errdefs.IsNotFound(err))
+
+		err = p.TagImage(ctx, "redis:7.2", "redis:latest")
+		require.True(t, errdefs.IsNotFound(err))
+
+		err = p.RemoveImage(ctx, "redis:7.2")
+		require.True(t, errdefs.IsNotFound(err))
+
+		deleted, err := p.PruneImages(ctx, 0)
+		require.NoError(t, err)
+		require.Empty(t, deleted)
+	})
+
+	t.Run("reuse", func(t *testing.T) {
+		config.Reset() // reset the config using the internal method to avoid the sync.Once
+		t.Cleanup(config.Reset)
+
+		p := NewFakeProvider(t)
+		ctx := context.Background()
+
+		newRequest := func(env map[string]string) GenericContainerRequest {
+			return GenericContainerRequest{
+				ProviderType:     p.ProviderType(),
+				ContainerRequest: ContainerRequest{Image: "redis:7", Env: env},
+				Started:          true,
+				Reuse:            true,
+			}
+		}
+
+		// the containers without a name are not reused if the reuse is not enabled
+		ctr1, err := GenericContainer(ctx, newRequest(nil))
+		require.NoError(t, err)
+		ctr2, err := GenericContainer(ctx, newRequest(nil))
+		require.NoError(t, err)
+		require.NotEqual(t, ctr1.GetContainerID(), ctr2.GetContainerID())
+
+		// neither are the named containers, with a warning
+		logger := &inMemoryLogger{}
+		named1 := newRequest(nil)
+		named1.Name = "named"
+		named1.Logger = logger
+
+		_, err = GenericContainer(ctx, named1)
+		require.NoError(t, err)
+		require.Len(t, p.Containers(), 3)
+		require.Contains(t, logger.data[0], "Not reusing container named, as the reuse is not enabled")
+
+		config.Reset()
+		t.Setenv("TESTCONTAINERS_REUSE_ENABLED", "true")
+
+		ctr3, err := GenericContainer(ctx, newRequest(nil))
+		require.NoError(t, err)
+		ctr4, err := GenericContainer(ctx, newRequest(nil))
+		require.NoError(t, err)
+		require.Equal(t, ctr3.GetContainerID(), ctr4.GetContainerID())
+
+		// a changed request creates a new container
+		ctr5, err := GenericContainer(ctx, newRequest(map[string]string{"REDIS_ARGS": "--save 60 1"}))
+		require.NoError(t, err)
+		require.NotEqual(t, ctr3.GetContainerID(), ctr5.GetContainerID())
+
+		// a changed request with the same name is not reused
+		named := newRequest(nil)
+		named.Name = "cache"
+		_, err = GenericContainer(ctx, named)
+		require.NoError(t, err)
+
+		named.Env = map[string]string{"REDIS_ARGS": "--save 60 1"}
+		_, err = GenericContainer(ctx, named)
+		require.ErrorIs(t, err, ErrReuseRequestChanged)
+	})
+
+	t.Run("unregistered-after-test", func(t *testing.T) {
+		var pt ProviderType
+		t.Run("register", func(t *testing.T) {
+			pt = NewFakeProvider(t).ProviderType()
+
+			_, err := pt.GetProvider()
+			require.NoError(t, err)
+		})
+
+		_, err := pt.GetProvider()
+		require.EqualError(t, err, "unknown provider")
+	})
+}
+
+type fakeTestLogConsumer struct {
+	buf bytes.Buffer
+}
+
+func (c *fakeTestLogConsumer) Accept(l Log) {
+	c.buf.Write(l.Content)
+}
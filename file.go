@@ -4,8 +4,10 @@ import (
 	"archive/tar"
 	"bytes"
 	"compress/gzip"
+	"errors"
 	"fmt"
 	"io"
+	"io/fs"
 	"os"
 	"path/filepath"
 	"strings"
@@ -139,3 +141,189 @@ func tarFile(basePath string, fileContent func(tw io.Writer) error, fileContentS
 
 	return buffer, nil
 }
+
+// untarDir extracts an uncompressed tar archive of the directory baseDir, as produced by the
+// archive API of the Docker daemon, into dst, which is created if it does not exist.
+// It's the inverse of tarDir, but it preserves symlinks, hard links, file modes, modification times
+// and, if the current user is allowed to change it, the ownership of the files.
+// Entries that would be extracted outside dst, even through a symlink, are rejected.
+func untarDir(r io.Reader, dst string, baseDir string) error {
+	// always use dst as absolute path
+	abs, err := filepath.Abs(dst)
+	if err != nil {
+		return fmt.Errorf("error getting absolute path: %w", err)
+	}
+	dst = abs
+
+	if err := os.MkdirAll(dst, 0o755); err != nil {
+		return fmt.Errorf("error creating directory: %w", err)
+	}
+
+	Logger.Printf(">> extracting TAR file to directory: %s\n", dst)
+
+	// the attributes of the directories are restored at the end,
+	// as extracting their content would modify them.
+	type extractedDir struct {
+		header *tar.Header
+		target string
+	}
+	var dirs []extractedDir
+
+	tr := tar.NewReader(r)
+	for {
+		header, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("error reading tar file: %w", err)
+		}
+
+		rel, ok := archiveRelPath(header.Name, baseDir)
+		if !ok {
+			return fmt.Errorf("entry %s is outside of directory %s", header.Name, baseDir)
+		}
+
+		target, err := extractPath(dst, rel)
+		if err != nil {
+			return err
+		}
+
+		switch header.Typeflag {
+		case tar.TypeDir:
+			if err := os.MkdirAll(target, 0o755); err != nil {
+				return fmt.Errorf("error creating directory: %w", err)
+			}
+
+			dirs = append(dirs, extractedDir{header: header, target: target})
+
+			continue
+		case tar.TypeReg:
+			if err := extractFile(tr, target); err != nil {
+				return err
+			}
+		case tar.TypeSymlink:
+			if err := replaceWith(target, func() error { return os.Symlink(header.Linkname, target) }); err != nil {
+				return fmt.Errorf("error creating symlink: %w", err)
+			}
+		case tar.TypeLink:
+			linkRel, ok := archiveRelPath(header.Linkname, baseDir)
+			if !ok {
+				return fmt.Errorf("hard link %s is outside of directory %s", header.Linkname, baseDir)
+			}
+
+			source, err := extractPath(dst, linkRel)
+			if err != nil {
+				return err
+			}
+
+			if err := replaceWith(target, func() error { return os.Link(source, target) }); err != nil {
+				return fmt.Errorf("error creating hard link: %w", err)
+			}
+		default:
+			Logger.Printf(">> skipping unsupported file: %s\n", header.Name)
+			continue
+		}
+
+		if err := restoreAttributes(header, target); err != nil {
+			return err
+		}
+	}
+
+	// restore the deepest directories first, in case any of them is not writable.
+	for i := len(dirs) - 1; i >= 0; i-- {
+		if err := restoreAttributes(dirs[i].header, dirs[i].target); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// extractPath returns the path of rel inside dst, failing if it's outside of dst,
+// either because of its ".." elements or because any of its parents is a symlink.
+func extractPath(dst string, rel string) (string, error) {
+	target := filepath.Join(dst, filepath.FromSlash(rel))
+	if target != dst && !strings.HasPrefix(target, dst+string(filepath.Separator)) {
+		return "", fmt.Errorf("path %s is outside of directory %s", rel, dst)
+	}
+
+	for parent := filepath.Dir(target); len(parent) > len(dst); parent = filepath.Dir(parent) {
+		fi, err := os.Lstat(parent)
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				continue
+			}
+
+			return "", err
+		}
+
+		if fi.Mode()&fs.ModeSymlink != 0 {
+			return "", fmt.Errorf("path %s is outside of directory %s: %s is a symlink", rel, dst, parent)
+		}
+	}
+
+	return target, nil
+}
+
+// extractFile writes the content of the current entry of the tar reader to a new file,
+// replacing the existing one, if any, without following it if it's a symlink.
+func extractFile(tr *tar.Reader, target string) error {
+	if err := os.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+		return fmt.Errorf("error creating directory: %w", err)
+	}
+
+	return replaceWith(target, func() error {
+		f, err := os.OpenFile(target, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o600)
+		if err != nil {
+			return fmt.Errorf("error creating file: %w", err)
+		}
+		defer f.Close()
+
+		if _, err := io.Copy(f, tr); err != nil {
+			return fmt.Errorf("error extracting file: %w", err)
+		}
+
+		return f.Close()
+	})
+}
+
+// replaceWith removes the file at target, if it exists and it's not a directory, and creates it again.
+func replaceWith(target string, create func() error) error {
+	fi, err := os.Lstat(target)
+	if err == nil && !fi.IsDir() {
+		if err := os.Remove(target); err != nil {
+			return err
+		}
+	}
+
+	return create()
+}
+
+// restoreAttributes sets the ownership, mode and modification time of the header to the target.
+// Hard links share them with the file they link to, and symlinks keep their own mode and
+// modification time, as they can't be changed portably.
+func restoreAttributes(header *tar.Header, target string) error {
+	if header.Typeflag == tar.TypeLink {
+		return nil
+	}
+
+	// changing the ownership is only possible with enough privileges, so errors are ignored.
+	// It's done first, as it could clear the setuid and setgid bits.
+	_ = os.Lchown(target, header.Uid, header.Gid)
+
+	if header.Typeflag == tar.TypeSymlink {
+		return nil
+	}
+
+	mode := header.FileInfo().Mode() & (fs.ModePerm | fs.ModeSetuid | fs.ModeSetgid | fs.ModeSticky)
+	if err := os.Chmod(target, mode); err != nil {
+		return fmt.Errorf("error changing file mode: %w", err)
+	}
+
+	if err := os.Chtimes(target, header.AccessTime, header.ModTime); err != nil {
+		return fmt.Errorf("error changing file times: %w", err)
+	}
+
+	return nil
+}
@@ -11,6 +11,7 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -139,6 +140,110 @@ func Test_TarFile(t *testing.T) {
 	assert.Equal(t, b, untarBytes)
 }
 
+func Test_UntarDir(t *testing.T) {
+	modTime := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	// buildTar builds an uncompressed tar archive, as the Docker daemon returns it.
+	buildTar := func(t *testing.T, headers ...*tar.Header) io.Reader {
+		t.Helper()
+
+		buff := &bytes.Buffer{}
+		tw := tar.NewWriter(buff)
+		for _, h := range headers {
+			if h.Typeflag == tar.TypeReg {
+				h.Size = int64(len(h.Name))
+			}
+			require.NoError(t, tw.WriteHeader(h))
+			if h.Typeflag == tar.TypeReg {
+				_, err := tw.Write([]byte(h.Name))
+				require.NoError(t, err)
+			}
+		}
+		require.NoError(t, tw.Close())
+
+		return buff
+	}
+
+	t.Run("preserves-symlinks-modes-and-times", func(t *testing.T) {
+		r := buildTar(t,
+			&tar.Header{Name: "reports/", Typeflag: tar.TypeDir, Mode: 0o755, ModTime: modTime},
+			&tar.Header{Name: "reports/nested/", Typeflag: tar.TypeDir, Mode: 0o500, ModTime: modTime},
+			&tar.Header{Name: "reports/nested/result.txt", Typeflag: tar.TypeReg, Mode: 0o640, ModTime: modTime},
+			&tar.Header{Name: "reports/run.sh", Typeflag: tar.TypeReg, Mode: 0o750, ModTime: modTime},
+			&tar.Header{Name: "reports/latest", Typeflag: tar.TypeSymlink, Linkname: "nested/result.txt"},
+			&tar.Header{Name: "reports/run-link.sh", Typeflag: tar.TypeLink, Linkname: "reports/run.sh"},
+		)
+
+		dst := filepath.Join(t.TempDir(), "out")
+		require.NoError(t, untarDir(r, dst, "reports"))
+		t.Cleanup(func() {
+			// allow the temporary directory to be removed
+			require.NoError(t, os.Chmod(filepath.Join(dst, "nested"), 0o755))
+		})
+
+		content, err := os.ReadFile(filepath.Join(dst, "nested", "result.txt"))
+		require.NoError(t, err)
+		require.Equal(t, "reports/nested/result.txt", string(content))
+
+		fi, err := os.Stat(filepath.Join(dst, "run.sh"))
+		require.NoError(t, err)
+		require.Equal(t, os.FileMode(0o750), fi.Mode().Perm())
+		require.True(t, modTime.Equal(fi.ModTime()))
+
+		fi, err = os.Stat(filepath.Join(dst, "nested"))
+		require.NoError(t, err)
+		require.Equal(t, os.FileMode(0o500), fi.Mode().Perm())
+		require.True(t, modTime.Equal(fi.ModTime()))
+
+		link, err := os.Readlink(filepath.Join(dst, "latest"))
+		require.NoError(t, err)
+		require.Equal(t, "nested/result.txt", link)
+
+		content, err = os.ReadFile(filepath.Join(dst, "run-link.sh"))
+		require.NoError(t, err)
+		require.Equal(t, "reports/run.sh", string(content))
+	})
+
+	t.Run("root-directory", func(t *testing.T) {
+		r := buildTar(t,
+			&tar.Header{Name: "etc/", Typeflag: tar.TypeDir, Mode: 0o755},
+			&tar.Header{Name: "etc/hostname", Typeflag: tar.TypeReg, Mode: 0o644},
+		)
+
+		dst := t.TempDir()
+		require.NoError(t, untarDir(r, dst, "/"))
+
+		content, err := os.ReadFile(filepath.Join(dst, "etc", "hostname"))
+		require.NoError(t, err)
+		require.Equal(t, "etc/hostname", string(content))
+	})
+
+	t.Run("rejects-path-traversal", func(t *testing.T) {
+		r := buildTar(t,
+			&tar.Header{Name: "reports/", Typeflag: tar.TypeDir, Mode: 0o755},
+			&tar.Header{Name: "reports/../../evil.txt", Typeflag: tar.TypeReg, Mode: 0o644},
+		)
+
+		dst := filepath.Join(t.TempDir(), "out")
+		require.Error(t, untarDir(r, dst, "reports"))
+		require.NoFileExists(t, filepath.Join(filepath.Dir(dst), "evil.txt"))
+	})
+
+	t.Run("rejects-writing-through-symlinks", func(t *testing.T) {
+		outside := t.TempDir()
+
+		r := buildTar(t,
+			&tar.Header{Name: "reports/", Typeflag: tar.TypeDir, Mode: 0o755},
+			&tar.Header{Name: "reports/escape", Typeflag: tar.TypeSymlink, Linkname: outside},
+			&tar.Header{Name: "reports/escape/evil.txt", Typeflag: tar.TypeReg, Mode: 0o644},
+		)
+
+		err := untarDir(r, filepath.Join(t.TempDir(), "out"), "reports")
+		require.ErrorContains(t, err, "is a symlink")
+		require.NoFileExists(t, filepath.Join(outside, "evil.txt"))
+	})
+}
+
 // untar takes a destination path and a reader; a tar reader loops over the tarfile
 // creating the file structure at 'dst' along the way, and writing any files
 func untar(dst string, r io.Reader) error {
@@ -2,9 +2,12 @@ package testcontainers
 
 import (
 	"context"
+	"embed"
 	"fmt"
 	"io"
+	"io/fs"
 	"log"
+	"os"
 	"path/filepath"
 	"strings"
 	"testing"
@@ -241,3 +244,247 @@ func TestBuildImageFromDockerfile_TargetDoesNotExist(t *testing.T) {
 	CleanupContainer(t, ctr)
 	require.Error(t, err)
 }
+
+func TestBuildImageFromDockerfile_BuildKit(t *testing.T) {
+	ctx := context.Background()
+
+	consumer := &testBuildLogConsumer{}
+
+	// buildWithBuildKit {
+	c, err := GenericContainer(ctx, GenericContainerRequest{
+		ContainerRequest: ContainerRequest{
+			FromDockerfile: FromDockerfile{
+				Context:    "testdata",
+				Dockerfile: "buildkit.Dockerfile",
+				Target:     "secret",
+				Secrets: map[string][]byte{
+					"token": []byte("s3cr3t"),
+				},
+				BuildLogConsumer: consumer,
+			},
+		},
+		Started: true,
+	})
+	// }
+	CleanupContainer(t, c)
+	require.NoError(t, err)
+
+	r, err := c.Logs(ctx)
+	require.NoError(t, err)
+
+	logs, err := io.ReadAll(r)
+	require.NoError(t, err)
+	require.Equal(t, "secret\n", string(logs))
+
+	var output strings.Builder
+	for _, l := range consumer.logs {
+		require.Equal(t, StdoutLog, l.LogType)
+		output.Write(l.Content)
+	}
+	require.Contains(t, output.String(), "--mount=type=secret")
+}
+
+func TestBuildImageFromDockerfile_BuildKitMissingSecret(t *testing.T) {
+	ctx := context.Background()
+
+	c, err := GenericContainer(ctx, GenericContainerRequest{
+		ContainerRequest: ContainerRequest{
+			FromDockerfile: FromDockerfile{
+				Context:    "testdata",
+				Dockerfile: "buildkit.Dockerfile",
+				Target:     "secret",
+				BuildKit:   true,
+			},
+		},
+		Started: true,
+	})
+	CleanupContainer(t, c)
+	require.Error(t, err)
+}
+
+func TestBuildOptions_BuildKit(t *testing.T) {
+	version := "1.0"
+
+	req := &ContainerRequest{
+		FromDockerfile: FromDockerfile{
+			Context:     "testdata",
+			Dockerfile:  "buildkit.Dockerfile",
+			Target:      "final",
+			CacheFrom:   []string{"registry.example.com/app:cache"},
+			InlineCache: true,
+			BuildArgs:   map[string]*string{"VERSION": &version},
+		},
+	}
+
+	opts, err := req.BuildOptions()
+	require.NoError(t, err)
+	tryClose(opts.Context)
+
+	require.Equal(t, types.BuilderBuildKit, opts.Version)
+	require.Equal(t, "final", opts.Target)
+	require.Equal(t, []string{"registry.example.com/app:cache"}, opts.CacheFrom)
+	require.Len(t, opts.BuildArgs, 2)
+	require.Equal(t, "1", *opts.BuildArgs["BUILDKIT_INLINE_CACHE"])
+
+	// the build args of the request are not modified
+	require.Equal(t, map[string]*string{"VERSION": &version}, req.FromDockerfile.BuildArgs)
+
+	t.Run("legacy", func(t *testing.T) {
+		req := &ContainerRequest{
+			FromDockerfile: FromDockerfile{
+				Context:    "testdata",
+				Dockerfile: "echo.Dockerfile",
+			},
+		}
+
+		opts, err := req.BuildOptions()
+		require.NoError(t, err)
+		tryClose(opts.Context)
+
+		require.Empty(t, opts.Version)
+	})
+}
+
+//go:embed all:testdata/buildfs
+var buildFS embed.FS
+
+func TestBuildImageFromDockerfile_ContextFS(t *testing.T) {
+	ctx := context.Background()
+
+	// buildFromContextFS {
+	contextFS, err := fs.Sub(buildFS, "testdata/buildfs")
+	require.NoError(t, err)
+
+	c, err := GenericContainer(ctx, GenericContainerRequest{
+		ContainerRequest: ContainerRequest{
+			FromDockerfile: FromDockerfile{
+				ContextFS: contextFS,
+				DockerfileContent: `FROM docker.io/alpine
+COPY . /app
+CMD ["ls", "-R", "/app"]`,
+			},
+		},
+		Started: true,
+	})
+	// }
+	CleanupContainer(t, c)
+	require.NoError(t, err)
+
+	r, err := c.Logs(ctx)
+	require.NoError(t, err)
+
+	logs, err := io.ReadAll(r)
+	require.NoError(t, err)
+
+	// secret.txt and the Dockerfile are excluded by the .dockerignore file
+	require.Equal(t, "/app:\nconf\nhello.txt\n\n/app/conf:\napp.properties\n", string(logs))
+}
+
+func TestBuildHash(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "Dockerfile"), []byte("FROM docker.io/alpine\n"), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "app.txt"), []byte("v1"), 0o644))
+
+	version := "1.0"
+
+	hash := func(t *testing.T, req *ContainerRequest) string {
+		t.Helper()
+
+		h, err := req.buildHash(types.ImageBuildOptions{
+			Dockerfile: req.GetDockerfile(),
+			BuildArgs:  req.GetBuildArgs(),
+			Target:     req.FromDockerfile.Target,
+		})
+		require.NoError(t, err)
+		require.Len(t, h, 64)
+
+		return h
+	}
+
+	newRequest := func() *ContainerRequest {
+		return &ContainerRequest{
+			FromDockerfile: FromDockerfile{
+				Context:   dir,
+				BuildArgs: map[string]*string{"VERSION": &version, "UNSET": nil},
+				KeepImage: true,
+			},
+		}
+	}
+
+	base := hash(t, newRequest())
+	require.Equal(t, base, hash(t, newRequest()))
+
+	t.Run("modification-time", func(t *testing.T) {
+		later := time.Now().Add(time.Hour)
+		require.NoError(t, os.Chtimes(filepath.Join(dir, "app.txt"), later, later))
+
+		require.Equal(t, base, hash(t, newRequest()))
+	})
+
+	t.Run("build-args", func(t *testing.T) {
+		other := "2.0"
+		req := newRequest()
+		req.FromDockerfile.BuildArgs = map[string]*string{"VERSION": &other, "UNSET": nil}
+
+		require.NotEqual(t, base, hash(t, req))
+	})
+
+	t.Run("target", func(t *testing.T) {
+		req := newRequest()
+		req.FromDockerfile.Target = "final"
+
+		require.NotEqual(t, base, hash(t, req))
+	})
+
+	t.Run("dockerfile-content", func(t *testing.T) {
+		req := newRequest()
+		req.FromDockerfile.DockerfileContent = "FROM docker.io/alpine:3.20\n"
+
+		require.NotEqual(t, base, hash(t, req))
+	})
+
+	t.Run("context", func(t *testing.T) {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "app.txt"), []byte("v2"), 0o644))
+
+		require.NotEqual(t, base, hash(t, newRequest()))
+	})
+}
+
+func TestBuildImageFromDockerfile_ReuseBuiltImage(t *testing.T) {
+	provider, err := NewDockerProvider()
+	require.NoError(t, err)
+	defer provider.Close()
+
+	ctx := context.Background()
+
+	// reuseBuiltImage {
+	req := &ContainerRequest{
+		FromDockerfile: FromDockerfile{
+			Context:    "testdata",
+			Dockerfile: "echo.Dockerfile",
+			KeepImage:  true,
+		},
+	}
+	// }
+
+	tag, err := provider.BuildImage(ctx, req)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_, err := provider.Client().ImageRemove(ctx, tag, image.RemoveOptions{Force: true, PruneChildren: true})
+		require.NoError(t, err)
+	})
+	require.True(t, strings.HasPrefix(tag, builtImageRepo+":"))
+
+	inspect, _, err := provider.Client().ImageInspectWithRaw(ctx, tag)
+	require.NoError(t, err)
+
+	// the second build is skipped, as the image with the same hash exists
+	reusedTag, err := provider.BuildImage(ctx, req)
+	require.NoError(t, err)
+	require.Equal(t, tag, reusedTag)
+
+	reused, _, err := provider.Client().ImageInspectWithRaw(ctx, reusedTag)
+	require.NoError(t, err)
+	require.Equal(t, inspect.ID, reused.ID)
+	require.Equal(t, inspect.Created, reused.Created)
+}
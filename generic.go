@@ -11,7 +11,12 @@ import (
 )
 
 var (
-	reuseContainerMx  sync.Mutex
+	reuseContainerMx sync.Mutex
+
+	// ErrReuseEmptyName was returned when a container was requested with the Reuse option and without a name.
+	//
+	// Deprecated: it's not returned anymore, as the containers requested with the Reuse option and without
+	// a name are identified by a hash of their request.
 	ErrReuseEmptyName = errors.New("with reuse option a container name mustn't be empty")
 )
 
@@ -21,7 +26,7 @@ type GenericContainerRequest struct {
 	Started          bool         // whether to auto-start the container
 	ProviderType     ProviderType // which provider to use, Docker if empty
 	Logger           Logging      // provide a container specific Logging - use default global logger if empty
-	Reuse            bool         // reuse an existing container if it exists or create a new one. a container name mustn't be empty
+	Reuse            bool         // reuse an existing container created from the same request if it exists or create a new one. without a name, it requires the reuse.enabled property
 }
 
 // Deprecated: will be removed in the future.
@@ -48,8 +53,20 @@ func GenericNetwork(ctx context.Context, req GenericNetworkRequest) (Network, er
 
 // GenericContainer creates a generic container with parameters
 func GenericContainer(ctx context.Context, req GenericContainerRequest) (Container, error) {
-	if req.Reuse && req.Name == "" {
-		return nil, ErrReuseEmptyName
+	c, err := genericContainer(ctx, req)
+	if err != nil && c == nil {
+		// the reserved host ports are released when the container is terminated,
+		// so they are released here if the container was not created.
+		req.releaseReservedHostPorts()
+	}
+
+	return c, err
+}
+
+// genericContainer creates the container of GenericContainer.
+func genericContainer(ctx context.Context, req GenericContainerRequest) (Container, error) {
+	if err := req.expandReservedHostPorts(); err != nil {
+		return nil, fmt.Errorf("expand reserved host ports: %w", err)
 	}
 
 	logging := req.Logger
@@ -62,8 +79,16 @@ func GenericContainer(ctx context.Context, req GenericContainerRequest) (Contain
 	}
 	defer provider.Close()
 
+	// the reuse must be enabled globally, which is meant for the developer machines, so the containers
+	// are never reused in CI, including the named ones.
+	reuse := req.Reuse && provider.Config().Config.ReuseEnabled
+	if req.Reuse && !reuse && req.Name != "" {
+		logging.Printf("⚠️ Not reusing container %s, as the reuse is not enabled: set reuse.enabled=true "+
+			"in the properties file, or TESTCONTAINERS_REUSE_ENABLED=true", req.Name)
+	}
+
 	var c Container
-	if req.Reuse {
+	if reuse {
 		// we must protect the reusability of the container in the case it's invoked
 		// in a parallel execution, via ParallelContainers or t.Parallel()
 		reuseContainerMx.Lock()
@@ -14,6 +14,8 @@ import (
 	"github.com/docker/docker/api/types/filters"
 	"github.com/stretchr/testify/require"
 
+	"github.com/testcontainers/testcontainers-go/internal/config"
+	"github.com/testcontainers/testcontainers-go/internal/core"
 	"github.com/testcontainers/testcontainers-go/wait"
 )
 
@@ -50,16 +52,6 @@ func TestGenericReusableContainer(t *testing.T) {
 		errorMatcher  func(err error) error
 		reuseOption   bool
 	}{
-		{
-			name: "reuse option with empty name",
-			errorMatcher: func(err error) error {
-				if errors.Is(err, ErrReuseEmptyName) {
-					return nil
-				}
-				return err
-			},
-			reuseOption: true,
-		},
 		{
 			name:          "container already exists (reuse=false)",
 			containerName: reusableContainerName,
@@ -106,6 +98,43 @@ func TestGenericReusableContainer(t *testing.T) {
 	}
 }
 
+func TestGenericReusableContainerByHash(t *testing.T) {
+	config.Reset() // reset the config using the internal method to avoid the sync.Once
+	t.Cleanup(config.Reset)
+	t.Setenv("TESTCONTAINERS_REUSE_ENABLED", "true")
+
+	ctx := context.Background()
+
+	// reuseByHash {
+	req := GenericContainerRequest{
+		ContainerRequest: ContainerRequest{
+			Image:        nginxAlpineImage,
+			ExposedPorts: []string{nginxDefaultPort},
+			Env:          map[string]string{"TEST_SESSION": core.SessionID()},
+			WaitingFor:   wait.ForListeningPort(nginxDefaultPort),
+		},
+		Started: true,
+		Reuse:   true,
+	}
+
+	n1, err := GenericContainer(ctx, req)
+	CleanupContainer(t, n1)
+	require.NoError(t, err)
+
+	// the same request reuses the container
+	n2, err := GenericContainer(ctx, req)
+	require.NoError(t, err)
+	require.Equal(t, n1.GetContainerID(), n2.GetContainerID())
+
+	// a changed request creates a new container
+	req.Env = map[string]string{"TEST_SESSION": core.SessionID(), "NGINX_PORT": "80"}
+	n3, err := GenericContainer(ctx, req)
+	CleanupContainer(t, n3)
+	require.NoError(t, err)
+	require.NotEqual(t, n1.GetContainerID(), n3.GetContainerID())
+	// }
+}
+
 func TestGenericContainerShouldReturnRefOnError(t *testing.T) {
 	// In this test, we are going to cancel the context to exit the `wait.Strategy`.
 	// We want to make sure that the GenericContainer call will still return a reference to the
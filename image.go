@@ -2,6 +2,9 @@ package testcontainers
 
 import (
 	"context"
+	"time"
+
+	specs "github.com/opencontainers/image-spec/specs-go/v1"
 )
 
 // ImageInfo represents a summary information of an image
@@ -10,9 +13,42 @@ type ImageInfo struct {
 	Name string
 }
 
+// ImageDetails represents the details of an image, as returned by InspectImage
+type ImageDetails struct {
+	ID       string
+	RepoTags []string
+	Digests  []string // repository digests of the image, e.g. redis@sha256:...
+	Platform specs.Platform
+	Size     int64
+	Labels   map[string]string
+	Created  time.Time
+}
+
 // ImageProvider allows manipulating images
 type ImageProvider interface {
 	ListImages(context.Context) ([]ImageInfo, error)
 	SaveImages(context.Context, string, ...string) error
+	LoadImages(context.Context, string) error
 	PullImage(context.Context, string) error
+	InspectImage(context.Context, string) (ImageDetails, error)
+	TagImage(ctx context.Context, source string, target string) error
+	RemoveImage(ctx context.Context, img string, opts ...RemoveImageOption) error
+	PruneImages(ctx context.Context, olderThan time.Duration) ([]string, error)
+}
+
+// removeImageOptions is a type that holds the options for removing an image.
+type removeImageOptions struct {
+	force bool
+}
+
+// RemoveImageOption is a type that represents an option for removing an image.
+type RemoveImageOption func(*removeImageOptions)
+
+// RemoveImageForce returns a RemoveImageOption forcing the removal of the image, even if
+// it's used by stopped containers, or if it's removed by ID while it has several tags.
+// Default: the removal fails in those cases.
+func RemoveImageForce() RemoveImageOption {
+	return func(o *removeImageOptions) {
+		o.force = true
+	}
 }
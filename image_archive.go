@@ -0,0 +1,209 @@
+package testcontainers
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/distribution/reference"
+)
+
+// ErrOffline is returned when an image must be pulled while the offline mode is enabled,
+// and the image is not found in the image archives directory.
+var ErrOffline = errors.New("offline mode forbids pulling images")
+
+// imageArchiveExtensions are the extensions of the image archives looked up in the image archives directory.
+var imageArchiveExtensions = []string{".tar", ".tar.gz", ".tgz"}
+
+var (
+	// imageArchivesMx protects imageArchives.
+	imageArchivesMx sync.Mutex
+
+	// imageArchives caches the tags of the image archives, by path, so each archive is only
+	// read once per process, unless it's modified.
+	imageArchives = map[string]indexedImageArchive{}
+)
+
+// indexedImageArchive holds the tags of an image archive, and the modification time
+// and size of the archive when they were read.
+type indexedImageArchive struct {
+	modTime time.Time
+	size    int64
+	tags    []string
+}
+
+// findImageArchive returns the path of the first archive of the directory, in lexical order,
+// containing the image, or an empty string if no archive contains it. The archives which can't
+// be read are skipped, logging why.
+func findImageArchive(logger Logging, dir string, img string) (string, error) {
+	name, ok := normalizeImageTag(img)
+	if !ok {
+		return "", nil
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return "", fmt.Errorf("read image archives directory: %w", err)
+	}
+
+	for _, entry := range entries {
+		if entry.IsDir() || !isImageArchive(entry.Name()) {
+			continue
+		}
+
+		archive := filepath.Join(dir, entry.Name())
+		tags, err := indexImageArchive(archive)
+		if err != nil {
+			logger.Printf("⚠️ Skipping unreadable image archive: %v", err)
+			continue
+		}
+
+		for _, tag := range tags {
+			if t, ok := normalizeImageTag(tag); ok && t == name {
+				return archive, nil
+			}
+		}
+	}
+
+	return "", nil
+}
+
+// indexImageArchive returns the tags of the images of an archive, reading them only
+// if the archive was not read before, or if it was modified since it was read.
+func indexImageArchive(archive string) ([]string, error) {
+	info, err := os.Stat(archive)
+	if err != nil {
+		return nil, fmt.Errorf("stat image archive: %w", err)
+	}
+
+	imageArchivesMx.Lock()
+	indexed, ok := imageArchives[archive]
+	imageArchivesMx.Unlock()
+
+	if ok && indexed.modTime.Equal(info.ModTime()) && indexed.size == info.Size() {
+		return indexed.tags, nil
+	}
+
+	tags, err := imageArchiveTags(archive)
+	if err != nil {
+		return nil, err
+	}
+
+	imageArchivesMx.Lock()
+	imageArchives[archive] = indexedImageArchive{
+		modTime: info.ModTime(),
+		size:    info.Size(),
+		tags:    tags,
+	}
+	imageArchivesMx.Unlock()
+
+	return tags, nil
+}
+
+// isImageArchive returns true if the file name has the extension of an image archive.
+func isImageArchive(name string) bool {
+	for _, ext := range imageArchiveExtensions {
+		if strings.HasSuffix(name, ext) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// normalizeImageTag returns the fully qualified name of the image, with the latest tag if it has
+// no tag, e.g. docker.io/library/alpine:latest for alpine. It returns false for invalid references
+// and for the references using a digest, which cannot be matched with the tags of the archives.
+func normalizeImageTag(img string) (string, bool) {
+	named, err := reference.ParseNormalizedNamed(img)
+	if err != nil {
+		return "", false
+	}
+
+	if _, ok := named.(reference.Digested); ok {
+		return "", false
+	}
+
+	return reference.TagNameOnly(named).String(), true
+}
+
+// imageArchiveTags returns the tags of the images of an archive, either created by docker save,
+// listing them in its manifest.json file, or an OCI image layout, annotating them in its index.json file.
+// The archive can be compressed with gzip.
+func imageArchiveTags(archive string) ([]string, error) {
+	f, err := os.Open(archive)
+	if err != nil {
+		return nil, fmt.Errorf("open image archive: %w", err)
+	}
+	defer f.Close()
+
+	var r io.Reader = f
+	if !strings.HasSuffix(archive, ".tar") {
+		gz, err := gzip.NewReader(f)
+		if err != nil {
+			return nil, fmt.Errorf("read image archive %s: %w", archive, err)
+		}
+		defer gz.Close()
+
+		r = gz
+	}
+
+	var tags []string
+	seen := map[string]bool{}
+	add := func(tag string) {
+		if tag != "" && !seen[tag] {
+			seen[tag] = true
+			tags = append(tags, tag)
+		}
+	}
+
+	tr := tar.NewReader(r)
+	for {
+		hdr, err := tr.Next()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return nil, fmt.Errorf("read image archive %s: %w", archive, err)
+		}
+
+		switch strings.TrimPrefix(hdr.Name, "./") {
+		case "manifest.json":
+			var manifest []struct {
+				RepoTags []string
+			}
+			if err := json.NewDecoder(tr).Decode(&manifest); err != nil {
+				return nil, fmt.Errorf("read manifest.json of image archive %s: %w", archive, err)
+			}
+
+			for _, m := range manifest {
+				for _, tag := range m.RepoTags {
+					add(tag)
+				}
+			}
+		case "index.json":
+			var index struct {
+				Manifests []struct {
+					Annotations map[string]string `json:"annotations"`
+				} `json:"manifests"`
+			}
+			if err := json.NewDecoder(tr).Decode(&index); err != nil {
+				return nil, fmt.Errorf("read index.json of image archive %s: %w", archive, err)
+			}
+
+			for _, m := range index.Manifests {
+				add(m.Annotations["io.containerd.image.name"])
+			}
+		}
+	}
+
+	return tags, nil
+}
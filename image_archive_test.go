@@ -0,0 +1,143 @@
+package testcontainers
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// writeImageArchive writes a tar archive with the files, in lexical order, compressed with gzip if the path ends with .gz.
+func writeImageArchive(t *testing.T, path string, files map[string]string) {
+	t.Helper()
+
+	f, err := os.Create(path)
+	require.NoError(t, err)
+	defer f.Close()
+
+	var w io.Writer = f
+	if filepath.Ext(path) == ".gz" {
+		gz := gzip.NewWriter(f)
+		defer gz.Close()
+		w = gz
+	}
+
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	tw := tar.NewWriter(w)
+	for _, name := range names {
+		content := files[name]
+		err = tw.WriteHeader(&tar.Header{Name: name, Mode: 0o644, Size: int64(len(content)), Typeflag: tar.TypeReg})
+		require.NoError(t, err)
+
+		_, err = tw.Write([]byte(content))
+		require.NoError(t, err)
+	}
+	require.NoError(t, tw.Close())
+}
+
+func TestImageArchives(t *testing.T) {
+	dir := t.TempDir()
+
+	writeImageArchive(t, filepath.Join(dir, "a-redis.tar"), map[string]string{
+		"manifest.json": `[{"Config":"blobs/sha256/aaa","RepoTags":["redis:7.2","redis:latest"],"Layers":[]}]`,
+		"index.json":    `{"manifests":[{"annotations":{"io.containerd.image.name":"docker.io/library/redis:7.2"}}]}`,
+	})
+	writeImageArchive(t, filepath.Join(dir, "b-oci.tar.gz"), map[string]string{
+		"oci-layout": `{"imageLayoutVersion":"1.0.0"}`,
+		"index.json": `{"manifests":[{"annotations":{"io.containerd.image.name":"quay.io/keycloak/keycloak:25.0","org.opencontainers.image.ref.name":"25.0"}}]}`,
+	})
+	writeImageArchive(t, filepath.Join(dir, "c-redis.tar"), map[string]string{
+		"manifest.json": `[{"RepoTags":["docker.io/library/redis:7.2"]}]`,
+	})
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "README.md"), []byte("not an archive"), 0o600))
+
+	tags, err := imageArchiveTags(filepath.Join(dir, "a-redis.tar"))
+	require.NoError(t, err)
+	require.Equal(t, []string{"docker.io/library/redis:7.2", "redis:7.2", "redis:latest"}, tags)
+
+	tags, err = imageArchiveTags(filepath.Join(dir, "b-oci.tar.gz"))
+	require.NoError(t, err)
+	require.Equal(t, []string{"quay.io/keycloak/keycloak:25.0"}, tags)
+
+	for img, expected := range map[string]string{
+		"redis":                          "a-redis.tar",
+		"docker.io/redis:7.2":            "a-redis.tar",
+		"quay.io/keycloak/keycloak:25.0": "b-oci.tar.gz",
+		"redis:6":                        "",
+		"quay.io/keycloak/keycloak":      "",
+		"redis@sha256:0000000000000000000000000000000000000000000000000000000000000000": "",
+	} {
+		t.Run(img, func(t *testing.T) {
+			archive, err := findImageArchive(TestLogger(t), dir, img)
+			require.NoError(t, err)
+
+			if expected == "" {
+				require.Empty(t, archive)
+				return
+			}
+
+			require.Equal(t, filepath.Join(dir, expected), archive)
+		})
+	}
+
+	t.Run("invalid-archive", func(t *testing.T) {
+		dir := t.TempDir()
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "a-broken.tar.gz"), []byte("not gzip"), 0o600))
+		writeImageArchive(t, filepath.Join(dir, "b-redis.tar"), map[string]string{
+			"manifest.json": `[{"RepoTags":["redis:7.2"]}]`,
+		})
+
+		// the unreadable archives are skipped
+		archive, err := findImageArchive(TestLogger(t), dir, "redis:7.2")
+		require.NoError(t, err)
+		require.Equal(t, filepath.Join(dir, "b-redis.tar"), archive)
+	})
+
+	t.Run("cached", func(t *testing.T) {
+		dir := t.TempDir()
+		archive := filepath.Join(dir, "redis.tar")
+		writeImageArchive(t, archive, map[string]string{
+			"manifest.json": `[{"RepoTags":["redis:7.2"]}]`,
+		})
+
+		tags, err := indexImageArchive(archive)
+		require.NoError(t, err)
+		require.Equal(t, []string{"redis:7.2"}, tags)
+
+		imageArchivesMx.Lock()
+		indexed := imageArchives[archive]
+		indexed.tags = []string{"cached:1"}
+		imageArchives[archive] = indexed
+		imageArchivesMx.Unlock()
+
+		// the archive is not read again while it's not modified
+		tags, err = indexImageArchive(archive)
+		require.NoError(t, err)
+		require.Equal(t, []string{"cached:1"}, tags)
+
+		writeImageArchive(t, archive, map[string]string{
+			"manifest.json": `[{"RepoTags":["redis:7.4"]}]`,
+		})
+		require.NoError(t, os.Chtimes(archive, time.Now(), time.Now().Add(time.Hour)))
+
+		tags, err = indexImageArchive(archive)
+		require.NoError(t, err)
+		require.Equal(t, []string{"redis:7.4"}, tags)
+	})
+
+	t.Run("missing-directory", func(t *testing.T) {
+		_, err := findImageArchive(TestLogger(t), filepath.Join(t.TempDir(), "missing"), "redis:7.2")
+		require.ErrorContains(t, err, "read image archives directory")
+	})
+}
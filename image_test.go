@@ -5,7 +5,14 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 
+	"github.com/docker/docker/api/types/image"
+	"github.com/docker/docker/client"
+	"github.com/docker/docker/errdefs"
+	"github.com/stretchr/testify/require"
+
+	"github.com/testcontainers/testcontainers-go/internal/config"
 	"github.com/testcontainers/testcontainers-go/internal/core"
 )
 
@@ -87,3 +94,180 @@ func TestSaveImages(t *testing.T) {
 		t.Fatalf("output file is empty")
 	}
 }
+
+func TestLoadImages(t *testing.T) {
+	ctx := context.Background()
+
+	provider, err := NewDockerProvider()
+	require.NoError(t, err)
+	defer provider.Close()
+
+	// save a copy of an image with a tag unique to the test, which is removed afterwards
+	img := "testcontainers/load-images:" + core.SessionID()
+
+	err = provider.PullImage(ctx, nginxAlpineImage)
+	require.NoError(t, err)
+
+	err = provider.client.ImageTag(ctx, nginxAlpineImage, img)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_, _ = provider.client.ImageRemove(ctx, img, image.RemoveOptions{Force: true})
+	})
+
+	archives := t.TempDir()
+	err = provider.SaveImages(ctx, filepath.Join(archives, "load-images.tar"), img)
+	require.NoError(t, err)
+
+	removeImage := func() {
+		_, err := provider.client.ImageRemove(ctx, img, image.RemoveOptions{})
+		require.NoError(t, err)
+	}
+	removeImage()
+
+	t.Run("load-images", func(t *testing.T) {
+		err := provider.LoadImages(ctx, filepath.Join(archives, "load-images.tar"))
+		require.NoError(t, err)
+
+		_, _, err = provider.client.ImageInspectWithRaw(ctx, img)
+		require.NoError(t, err)
+
+		removeImage()
+	})
+
+	t.Run("offline-with-image-archives", func(t *testing.T) {
+		config.Reset() // reset the config using the internal method to avoid the sync.Once
+		t.Cleanup(config.Reset)
+		t.Setenv("TESTCONTAINERS_IMAGE_ARCHIVES_DIR", archives)
+		t.Setenv("TESTCONTAINERS_OFFLINE", "true")
+
+		// imageArchivesDir {
+		ctr, err := GenericContainer(ctx, GenericContainerRequest{
+			ContainerRequest: ContainerRequest{
+				// the image is loaded from the archives of the TESTCONTAINERS_IMAGE_ARCHIVES_DIR directory
+				Image: img,
+			},
+		})
+		// }
+		CleanupContainer(t, ctr)
+		require.NoError(t, err)
+	})
+
+	t.Run("offline", func(t *testing.T) {
+		config.Reset() // reset the config using the internal method to avoid the sync.Once
+		t.Cleanup(config.Reset)
+		t.Setenv("TESTCONTAINERS_OFFLINE", "true")
+
+		ctr, err := GenericContainer(ctx, GenericContainerRequest{
+			ContainerRequest: ContainerRequest{
+				Image: "testcontainers/not-present:offline",
+			},
+		})
+		CleanupContainer(t, ctr)
+		require.ErrorIs(t, err, ErrOffline)
+	})
+}
+
+func TestImageManagement(t *testing.T) {
+	ctx := context.Background()
+
+	provider, err := NewDockerProvider()
+	require.NoError(t, err)
+	defer provider.Close()
+
+	err = provider.PullImage(ctx, nginxAlpineImage)
+	require.NoError(t, err)
+
+	details, err := provider.InspectImage(ctx, nginxAlpineImage)
+	require.NoError(t, err)
+	require.NotEmpty(t, details.ID)
+	require.Contains(t, details.RepoTags, nginxAlpineImage)
+	require.NotEmpty(t, details.Digests)
+	require.Equal(t, "linux", details.Platform.OS)
+	require.Positive(t, details.Size)
+	require.False(t, details.Created.IsZero())
+
+	img := "testcontainers/image-management:" + core.SessionID()
+	err = provider.TagImage(ctx, nginxAlpineImage, img)
+	require.NoError(t, err)
+
+	tagged, err := provider.InspectImage(ctx, img)
+	require.NoError(t, err)
+	require.Equal(t, details.ID, tagged.ID)
+
+	err = provider.RemoveImage(ctx, img)
+	require.NoError(t, err)
+
+	_, err = provider.InspectImage(ctx, img)
+	require.True(t, errdefs.IsNotFound(err), err)
+
+	// removing the tag keeps the image
+	_, err = provider.InspectImage(ctx, nginxAlpineImage)
+	require.NoError(t, err)
+}
+
+// imageRemoveMockCli is a mock implementation of client.APIClient, recording the options of the image removals.
+type imageRemoveMockCli struct {
+	client.APIClient
+
+	options []image.RemoveOptions
+}
+
+func (m *imageRemoveMockCli) ImageRemove(_ context.Context, _ string, options image.RemoveOptions) ([]image.DeleteResponse, error) {
+	m.options = append(m.options, options)
+	return nil, nil
+}
+
+func TestRemoveImageForce(t *testing.T) {
+	ctx := context.Background()
+
+	cli := &imageRemoveMockCli{}
+	provider := &DockerProvider{client: cli}
+
+	require.NoError(t, provider.RemoveImage(ctx, "redis:7.2"))
+	require.NoError(t, provider.RemoveImage(ctx, "redis:7.2", RemoveImageForce()))
+
+	require.Equal(t, []image.RemoveOptions{
+		{PruneChildren: true},
+		{Force: true, PruneChildren: true},
+	}, cli.options)
+}
+
+func TestPruneImages(t *testing.T) {
+	ctx := context.Background()
+
+	provider, err := NewDockerProvider()
+	require.NoError(t, err)
+	defer provider.Close()
+
+	tag, err := provider.BuildImage(ctx, &ContainerRequest{
+		FromDockerfile: FromDockerfile{
+			Context:    "testdata",
+			Dockerfile: "echo.Dockerfile",
+			Repo:       "testcontainers/prune-images",
+			Tag:        core.SessionID(),
+			KeepImage:  true,
+		},
+	})
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_ = provider.RemoveImage(ctx, tag)
+	})
+
+	details, err := provider.InspectImage(ctx, tag)
+	require.NoError(t, err)
+	require.Equal(t, "true", details.Labels[core.LabelBuilt])
+	// kept images are not labeled with the session, so they are not removed by Ryuk
+	require.NotContains(t, details.Labels, core.LabelSessionID)
+
+	// too recent
+	deleted, err := provider.PruneImages(ctx, time.Hour)
+	require.NoError(t, err)
+	require.NotContains(t, deleted, details.ID)
+
+	deleted, err = provider.PruneImages(ctx, 0)
+	require.NoError(t, err)
+	require.Contains(t, deleted, details.ID)
+
+	_, err = provider.InspectImage(ctx, tag)
+	require.True(t, errdefs.IsNotFound(err), err)
+}
@@ -81,6 +81,53 @@ type Config struct {
 	// Environment variable: TESTCONTAINERS_RYUK_VERBOSE
 	RyukVerbose bool `properties:"ryuk.verbose,default=false"`
 
+	// SweeperEnabled is a flag to enable the in-process sweeper when the Garbage Collector is disabled.
+	// The sweeper removes the resources created by the test process when it's interrupted, or when
+	// testcontainers.SweepResources is called, and the resources of the test sessions whose owning
+	// process no longer exists.
+	//
+	// Environment variable: TESTCONTAINERS_SWEEPER_ENABLED
+	SweeperEnabled bool `properties:"sweeper.enabled,default=false"`
+
+	// ReuseEnabled is a flag to enable the reuse of the containers requested with the Reuse option,
+	// including the ones without a name, which are identified by a hash of their request. It's meant to be
+	// enabled in the properties file of the developer machines only, so the containers are never reused in CI,
+	// including the named ones.
+	//
+	// Environment variable: TESTCONTAINERS_REUSE_ENABLED
+	ReuseEnabled bool `properties:"reuse.enabled,default=false"`
+
+	// PullPolicy is the default pull policy of the container images: "always", "missing", "never",
+	// or "max-age=<duration>", e.g. "max-age=24h". Defaults to "missing".
+	//
+	// Environment variable: TESTCONTAINERS_PULL_POLICY
+	PullPolicy string `properties:"pull.policy,default="`
+
+	// RegistryMirrors is a comma-separated list of <pattern>=<mirror> rules rewriting the images
+	// of the registries matching the pattern to the mirror, e.g. "quay.io=mirror.example.com/quay".
+	// The patterns can contain * wildcards, e.g. "*.gcr.io", and the first matching rule is used.
+	//
+	// Environment variable: TESTCONTAINERS_REGISTRY_MIRRORS
+	RegistryMirrors string `properties:"registry.mirrors,default="`
+
+	// RegistryMirrorsFile is the path of a file with one <pattern>=<mirror> registry mirror rule per line,
+	// which are evaluated after the RegistryMirrors rules. Lines starting with # are ignored.
+	//
+	// Environment variable: TESTCONTAINERS_REGISTRY_MIRRORS_FILE
+	RegistryMirrorsFile string `properties:"registry.mirrors.file,default="`
+
+	// ImageArchivesDir is the path of a directory of image archives, as created by docker save,
+	// which are consulted before pulling an image: an image found in an archive is loaded from it.
+	//
+	// Environment variable: TESTCONTAINERS_IMAGE_ARCHIVES_DIR
+	ImageArchivesDir string `properties:"image.archives.dir,default="`
+
+	// Offline is a flag to forbid pulling images from the registries. Pulling an image which is not
+	// found in the image archives directory fails with an error.
+	//
+	// Environment variable: TESTCONTAINERS_OFFLINE
+	Offline bool `properties:"offline,default=false"`
+
 	// TestcontainersHost is the address of the Testcontainers host.
 	//
 	// Environment variable: TESTCONTAINERS_DOCKER_SOCKET_OVERRIDE
@@ -131,6 +178,16 @@ func read() Config {
 			config.RyukVerbose = ryukVerboseEnv == "true"
 		}
 
+		sweeperEnabledEnv := os.Getenv("TESTCONTAINERS_SWEEPER_ENABLED")
+		if parseBool(sweeperEnabledEnv) {
+			config.SweeperEnabled = sweeperEnabledEnv == "true"
+		}
+
+		reuseEnabledEnv := os.Getenv("TESTCONTAINERS_REUSE_ENABLED")
+		if parseBool(reuseEnabledEnv) {
+			config.ReuseEnabled = reuseEnabledEnv == "true"
+		}
+
 		ryukReconnectionTimeoutEnv := os.Getenv("TESTCONTAINERS_RYUK_RECONNECTION_TIMEOUT")
 		if timeout, err := time.ParseDuration(ryukReconnectionTimeoutEnv); err == nil {
 			config.RyukReconnectionTimeout = timeout
@@ -141,6 +198,31 @@ func read() Config {
 			config.RyukConnectionTimeout = timeout
 		}
 
+		pullPolicy := os.Getenv("TESTCONTAINERS_PULL_POLICY")
+		if pullPolicy != "" {
+			config.PullPolicy = pullPolicy
+		}
+
+		registryMirrors := os.Getenv("TESTCONTAINERS_REGISTRY_MIRRORS")
+		if registryMirrors != "" {
+			config.RegistryMirrors = registryMirrors
+		}
+
+		registryMirrorsFile := os.Getenv("TESTCONTAINERS_REGISTRY_MIRRORS_FILE")
+		if registryMirrorsFile != "" {
+			config.RegistryMirrorsFile = registryMirrorsFile
+		}
+
+		imageArchivesDir := os.Getenv("TESTCONTAINERS_IMAGE_ARCHIVES_DIR")
+		if imageArchivesDir != "" {
+			config.ImageArchivesDir = imageArchivesDir
+		}
+
+		offlineEnv := os.Getenv("TESTCONTAINERS_OFFLINE")
+		if parseBool(offlineEnv) {
+			config.Offline = offlineEnv == "true"
+		}
+
 		return config
 	}
 
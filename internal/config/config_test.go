@@ -25,6 +25,13 @@ func resetTestEnv(t *testing.T) {
 	t.Setenv("TESTCONTAINERS_RYUK_VERBOSE", "")
 	t.Setenv("TESTCONTAINERS_RYUK_RECONNECTION_TIMEOUT", "")
 	t.Setenv("TESTCONTAINERS_RYUK_CONNECTION_TIMEOUT", "")
+	t.Setenv("TESTCONTAINERS_PULL_POLICY", "")
+	t.Setenv("TESTCONTAINERS_REGISTRY_MIRRORS", "")
+	t.Setenv("TESTCONTAINERS_REGISTRY_MIRRORS_FILE", "")
+	t.Setenv("TESTCONTAINERS_IMAGE_ARCHIVES_DIR", "")
+	t.Setenv("TESTCONTAINERS_OFFLINE", "")
+	t.Setenv("TESTCONTAINERS_SWEEPER_ENABLED", "")
+	t.Setenv("TESTCONTAINERS_REUSE_ENABLED", "")
 }
 
 func TestReadConfig(t *testing.T) {
@@ -515,6 +522,126 @@ func TestReadTCConfig(t *testing.T) {
 					RyukReconnectionTimeout: defaultRyukReconnectionTimeout,
 				},
 			},
+			{
+				"With pull policy set as a property",
+				`pull.policy=max-age=24h`,
+				map[string]string{},
+				Config{
+					PullPolicy:              "max-age=24h",
+					RyukConnectionTimeout:   defaultRyukConnectionTimeout,
+					RyukReconnectionTimeout: defaultRyukReconnectionTimeout,
+				},
+			},
+			{
+				"With pull policy set as env var and properties: Env var wins",
+				`pull.policy=max-age=24h`,
+				map[string]string{
+					"TESTCONTAINERS_PULL_POLICY": "never",
+				},
+				Config{
+					PullPolicy:              "never",
+					RyukConnectionTimeout:   defaultRyukConnectionTimeout,
+					RyukReconnectionTimeout: defaultRyukReconnectionTimeout,
+				},
+			},
+			{
+				"With registry mirrors set as properties",
+				`registry.mirrors=quay.io=mirror.example.com/quay,*.gcr.io=mirror.example.com/gcr
+				registry.mirrors.file=/etc/testcontainers/mirrors`,
+				map[string]string{},
+				Config{
+					RegistryMirrors:         "quay.io=mirror.example.com/quay,*.gcr.io=mirror.example.com/gcr",
+					RegistryMirrorsFile:     "/etc/testcontainers/mirrors",
+					RyukConnectionTimeout:   defaultRyukConnectionTimeout,
+					RyukReconnectionTimeout: defaultRyukReconnectionTimeout,
+				},
+			},
+			{
+				"With registry mirrors set as env var and properties: Env var wins",
+				`registry.mirrors=quay.io=mirror.example.com/quay
+				registry.mirrors.file=/etc/testcontainers/mirrors`,
+				map[string]string{
+					"TESTCONTAINERS_REGISTRY_MIRRORS":      "*=mirror.example.com",
+					"TESTCONTAINERS_REGISTRY_MIRRORS_FILE": "/tmp/mirrors",
+				},
+				Config{
+					RegistryMirrors:         "*=mirror.example.com",
+					RegistryMirrorsFile:     "/tmp/mirrors",
+					RyukConnectionTimeout:   defaultRyukConnectionTimeout,
+					RyukReconnectionTimeout: defaultRyukReconnectionTimeout,
+				},
+			},
+			{
+				"With sweeper enabled as a property",
+				`ryuk.disabled=true
+				sweeper.enabled=true`,
+				map[string]string{},
+				Config{
+					RyukDisabled:            true,
+					SweeperEnabled:          true,
+					RyukConnectionTimeout:   defaultRyukConnectionTimeout,
+					RyukReconnectionTimeout: defaultRyukReconnectionTimeout,
+				},
+			},
+			{
+				"With sweeper enabled as env var and disabled as a property: Env var wins",
+				`sweeper.enabled=false`,
+				map[string]string{
+					"TESTCONTAINERS_SWEEPER_ENABLED": "true",
+				},
+				Config{
+					SweeperEnabled:          true,
+					RyukConnectionTimeout:   defaultRyukConnectionTimeout,
+					RyukReconnectionTimeout: defaultRyukReconnectionTimeout,
+				},
+			},
+			{
+				"With reuse enabled as a property",
+				`reuse.enabled=true`,
+				map[string]string{},
+				Config{
+					ReuseEnabled:            true,
+					RyukConnectionTimeout:   defaultRyukConnectionTimeout,
+					RyukReconnectionTimeout: defaultRyukReconnectionTimeout,
+				},
+			},
+			{
+				"With reuse enabled as a property and disabled as env var: Env var wins",
+				`reuse.enabled=true`,
+				map[string]string{
+					"TESTCONTAINERS_REUSE_ENABLED": "false",
+				},
+				Config{
+					RyukConnectionTimeout:   defaultRyukConnectionTimeout,
+					RyukReconnectionTimeout: defaultRyukReconnectionTimeout,
+				},
+			},
+			{
+				"With image archives and offline mode set as properties",
+				`image.archives.dir=/var/cache/images
+				offline=true`,
+				map[string]string{},
+				Config{
+					ImageArchivesDir:        "/var/cache/images",
+					Offline:                 true,
+					RyukConnectionTimeout:   defaultRyukConnectionTimeout,
+					RyukReconnectionTimeout: defaultRyukReconnectionTimeout,
+				},
+			},
+			{
+				"With image archives and offline mode set as env var and properties: Env var wins",
+				`image.archives.dir=/var/cache/images
+				offline=true`,
+				map[string]string{
+					"TESTCONTAINERS_IMAGE_ARCHIVES_DIR": "/tmp/images",
+					"TESTCONTAINERS_OFFLINE":            "false",
+				},
+				Config{
+					ImageArchivesDir:        "/tmp/images",
+					RyukConnectionTimeout:   defaultRyukConnectionTimeout,
+					RyukReconnectionTimeout: defaultRyukReconnectionTimeout,
+				},
+			},
 		}
 		for _, tt := range tests {
 			t.Run(fmt.Sprintf(tt.name), func(t *testing.T) {
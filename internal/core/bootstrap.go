@@ -2,8 +2,11 @@ package core
 
 import (
 	"crypto/sha256"
+	"errors"
 	"fmt"
 	"os"
+	"strconv"
+	"strings"
 
 	"github.com/google/uuid"
 	"github.com/shirou/gopsutil/v3/process"
@@ -41,6 +44,10 @@ var projectPath string
 // we need a way to identify the current test process, in the form of an UUID
 var processID string
 
+// sessionOwner identifies the process owning the test session, that is, the parent process,
+// in the form <hostname>:<pid>. It's empty if the session ID is not derived from the parent process.
+var sessionOwner string
+
 const sessionIDPlaceholder = "testcontainers-go:%d:%d"
 
 func init() {
@@ -82,14 +89,17 @@ func init() {
 		break
 	}
 
-	hasher := sha256.New()
-	_, err = hasher.Write([]byte(fmt.Sprintf(sessionIDPlaceholder, parentPid, createTime)))
-	if err != nil {
-		sessionID = uuid.New().String()
-		return
+	sessionID = hashSessionID(parentPid, createTime)
+
+	// the session can only be verified later if the parent process was found
+	if hostname, err := os.Hostname(); err == nil && createTime != 0 {
+		sessionOwner = fmt.Sprintf("%s:%d", hostname, parentPid)
 	}
+}
 
-	sessionID = fmt.Sprintf("%x", hasher.Sum(nil))
+// hashSessionID returns the session ID of the session owned by the process with the given pid and creation time.
+func hashSessionID(pid int, createTime int64) string {
+	return fmt.Sprintf("%x", sha256.Sum256([]byte(fmt.Sprintf(sessionIDPlaceholder, pid, createTime))))
 }
 
 func ProcessID() string {
@@ -103,3 +113,42 @@ func ProjectPath() string {
 func SessionID() string {
 	return sessionID
 }
+
+// SessionOwner returns the process owning the current session, in the form <hostname>:<pid>,
+// or an empty string if it's unknown.
+func SessionOwner() string {
+	return sessionOwner
+}
+
+// IsOrphanSession returns true if the process owning the session, in the form returned by SessionOwner,
+// is known to be gone, that is, it runs on this host and no process with that pid and the creation time
+// of the session exists anymore. It returns false if the owner is unknown or runs on another host.
+func IsOrphanSession(sessionID string, owner string) bool {
+	hostname, pidStr, ok := strings.Cut(owner, ":")
+	if !ok {
+		return false
+	}
+
+	if localHostname, err := os.Hostname(); err != nil || hostname != localHostname {
+		return false
+	}
+
+	pid, err := strconv.Atoi(pidStr)
+	if err != nil {
+		return false
+	}
+
+	p, err := process.NewProcess(int32(pid))
+	if err != nil {
+		// the process does not exist anymore
+		return errors.Is(err, process.ErrorProcessNotRunning)
+	}
+
+	createTime, err := p.CreateTime()
+	if err != nil {
+		return false
+	}
+
+	// a different session ID means the pid was reused by another process
+	return hashSessionID(pid, createTime) != sessionID
+}
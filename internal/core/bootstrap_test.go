@@ -0,0 +1,53 @@
+package core
+
+import (
+	"os"
+	"strconv"
+	"testing"
+
+	"github.com/shirou/gopsutil/v3/process"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsOrphanSession(t *testing.T) {
+	hostname, err := os.Hostname()
+	require.NoError(t, err)
+
+	p, err := process.NewProcess(int32(os.Getpid()))
+	require.NoError(t, err)
+
+	createTime, err := p.CreateTime()
+	require.NoError(t, err)
+
+	// a session owned by the test process itself
+	sessionID := hashSessionID(os.Getpid(), createTime)
+	owner := hostname + ":" + strconv.Itoa(os.Getpid())
+
+	t.Run("alive", func(t *testing.T) {
+		require.False(t, IsOrphanSession(sessionID, owner))
+	})
+
+	t.Run("current-session", func(t *testing.T) {
+		if SessionOwner() == "" {
+			t.Skip("the owner of the current session is unknown")
+		}
+
+		require.False(t, IsOrphanSession(SessionID(), SessionOwner()))
+	})
+
+	t.Run("reused-pid", func(t *testing.T) {
+		require.True(t, IsOrphanSession(hashSessionID(os.Getpid(), createTime-1000), owner))
+	})
+
+	t.Run("gone", func(t *testing.T) {
+		// above the maximum pid of Linux
+		require.True(t, IsOrphanSession(sessionID, hostname+":4194305"))
+	})
+
+	t.Run("unknown", func(t *testing.T) {
+		require.False(t, IsOrphanSession(sessionID, "another-host:"+strconv.Itoa(os.Getpid())))
+		require.False(t, IsOrphanSession(sessionID, hostname))
+		require.False(t, IsOrphanSession(sessionID, hostname+":pid"))
+		require.False(t, IsOrphanSession(sessionID, ""))
+	})
+}
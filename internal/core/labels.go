@@ -6,18 +6,38 @@ import (
 
 const (
 	LabelBase      = "org.testcontainers"
+	LabelBuilt     = LabelBase + ".built"
 	LabelLang      = LabelBase + ".lang"
 	LabelReaper    = LabelBase + ".reaper"
 	LabelRyuk      = LabelBase + ".ryuk"
 	LabelSessionID = LabelBase + ".sessionId"
 	LabelVersion   = LabelBase + ".version"
+
+	// LabelSessionOwner identifies the process owning the session, as returned by SessionOwner.
+	LabelSessionOwner = LabelBase + ".sessionOwner"
+
+	// LabelProjectPath is the working directory of the process owning the session, as returned by ProjectPath.
+	LabelProjectPath = LabelBase + ".projectPath"
+
+	// LabelReuseHash is the hash of the request of a reusable container, which is reused only by the same request.
+	LabelReuseHash = LabelBase + ".reuseHash"
 )
 
 func DefaultLabels(sessionID string) map[string]string {
-	return map[string]string{
+	labels := map[string]string{
 		LabelBase:      "true",
 		LabelLang:      "go",
 		LabelSessionID: sessionID,
 		LabelVersion:   internal.Version,
 	}
+
+	if sessionID == SessionID() && SessionOwner() != "" {
+		labels[LabelSessionOwner] = SessionOwner()
+	}
+
+	if sessionID == SessionID() && ProjectPath() != "" {
+		labels[LabelProjectPath] = ProjectPath()
+	}
+
+	return labels
 }
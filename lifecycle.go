@@ -30,11 +30,25 @@ type ContainerRequestHook func(ctx context.Context, req ContainerRequest) error
 // - Readied
 // - Stopping
 // - Stopped
+// - Pausing
+// - Paused
+// - Unpausing
+// - Unpaused
+// - Killing
+// - Killed
 // - Terminating
 // - Terminated
 // For that, it will receive a Container, modify it and return an error if needed.
 type ContainerHook func(ctx context.Context, container Container) error
 
+// ContainerExitHook is a hook that will be called when a container exits unexpectedly
+// once it's ready, that is, without being stopped, killed or terminated through its API,
+// using the different lifecycle hooks that are available:
+// - Died
+// - OOMKilled
+// For that, it will receive a Container and the details of its exit.
+type ContainerExitHook func(ctx context.Context, container Container, exit ContainerExit) error
+
 // ContainerLifecycleHooks is a struct that contains all the hooks that can be used
 // to modify the container lifecycle. All the container lifecycle hooks except the PreCreates hooks
 // will be passed to the container once it's created
@@ -46,16 +60,20 @@ type ContainerLifecycleHooks struct {
 	PostReadies    []ContainerHook
 	PreStops       []ContainerHook
 	PostStops      []ContainerHook
+	PrePauses      []ContainerHook
+	PostPauses     []ContainerHook
+	PreUnpauses    []ContainerHook
+	PostUnpauses   []ContainerHook
+	PreKills       []ContainerHook
+	PostKills      []ContainerHook
 	PreTerminates  []ContainerHook
 	PostTerminates []ContainerHook
+	PostDies       []ContainerExitHook // called for every unexpected exit, including the OOM kills
+	PostOOMKills   []ContainerExitHook // called before the PostDies hooks if the container was killed for running out of memory
 }
 
 // DefaultLoggingHook is a hook that will log the container lifecycle events
 var DefaultLoggingHook = func(logger Logging) ContainerLifecycleHooks {
-	shortContainerID := func(c Container) string {
-		return c.GetContainerID()[:12]
-	}
-
 	return ContainerLifecycleHooks{
 		PreCreates: []ContainerRequestHook{
 			func(ctx context.Context, req ContainerRequest) error {
@@ -65,55 +83,112 @@ var DefaultLoggingHook = func(logger Logging) ContainerLifecycleHooks {
 		},
 		PostCreates: []ContainerHook{
 			func(ctx context.Context, c Container) error {
-				logger.Printf("✅ Container created: %s", shortContainerID(c))
+				logger.Printf("✅ Container created: %s", shortContainerID(c.GetContainerID()))
 				return nil
 			},
 		},
 		PreStarts: []ContainerHook{
 			func(ctx context.Context, c Container) error {
-				logger.Printf("🐳 Starting container: %s", shortContainerID(c))
+				logger.Printf("🐳 Starting container: %s", shortContainerID(c.GetContainerID()))
 				return nil
 			},
 		},
 		PostStarts: []ContainerHook{
 			func(ctx context.Context, c Container) error {
-				logger.Printf("✅ Container started: %s", shortContainerID(c))
+				logger.Printf("✅ Container started: %s", shortContainerID(c.GetContainerID()))
 				return nil
 			},
 		},
 		PostReadies: []ContainerHook{
 			func(ctx context.Context, c Container) error {
-				logger.Printf("🔔 Container is ready: %s", shortContainerID(c))
+				logger.Printf("🔔 Container is ready: %s", shortContainerID(c.GetContainerID()))
 				return nil
 			},
 		},
 		PreStops: []ContainerHook{
 			func(ctx context.Context, c Container) error {
-				logger.Printf("🐳 Stopping container: %s", shortContainerID(c))
+				logger.Printf("🐳 Stopping container: %s", shortContainerID(c.GetContainerID()))
 				return nil
 			},
 		},
 		PostStops: []ContainerHook{
 			func(ctx context.Context, c Container) error {
-				logger.Printf("✅ Container stopped: %s", shortContainerID(c))
+				logger.Printf("✅ Container stopped: %s", shortContainerID(c.GetContainerID()))
+				return nil
+			},
+		},
+		PrePauses: []ContainerHook{
+			func(ctx context.Context, c Container) error {
+				logger.Printf("🐳 Pausing container: %s", shortContainerID(c.GetContainerID()))
+				return nil
+			},
+		},
+		PostPauses: []ContainerHook{
+			func(ctx context.Context, c Container) error {
+				logger.Printf("⏸️ Container paused: %s", shortContainerID(c.GetContainerID()))
+				return nil
+			},
+		},
+		PreUnpauses: []ContainerHook{
+			func(ctx context.Context, c Container) error {
+				logger.Printf("🐳 Unpausing container: %s", shortContainerID(c.GetContainerID()))
+				return nil
+			},
+		},
+		PostUnpauses: []ContainerHook{
+			func(ctx context.Context, c Container) error {
+				logger.Printf("▶️ Container unpaused: %s", shortContainerID(c.GetContainerID()))
+				return nil
+			},
+		},
+		PreKills: []ContainerHook{
+			func(ctx context.Context, c Container) error {
+				logger.Printf("🐳 Killing container: %s", shortContainerID(c.GetContainerID()))
+				return nil
+			},
+		},
+		PostKills: []ContainerHook{
+			func(ctx context.Context, c Container) error {
+				logger.Printf("💀 Container killed: %s", shortContainerID(c.GetContainerID()))
 				return nil
 			},
 		},
 		PreTerminates: []ContainerHook{
 			func(ctx context.Context, c Container) error {
-				logger.Printf("🐳 Terminating container: %s", shortContainerID(c))
+				logger.Printf("🐳 Terminating container: %s", shortContainerID(c.GetContainerID()))
 				return nil
 			},
 		},
 		PostTerminates: []ContainerHook{
 			func(ctx context.Context, c Container) error {
-				logger.Printf("🚫 Container terminated: %s", shortContainerID(c))
+				logger.Printf("🚫 Container terminated: %s", shortContainerID(c.GetContainerID()))
+				return nil
+			},
+		},
+		PostDies: []ContainerExitHook{
+			func(ctx context.Context, c Container, exit ContainerExit) error {
+				logger.Printf("💥 Container died: %s, exit code: %d, last logs:\n%s", shortContainerID(c.GetContainerID()), exit.ExitCode, exit.Logs)
+				return nil
+			},
+		},
+		PostOOMKills: []ContainerExitHook{
+			func(ctx context.Context, c Container, exit ContainerExit) error {
+				logger.Printf("💥 Container killed for running out of memory: %s", shortContainerID(c.GetContainerID()))
 				return nil
 			},
 		},
 	}
 }
 
+// shortContainerID returns the first 12 characters of a container ID, as Docker does.
+func shortContainerID(containerID string) string {
+	if len(containerID) > 12 {
+		return containerID[:12]
+	}
+
+	return containerID
+}
+
 // defaultPreCreateHook is a hook that will apply the default configuration to the container
 var defaultPreCreateHook = func(p *DockerProvider, dockerInput *container.Config, hostConfig *container.HostConfig, networkingConfig *network.NetworkingConfig) ContainerLifecycleHooks {
 	return ContainerLifecycleHooks{
@@ -194,6 +269,115 @@ var defaultLogConsumersHook = func(cfg *LogConsumerConfig) ContainerLifecycleHoo
 				return dockerContainer.stopLogProduction()
 			},
 		},
+		PostKills: []ContainerHook{
+			// Stop the log production if the container is no longer running,
+			// as the log stream has been closed by the container runtime.
+			// See combineContainerHooks for the order of execution.
+			func(ctx context.Context, c Container) error {
+				if cfg == nil || len(cfg.Consumers) == 0 || c.IsRunning() {
+					return nil
+				}
+
+				dockerContainer := c.(*DockerContainer)
+
+				return dockerContainer.stopLogProduction()
+			},
+		},
+	}
+}
+
+// defaultStatsConsumersHook is a hook that will start the stats consumers after the container is started,
+// and that will notify the stats reporters when the container is terminated
+var defaultStatsConsumersHook = func(cfg *StatsConsumerConfig) ContainerLifecycleHooks {
+	return ContainerLifecycleHooks{
+		PostStarts: []ContainerHook{
+			// Produce stats sending them to the stats consumers.
+			// See combineContainerHooks for the order of execution.
+			func(ctx context.Context, c Container) error {
+				if cfg == nil || len(cfg.Consumers) == 0 {
+					return nil
+				}
+
+				dockerContainer := c.(*DockerContainer)
+				dockerContainer.statsConsumers = cfg.Consumers
+
+				return dockerContainer.startStatsProduction(ctx)
+			},
+		},
+		PostStops: []ContainerHook{
+			// Stop the stats production.
+			// See combineContainerHooks for the order of execution.
+			func(ctx context.Context, c Container) error {
+				if cfg == nil || len(cfg.Consumers) == 0 {
+					return nil
+				}
+
+				c.(*DockerContainer).stopStatsProduction()
+
+				return nil
+			},
+		},
+		PostKills: []ContainerHook{
+			// Stop the stats production if the container is no longer running.
+			// See combineContainerHooks for the order of execution.
+			func(ctx context.Context, c Container) error {
+				if cfg == nil || len(cfg.Consumers) == 0 || c.IsRunning() {
+					return nil
+				}
+
+				c.(*DockerContainer).stopStatsProduction()
+
+				return nil
+			},
+		},
+		PreTerminates: []ContainerHook{
+			// Notify the stats reporters, once all the stats have been produced.
+			// See combineContainerHooks for the order of execution.
+			func(ctx context.Context, c Container) error {
+				if cfg == nil || len(cfg.Consumers) == 0 {
+					return nil
+				}
+
+				c.(*DockerContainer).stopStatsProduction()
+
+				for _, r := range statsReporters(cfg.Consumers) {
+					r.Report(c.GetContainerID())
+				}
+
+				return nil
+			},
+		},
+	}
+}
+
+// defaultExitWatcherHook is a hook that will watch the container for unexpected exits once it's ready,
+// calling the PostOOMKills and PostDies hooks. The watcher is stopped before the container is
+// stopped, killed or terminated on purpose.
+var defaultExitWatcherHook = func() ContainerLifecycleHooks {
+	stopWatcher := func(ctx context.Context, c Container) error {
+		c.(*DockerContainer).stopExitWatcher(ctx)
+		return nil
+	}
+
+	return ContainerLifecycleHooks{
+		PostReadies: []ContainerHook{
+			func(ctx context.Context, c Container) error {
+				return c.(*DockerContainer).startExitWatcher(ctx)
+			},
+		},
+		PreStops:      []ContainerHook{stopWatcher},
+		PreKills:      []ContainerHook{stopWatcher},
+		PreTerminates: []ContainerHook{stopWatcher},
+		PostKills: []ContainerHook{
+			// Keep watching the container if it survived the signal.
+			func(ctx context.Context, c Container) error {
+				if !c.IsRunning() {
+					return nil
+				}
+
+				return c.(*DockerContainer).startExitWatcher(ctx)
+			},
+		},
 	}
 }
 
@@ -277,7 +461,7 @@ var defaultReadinessHook = func() ContainerLifecycleHooks {
 					}
 				}
 
-				dockerContainer.isRunning = true
+				dockerContainer.isRunning.Store(true)
 
 				return nil
 			},
@@ -355,6 +539,48 @@ func (c *DockerContainer) stoppedHook(ctx context.Context) error {
 	})
 }
 
+// pausingHook is a hook that will be called before a container is paused.
+func (c *DockerContainer) pausingHook(ctx context.Context) error {
+	return c.applyLifecycleHooks(ctx, false, func(lifecycleHooks ContainerLifecycleHooks) []ContainerHook {
+		return lifecycleHooks.PrePauses
+	})
+}
+
+// pausedHook is a hook that will be called after a container is paused.
+func (c *DockerContainer) pausedHook(ctx context.Context) error {
+	return c.applyLifecycleHooks(ctx, false, func(lifecycleHooks ContainerLifecycleHooks) []ContainerHook {
+		return lifecycleHooks.PostPauses
+	})
+}
+
+// unpausingHook is a hook that will be called before a container is unpaused.
+func (c *DockerContainer) unpausingHook(ctx context.Context) error {
+	return c.applyLifecycleHooks(ctx, false, func(lifecycleHooks ContainerLifecycleHooks) []ContainerHook {
+		return lifecycleHooks.PreUnpauses
+	})
+}
+
+// unpausedHook is a hook that will be called after a container is unpaused.
+func (c *DockerContainer) unpausedHook(ctx context.Context) error {
+	return c.applyLifecycleHooks(ctx, false, func(lifecycleHooks ContainerLifecycleHooks) []ContainerHook {
+		return lifecycleHooks.PostUnpauses
+	})
+}
+
+// killingHook is a hook that will be called before a container is killed.
+func (c *DockerContainer) killingHook(ctx context.Context) error {
+	return c.applyLifecycleHooks(ctx, false, func(lifecycleHooks ContainerLifecycleHooks) []ContainerHook {
+		return lifecycleHooks.PreKills
+	})
+}
+
+// killedHook is a hook that will be called after a container is killed.
+func (c *DockerContainer) killedHook(ctx context.Context) error {
+	return c.applyLifecycleHooks(ctx, false, func(lifecycleHooks ContainerLifecycleHooks) []ContainerHook {
+		return lifecycleHooks.PostKills
+	})
+}
+
 // terminatingHook is a hook that will be called before a container is terminated.
 func (c *DockerContainer) terminatingHook(ctx context.Context) error {
 	return c.applyLifecycleHooks(ctx, false, func(lifecycleHooks ContainerLifecycleHooks) []ContainerHook {
@@ -369,6 +595,30 @@ func (c *DockerContainer) terminatedHook(ctx context.Context) error {
 	})
 }
 
+// oomKilledHook is a hook that will be called after a container is killed for running out of memory.
+func (c *DockerContainer) oomKilledHook(ctx context.Context, exit ContainerExit) error {
+	return c.applyExitHooks(ctx, exit, func(lifecycleHooks ContainerLifecycleHooks) []ContainerExitHook {
+		return lifecycleHooks.PostOOMKills
+	})
+}
+
+// diedHook is a hook that will be called after a container exits unexpectedly.
+func (c *DockerContainer) diedHook(ctx context.Context, exit ContainerExit) error {
+	return c.applyExitHooks(ctx, exit, func(lifecycleHooks ContainerLifecycleHooks) []ContainerExitHook {
+		return lifecycleHooks.PostDies
+	})
+}
+
+// applyExitHooks applies the exit hooks of all the lifecycle hooks.
+func (c *DockerContainer) applyExitHooks(ctx context.Context, exit ContainerExit, hooks func(lifecycleHooks ContainerLifecycleHooks) []ContainerExitHook) error {
+	errs := make([]error, len(c.lifecycleHooks))
+	for i, lifecycleHooks := range c.lifecycleHooks {
+		errs[i] = containerExitHookFn(ctx, hooks(lifecycleHooks))(c, exit)
+	}
+
+	return errors.Join(errs...)
+}
+
 // applyLifecycleHooks applies all lifecycle hooks reporting the container logs on error if logError is true.
 func (c *DockerContainer) applyLifecycleHooks(ctx context.Context, logError bool, hooks func(lifecycleHooks ContainerLifecycleHooks) []ContainerHook) error {
 	errs := make([]error, len(c.lifecycleHooks))
@@ -421,6 +671,18 @@ func containerHookFn(ctx context.Context, containerHook []ContainerHook) func(co
 	}
 }
 
+// containerExitHookFn is the counterpart of containerHookFn for the container exit hooks.
+func containerExitHookFn(ctx context.Context, containerExitHook []ContainerExitHook) func(container Container, exit ContainerExit) error {
+	return func(ctr Container, exit ContainerExit) error {
+		errs := make([]error, len(containerExitHook))
+		for i, hook := range containerExitHook {
+			errs[i] = hook(ctx, ctr, exit)
+		}
+
+		return errors.Join(errs...)
+	}
+}
+
 // Created is a hook that will be called after a container is created
 func (c ContainerLifecycleHooks) Created(ctx context.Context) func(container Container) error {
 	return containerHookFn(ctx, c.PostCreates)
@@ -451,6 +713,36 @@ func (c ContainerLifecycleHooks) Stopped(ctx context.Context) func(container Con
 	return containerHookFn(ctx, c.PostStops)
 }
 
+// Pausing is a hook that will be called before a container is paused
+func (c ContainerLifecycleHooks) Pausing(ctx context.Context) func(container Container) error {
+	return containerHookFn(ctx, c.PrePauses)
+}
+
+// Paused is a hook that will be called after a container is paused
+func (c ContainerLifecycleHooks) Paused(ctx context.Context) func(container Container) error {
+	return containerHookFn(ctx, c.PostPauses)
+}
+
+// Unpausing is a hook that will be called before a container is unpaused
+func (c ContainerLifecycleHooks) Unpausing(ctx context.Context) func(container Container) error {
+	return containerHookFn(ctx, c.PreUnpauses)
+}
+
+// Unpaused is a hook that will be called after a container is unpaused
+func (c ContainerLifecycleHooks) Unpaused(ctx context.Context) func(container Container) error {
+	return containerHookFn(ctx, c.PostUnpauses)
+}
+
+// Killing is a hook that will be called before a container is killed
+func (c ContainerLifecycleHooks) Killing(ctx context.Context) func(container Container) error {
+	return containerHookFn(ctx, c.PreKills)
+}
+
+// Killed is a hook that will be called after a container is killed
+func (c ContainerLifecycleHooks) Killed(ctx context.Context) func(container Container) error {
+	return containerHookFn(ctx, c.PostKills)
+}
+
 // Terminating is a hook that will be called before a container is terminated
 func (c ContainerLifecycleHooks) Terminating(ctx context.Context) func(container Container) error {
 	return containerHookFn(ctx, c.PreTerminates)
@@ -461,6 +753,16 @@ func (c ContainerLifecycleHooks) Terminated(ctx context.Context) func(container
 	return containerHookFn(ctx, c.PostTerminates)
 }
 
+// Died is a hook that will be called after a container exits unexpectedly
+func (c ContainerLifecycleHooks) Died(ctx context.Context) func(container Container, exit ContainerExit) error {
+	return containerExitHookFn(ctx, c.PostDies)
+}
+
+// OOMKilled is a hook that will be called after a container is killed for running out of memory
+func (c ContainerLifecycleHooks) OOMKilled(ctx context.Context) func(container Container, exit ContainerExit) error {
+	return containerExitHookFn(ctx, c.PostOOMKills)
+}
+
 func (p *DockerProvider) preCreateContainerHook(ctx context.Context, req ContainerRequest, dockerInput *container.Config, hostConfig *container.HostConfig, networkingConfig *network.NetworkingConfig) error {
 	// prepare mounts
 	hostConfig.Mounts = mapToDockerMounts(req.Mounts)
@@ -549,13 +851,24 @@ func combineContainerHooks(defaultHooks, userDefinedHooks []ContainerLifecycleHo
 	postReadies := []ContainerHook{}
 	preStops := []ContainerHook{}
 	postStops := []ContainerHook{}
+	prePauses := []ContainerHook{}
+	postPauses := []ContainerHook{}
+	preUnpauses := []ContainerHook{}
+	postUnpauses := []ContainerHook{}
+	preKills := []ContainerHook{}
+	postKills := []ContainerHook{}
 	preTerminates := []ContainerHook{}
 	postTerminates := []ContainerHook{}
+	postDies := []ContainerExitHook{}
+	postOOMKills := []ContainerExitHook{}
 
 	for _, defaultHook := range defaultHooks {
 		preCreates = append(preCreates, defaultHook.PreCreates...)
 		preStarts = append(preStarts, defaultHook.PreStarts...)
 		preStops = append(preStops, defaultHook.PreStops...)
+		prePauses = append(prePauses, defaultHook.PrePauses...)
+		preUnpauses = append(preUnpauses, defaultHook.PreUnpauses...)
+		preKills = append(preKills, defaultHook.PreKills...)
 		preTerminates = append(preTerminates, defaultHook.PreTerminates...)
 	}
 
@@ -570,8 +883,16 @@ func combineContainerHooks(defaultHooks, userDefinedHooks []ContainerLifecycleHo
 		postReadies = append(postReadies, userDefinedHook.PostReadies...)
 		preStops = append(preStops, userDefinedHook.PreStops...)
 		postStops = append(postStops, userDefinedHook.PostStops...)
+		prePauses = append(prePauses, userDefinedHook.PrePauses...)
+		postPauses = append(postPauses, userDefinedHook.PostPauses...)
+		preUnpauses = append(preUnpauses, userDefinedHook.PreUnpauses...)
+		postUnpauses = append(postUnpauses, userDefinedHook.PostUnpauses...)
+		preKills = append(preKills, userDefinedHook.PreKills...)
+		postKills = append(postKills, userDefinedHook.PostKills...)
 		preTerminates = append(preTerminates, userDefinedHook.PreTerminates...)
 		postTerminates = append(postTerminates, userDefinedHook.PostTerminates...)
+		postDies = append(postDies, userDefinedHook.PostDies...)
+		postOOMKills = append(postOOMKills, userDefinedHook.PostOOMKills...)
 	}
 
 	// finally, append the default post-hooks
@@ -580,7 +901,12 @@ func combineContainerHooks(defaultHooks, userDefinedHooks []ContainerLifecycleHo
 		postStarts = append(postStarts, defaultHook.PostStarts...)
 		postReadies = append(postReadies, defaultHook.PostReadies...)
 		postStops = append(postStops, defaultHook.PostStops...)
+		postPauses = append(postPauses, defaultHook.PostPauses...)
+		postUnpauses = append(postUnpauses, defaultHook.PostUnpauses...)
+		postKills = append(postKills, defaultHook.PostKills...)
 		postTerminates = append(postTerminates, defaultHook.PostTerminates...)
+		postDies = append(postDies, defaultHook.PostDies...)
+		postOOMKills = append(postOOMKills, defaultHook.PostOOMKills...)
 	}
 
 	return ContainerLifecycleHooks{
@@ -591,8 +917,16 @@ func combineContainerHooks(defaultHooks, userDefinedHooks []ContainerLifecycleHo
 		PostReadies:    postReadies,
 		PreStops:       preStops,
 		PostStops:      postStops,
+		PrePauses:      prePauses,
+		PostPauses:     postPauses,
+		PreUnpauses:    preUnpauses,
+		PostUnpauses:   postUnpauses,
+		PreKills:       preKills,
+		PostKills:      postKills,
 		PreTerminates:  preTerminates,
 		PostTerminates: postTerminates,
+		PostDies:       postDies,
+		PostOOMKills:   postOOMKills,
 	}
 }
 
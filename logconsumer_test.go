@@ -3,16 +3,20 @@ package testcontainers
 import (
 	"bytes"
 	"context"
+	"encoding/binary"
 	"errors"
 	"fmt"
 	"io"
+	"net"
 	"net/http"
 	"os"
 	"strings"
 	"sync"
 	"testing"
+	"testing/iotest"
 	"time"
 
+	"github.com/docker/docker/api/types/container"
 	"github.com/docker/docker/client"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -716,3 +720,66 @@ func TestRestartContainerWithLogConsumer(t *testing.T) {
 	logConsumer.AssertRead()
 	logConsumer.AssertRead()
 }
+
+// logsMockCli is a mock implementation of client.APIClient, returning a log with its timestamp
+// and a closed connection on the first logs request, and timeouts on the next ones.
+type logsMockCli struct {
+	client.APIClient
+
+	mtx    sync.Mutex
+	since  []string
+	frames []byte
+}
+
+func (m *logsMockCli) ContainerLogs(_ context.Context, _ string, opts container.LogsOptions) (io.ReadCloser, error) {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	m.since = append(m.since, opts.Since)
+	if len(m.since) == 1 {
+		return io.NopCloser(io.MultiReader(bytes.NewReader(m.frames), iotest.ErrReader(net.ErrClosed))), nil
+	}
+
+	return io.NopCloser(iotest.ErrReader(context.DeadlineExceeded)), nil
+}
+
+func (m *logsMockCli) Since() []string {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	return append([]string{}, m.since...)
+}
+
+func (m *logsMockCli) Close() error {
+	return nil
+}
+
+func TestLogProductionReconnects(t *testing.T) {
+	content := []byte("2024-01-02T03:04:05.000000001Z ready\n")
+	frame := make([]byte, 8, 8+len(content))
+	frame[0] = 1
+	binary.BigEndian.PutUint32(frame[4:], uint32(len(content)))
+	frame = append(frame, content...)
+
+	cli := &logsMockCli{frames: frame}
+	consumer := &fakeTestLogConsumer{}
+	ctr := &DockerContainer{
+		ID:        "container-id",
+		provider:  &DockerProvider{client: cli},
+		consumers: []LogConsumer{consumer},
+	}
+
+	require.NoError(t, ctr.startLogProduction(context.Background()))
+
+	// the logs are requested again from the timestamp of the last log, while the requests time out
+	require.Eventually(t, func() bool {
+		return len(cli.Since()) > 2
+	}, 5*time.Second, 10*time.Millisecond)
+	require.Equal(t, []string{"", "1704164645.000000002"}, cli.Since()[:2])
+
+	// the log production stops while it's reconnecting
+	require.NoError(t, ctr.stopLogProduction())
+	require.NoError(t, ctr.stopLogProduction())
+
+	require.Equal(t, "ready\n", consumer.buf.String())
+}
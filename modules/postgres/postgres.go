@@ -204,11 +204,13 @@ func WithSnapshotName(name string) SnapshotOption {
 	}
 }
 
-// Snapshot takes a snapshot of the current state of the database as a template, which can then be restored using
-// the Restore method. By default, the snapshot will be created under a database called migrated_template, you can
+// SnapshotDatabase takes a snapshot of the current state of the database as a template, which can then be restored using
+// the RestoreDatabase method. As it only copies the database, it's faster than [testcontainers.Container.Snapshot],
+// which captures the whole container. By default, the snapshot will be created under a database called migrated_template, you can
 // customize the snapshot name with the options.
 // If a snapshot already exists under the given/default name, it will be overwritten with the new snapshot.
-func (c *PostgresContainer) Snapshot(ctx context.Context, opts ...SnapshotOption) error {
+// It was previously named Snapshot, which is now the method snapshotting the whole container.
+func (c *PostgresContainer) SnapshotDatabase(ctx context.Context, opts ...SnapshotOption) error {
 	snapshotName, err := c.checkSnapshotConfig(opts)
 	if err != nil {
 		return err
@@ -230,9 +232,10 @@ func (c *PostgresContainer) Snapshot(ctx context.Context, opts ...SnapshotOption
 	return nil
 }
 
-// Restore will restore the database to a specific snapshot. By default, it will restore the last snapshot taken on the
-// database by the Snapshot method. If a snapshot name is provided, it will instead try to restore the snapshot by name.
-func (c *PostgresContainer) Restore(ctx context.Context, opts ...SnapshotOption) error {
+// RestoreDatabase will restore the database to a specific snapshot. By default, it will restore the last snapshot taken on the
+// database by the SnapshotDatabase method. If a snapshot name is provided, it will instead try to restore the snapshot by name.
+// It was previously named Restore, which is now the method restoring a snapshot of the whole container.
+func (c *PostgresContainer) RestoreDatabase(ctx context.Context, opts ...SnapshotOption) error {
 	snapshotName, err := c.checkSnapshotConfig(opts)
 	if err != nil {
 		return err
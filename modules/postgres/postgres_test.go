@@ -244,7 +244,7 @@ func TestSnapshot(t *testing.T) {
 
 			// 2. Create a snapshot of the database to restore later
 			// tt.options comes the test case, it can be specified as e.g. `postgres.WithSnapshotName("custom-snapshot")` or omitted, to use default name
-			err = ctr.Snapshot(ctx, tt.options...)
+			err = ctr.SnapshotDatabase(ctx, tt.options...)
 			require.NoError(t, err)
 
 			dbURL, err := ctr.ConnectionString(ctx)
@@ -253,7 +253,7 @@ func TestSnapshot(t *testing.T) {
 			t.Run("Test inserting a user", func(t *testing.T) {
 				t.Cleanup(func() {
 					// 3. In each test, reset the DB to its snapshot state.
-					err = ctr.Restore(ctx)
+					err = ctr.RestoreDatabase(ctx)
 					require.NoError(t, err)
 				})
 
@@ -276,7 +276,7 @@ func TestSnapshot(t *testing.T) {
 			// 4. Run as many tests as you need, they will each get a clean database
 			t.Run("Test querying empty DB", func(t *testing.T) {
 				t.Cleanup(func() {
-					err = ctr.Restore(ctx)
+					err = ctr.RestoreDatabase(ctx)
 					require.NoError(t, err)
 				})
 
@@ -314,7 +314,7 @@ func TestSnapshotWithOverrides(t *testing.T) {
 
 	_, _, err = ctr.Exec(ctx, []string{"psql", "-U", user, "-d", dbname, "-c", "CREATE TABLE users (id SERIAL, name TEXT NOT NULL, age INT NOT NULL)"})
 	require.NoError(t, err)
-	err = ctr.Snapshot(ctx, postgres.WithSnapshotName("other-snapshot"))
+	err = ctr.SnapshotDatabase(ctx, postgres.WithSnapshotName("other-snapshot"))
 	require.NoError(t, err)
 
 	dbURL, err := ctr.ConnectionString(ctx)
@@ -325,7 +325,7 @@ func TestSnapshotWithOverrides(t *testing.T) {
 		require.NoError(t, err)
 
 		// Doing the restore before we connect since this resets the pgx connection
-		err = ctr.Restore(ctx)
+		err = ctr.RestoreDatabase(ctx)
 		require.NoError(t, err)
 
 		conn, err := pgx.Connect(context.Background(), dbURL)
@@ -365,7 +365,7 @@ func TestSnapshotWithDockerExecFallback(t *testing.T) {
 	require.NoError(t, err)
 
 	// 2. Create a snapshot of the database to restore later
-	err = ctr.Snapshot(ctx, postgres.WithSnapshotName("test-snapshot"))
+	err = ctr.SnapshotDatabase(ctx, postgres.WithSnapshotName("test-snapshot"))
 	require.NoError(t, err)
 
 	dbURL, err := ctr.ConnectionString(ctx)
@@ -374,7 +374,7 @@ func TestSnapshotWithDockerExecFallback(t *testing.T) {
 	t.Run("Test inserting a user", func(t *testing.T) {
 		t.Cleanup(func() {
 			// 3. In each test, reset the DB to its snapshot state.
-			err := ctr.Restore(ctx)
+			err := ctr.RestoreDatabase(ctx)
 			require.NoError(t, err)
 		})
 
@@ -397,7 +397,7 @@ func TestSnapshotWithDockerExecFallback(t *testing.T) {
 	t.Run("Test querying empty DB", func(t *testing.T) {
 		// 4. Run as many tests as you need, they will each get a clean database
 		t.Cleanup(func() {
-			err := ctr.Restore(ctx)
+			err := ctr.RestoreDatabase(ctx)
 			require.NoError(t, err)
 		})
 
@@ -0,0 +1,267 @@
+package toxiproxy
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// Client is a client of the HTTP API of Toxiproxy, used to manage its proxies and their toxics.
+type Client struct {
+	uri        string
+	httpClient *http.Client
+}
+
+// NewClient returns a client of the HTTP API of Toxiproxy listening at the given URI,
+// e.g. "http://localhost:8474".
+func NewClient(uri string) *Client {
+	return &Client{
+		uri:        strings.TrimSuffix(uri, "/"),
+		httpClient: http.DefaultClient,
+	}
+}
+
+// APIError is the error returned by the HTTP API of Toxiproxy.
+type APIError struct {
+	// StatusCode is the HTTP status code of the response.
+	StatusCode int
+	// Message is the error message returned by Toxiproxy.
+	Message string
+}
+
+// Error implements the error interface.
+func (e *APIError) Error() string {
+	return fmt.Sprintf("toxiproxy: %s (status %d)", e.Message, e.StatusCode)
+}
+
+// IsNotFound returns true if the error is an [APIError] for a proxy or a toxic which doesn't exist.
+func IsNotFound(err error) bool {
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound
+}
+
+// Version returns the version of Toxiproxy.
+func (c *Client) Version(ctx context.Context) (string, error) {
+	var version struct {
+		Version string `json:"version"`
+	}
+	if err := c.do(ctx, http.MethodGet, "/version", nil, &version); err != nil {
+		return "", err
+	}
+
+	return version.Version, nil
+}
+
+// Proxies returns the proxies of Toxiproxy, indexed by name.
+func (c *Client) Proxies(ctx context.Context) (map[string]*Proxy, error) {
+	proxies := map[string]*Proxy{}
+	if err := c.do(ctx, http.MethodGet, "/proxies", nil, &proxies); err != nil {
+		return nil, err
+	}
+
+	for _, p := range proxies {
+		p.client = c
+	}
+
+	return proxies, nil
+}
+
+// Proxy returns the proxy with the given name.
+func (c *Client) Proxy(ctx context.Context, name string) (*Proxy, error) {
+	p := &Proxy{client: c}
+	if err := c.do(ctx, http.MethodGet, "/proxies/"+url.PathEscape(name), nil, p); err != nil {
+		return nil, err
+	}
+
+	return p, nil
+}
+
+// CreateProxy creates an enabled proxy with the given name, listening at the given address inside
+// the Toxiproxy container, e.g. "0.0.0.0:8666", and forwarding the connections to the upstream address,
+// e.g. "redis:6379". The port of the listen address must be exposed to reach the proxy from the host,
+// which is done by [ToxiproxyContainer.CreateProxy].
+func (c *Client) CreateProxy(ctx context.Context, name string, listen string, upstream string) (*Proxy, error) {
+	req := &Proxy{
+		Name:     name,
+		Listen:   listen,
+		Upstream: upstream,
+		Enabled:  true,
+	}
+
+	p := &Proxy{client: c}
+	if err := c.do(ctx, http.MethodPost, "/proxies", req, p); err != nil {
+		return nil, err
+	}
+
+	return p, nil
+}
+
+// ResetState enables all the proxies and removes all their toxics.
+func (c *Client) ResetState(ctx context.Context) error {
+	return c.do(ctx, http.MethodPost, "/reset", nil, nil)
+}
+
+// do sends a request to the HTTP API, encoding the body as JSON, and decodes the response into out, if not nil.
+func (c *Client) do(ctx context.Context, method string, path string, body any, out any) error {
+	var r io.Reader
+	if body != nil {
+		bs, err := json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("marshal request: %w", err)
+		}
+		r = bytes.NewReader(bs)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, c.uri+path, r)
+	if err != nil {
+		return fmt.Errorf("new request: %w", err)
+	}
+
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("%s %s: %w", method, path, err)
+	}
+	defer resp.Body.Close()
+
+	bs, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("read response: %w", err)
+	}
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		var apiErr struct {
+			Error string `json:"error"`
+		}
+		if err := json.Unmarshal(bs, &apiErr); err != nil || apiErr.Error == "" {
+			apiErr.Error = strings.TrimSpace(string(bs))
+		}
+
+		return &APIError{StatusCode: resp.StatusCode, Message: apiErr.Error}
+	}
+
+	if out == nil || len(bs) == 0 {
+		return nil
+	}
+
+	if err := json.Unmarshal(bs, out); err != nil {
+		return fmt.Errorf("unmarshal response: %w", err)
+	}
+
+	return nil
+}
+
+// Proxy is a proxy of Toxiproxy, forwarding the connections to its listen address to the upstream address,
+// applying its toxics to the traffic.
+type Proxy struct {
+	// Name is the unique name of the proxy.
+	Name string `json:"name"`
+	// Listen is the address the proxy listens at, inside the Toxiproxy container.
+	Listen string `json:"listen"`
+	// Upstream is the address the proxy forwards the connections to.
+	Upstream string `json:"upstream"`
+	// Enabled is false if the proxy is disabled, refusing any connection.
+	Enabled bool `json:"enabled"`
+	// Toxics are the toxics of the proxy, when it was read.
+	Toxics []Toxic `json:"toxics,omitempty"`
+
+	// Endpoint is the endpoint of the proxy in the host:port format, reachable from the host,
+	// for the proxies created by [ToxiproxyContainer.CreateProxy] and [ToxiproxyContainer.Route].
+	Endpoint string `json:"-"`
+
+	client   *Client
+	onDelete func()
+}
+
+// path returns the path of the proxy in the HTTP API.
+func (p *Proxy) path() string {
+	return "/proxies/" + url.PathEscape(p.Name)
+}
+
+// Enable enables the proxy, so it accepts connections again.
+func (p *Proxy) Enable(ctx context.Context) error {
+	return p.setEnabled(ctx, true)
+}
+
+// Disable disables the proxy, closing all its connections and refusing the new ones,
+// which simulates the upstream being down.
+func (p *Proxy) Disable(ctx context.Context) error {
+	return p.setEnabled(ctx, false)
+}
+
+// setEnabled enables or disables the proxy.
+func (p *Proxy) setEnabled(ctx context.Context, enabled bool) error {
+	body := map[string]bool{"enabled": enabled}
+	if err := p.client.do(ctx, http.MethodPost, p.path(), body, nil); err != nil {
+		return err
+	}
+
+	p.Enabled = enabled
+
+	return nil
+}
+
+// Delete deletes the proxy, closing all its connections.
+func (p *Proxy) Delete(ctx context.Context) error {
+	if err := p.client.do(ctx, http.MethodDelete, p.path(), nil, nil); err != nil {
+		return err
+	}
+
+	if p.onDelete != nil {
+		p.onDelete()
+		p.onDelete = nil
+	}
+
+	return nil
+}
+
+// ListToxics returns the current toxics of the proxy.
+func (p *Proxy) ListToxics(ctx context.Context) ([]Toxic, error) {
+	var toxics []Toxic
+	if err := p.client.do(ctx, http.MethodGet, p.path()+"/toxics", nil, &toxics); err != nil {
+		return nil, err
+	}
+
+	return toxics, nil
+}
+
+// AddToxic adds the toxic to the proxy, applying it to the existing and the new connections,
+// and returns it as created by Toxiproxy. If the name of the toxic is empty, Toxiproxy names it
+// after its type and stream, e.g. "latency_downstream".
+func (p *Proxy) AddToxic(ctx context.Context, toxic Toxic) (*Toxic, error) {
+	created := &Toxic{}
+	if err := p.client.do(ctx, http.MethodPost, p.path()+"/toxics", toxic, created); err != nil {
+		return nil, err
+	}
+
+	return created, nil
+}
+
+// UpdateToxic updates the toxicity and the attributes of the toxic of the proxy with the same name,
+// so the toxics can be adjusted during a test without closing the connections.
+func (p *Proxy) UpdateToxic(ctx context.Context, toxic Toxic) (*Toxic, error) {
+	if toxic.Name == "" {
+		return nil, errors.New("the name of the toxic to update is required")
+	}
+
+	updated := &Toxic{}
+	if err := p.client.do(ctx, http.MethodPost, p.path()+"/toxics/"+url.PathEscape(toxic.Name), toxic, updated); err != nil {
+		return nil, err
+	}
+
+	return updated, nil
+}
+
+// RemoveToxic removes the toxic with the given name from the proxy.
+func (p *Proxy) RemoveToxic(ctx context.Context, name string) error {
+	return p.client.do(ctx, http.MethodDelete, p.path()+"/toxics/"+url.PathEscape(name), nil, nil)
+}
@@ -0,0 +1,252 @@
+package toxiproxy_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/testcontainers/testcontainers-go/modules/toxiproxy"
+)
+
+// fakeAPI is an in-memory implementation of the subset of the HTTP API of Toxiproxy used by the client.
+type fakeAPI struct {
+	mx      sync.Mutex
+	proxies map[string]*toxiproxy.Proxy
+}
+
+func newFakeAPI(t *testing.T) *toxiproxy.Client {
+	t.Helper()
+
+	api := &fakeAPI{proxies: map[string]*toxiproxy.Proxy{}}
+	srv := httptest.NewServer(api)
+	t.Cleanup(srv.Close)
+
+	return toxiproxy.NewClient(srv.URL + "/")
+}
+
+func (f *fakeAPI) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mx.Lock()
+	defer f.mx.Unlock()
+
+	parts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+
+	switch {
+	case r.Method == http.MethodGet && parts[0] == "version":
+		writeJSON(w, http.StatusOK, map[string]string{"version": "2.12.0"})
+	case r.Method == http.MethodPost && parts[0] == "reset":
+		for _, p := range f.proxies {
+			p.Enabled = true
+			p.Toxics = nil
+		}
+		w.WriteHeader(http.StatusNoContent)
+	case r.Method == http.MethodGet && len(parts) == 1:
+		writeJSON(w, http.StatusOK, f.proxies)
+	case r.Method == http.MethodPost && len(parts) == 1:
+		p := &toxiproxy.Proxy{}
+		_ = json.NewDecoder(r.Body).Decode(p)
+		if _, ok := f.proxies[p.Name]; ok {
+			writeError(w, http.StatusConflict, "proxy already exists")
+			return
+		}
+		f.proxies[p.Name] = p
+		writeJSON(w, http.StatusCreated, p)
+	default:
+		p, ok := f.proxies[parts[1]]
+		if !ok {
+			writeError(w, http.StatusNotFound, "proxy not found")
+			return
+		}
+		f.serveProxy(w, r, p, parts[2:])
+	}
+}
+
+func (f *fakeAPI) serveProxy(w http.ResponseWriter, r *http.Request, p *toxiproxy.Proxy, parts []string) {
+	switch {
+	case r.Method == http.MethodGet && len(parts) == 0:
+		writeJSON(w, http.StatusOK, p)
+	case r.Method == http.MethodPost && len(parts) == 0:
+		_ = json.NewDecoder(r.Body).Decode(p)
+		writeJSON(w, http.StatusOK, p)
+	case r.Method == http.MethodDelete && len(parts) == 0:
+		delete(f.proxies, p.Name)
+		w.WriteHeader(http.StatusNoContent)
+	case r.Method == http.MethodGet && len(parts) == 1:
+		writeJSON(w, http.StatusOK, p.Toxics)
+	case r.Method == http.MethodPost && len(parts) == 1:
+		toxic := toxiproxy.Toxic{}
+		_ = json.NewDecoder(r.Body).Decode(&toxic)
+		if toxic.Name == "" {
+			toxic.Name = toxic.Type + "_" + string(toxic.Stream)
+		}
+		p.Toxics = append(p.Toxics, toxic)
+		writeJSON(w, http.StatusOK, toxic)
+	default:
+		for i, toxic := range p.Toxics {
+			if toxic.Name != parts[1] {
+				continue
+			}
+
+			if r.Method == http.MethodDelete {
+				p.Toxics = append(p.Toxics[:i], p.Toxics[i+1:]...)
+				w.WriteHeader(http.StatusNoContent)
+				return
+			}
+
+			_ = json.NewDecoder(r.Body).Decode(&p.Toxics[i])
+			writeJSON(w, http.StatusOK, p.Toxics[i])
+			return
+		}
+		writeError(w, http.StatusNotFound, "toxic not found")
+	}
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, msg string) {
+	writeJSON(w, status, map[string]any{"error": msg, "status": status})
+}
+
+func TestClient(t *testing.T) {
+	ctx := context.Background()
+
+	client := newFakeAPI(t)
+
+	version, err := client.Version(ctx)
+	require.NoError(t, err)
+	require.Equal(t, "2.12.0", version)
+
+	proxy, err := client.CreateProxy(ctx, "redis", "0.0.0.0:8666", "redis:6379")
+	require.NoError(t, err)
+	require.Equal(t, "redis", proxy.Name)
+	require.Equal(t, "0.0.0.0:8666", proxy.Listen)
+	require.Equal(t, "redis:6379", proxy.Upstream)
+	require.True(t, proxy.Enabled)
+
+	_, err = client.CreateProxy(ctx, "redis", "0.0.0.0:8667", "redis:6379")
+	var apiErr *toxiproxy.APIError
+	require.ErrorAs(t, err, &apiErr)
+	require.Equal(t, http.StatusConflict, apiErr.StatusCode)
+	require.Equal(t, "proxy already exists", apiErr.Message)
+
+	t.Run("toxics", func(t *testing.T) {
+		toxic, err := proxy.AddToxic(ctx, toxiproxy.Latency(time.Second, 100*time.Millisecond))
+		require.NoError(t, err)
+		require.Equal(t, "latency_downstream", toxic.Name)
+		require.Equal(t, "latency", toxic.Type)
+		require.Equal(t, toxiproxy.Downstream, toxic.Stream)
+		require.InDelta(t, 1, toxic.Toxicity, 0)
+		require.Equal(t, map[string]any{"latency": float64(1000), "jitter": float64(100)}, toxic.Attributes)
+
+		toxic.Attributes["latency"] = 10
+		_, err = proxy.UpdateToxic(ctx, *toxic)
+		require.NoError(t, err)
+
+		toxics, err := proxy.ListToxics(ctx)
+		require.NoError(t, err)
+		require.Len(t, toxics, 1)
+		require.Equal(t, float64(10), toxics[0].Attributes["latency"])
+
+		_, err = proxy.UpdateToxic(ctx, toxiproxy.Timeout(0))
+		require.Error(t, err)
+
+		require.NoError(t, proxy.RemoveToxic(ctx, toxic.Name))
+
+		err = proxy.RemoveToxic(ctx, toxic.Name)
+		require.True(t, toxiproxy.IsNotFound(err))
+	})
+
+	t.Run("enable-disable", func(t *testing.T) {
+		require.NoError(t, proxy.Disable(ctx))
+		require.False(t, proxy.Enabled)
+
+		p, err := client.Proxy(ctx, "redis")
+		require.NoError(t, err)
+		require.False(t, p.Enabled)
+
+		require.NoError(t, client.ResetState(ctx))
+
+		proxies, err := client.Proxies(ctx)
+		require.NoError(t, err)
+		require.Len(t, proxies, 1)
+		require.True(t, proxies["redis"].Enabled)
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		require.NoError(t, proxy.Delete(ctx))
+
+		_, err := client.Proxy(ctx, "redis")
+		require.True(t, toxiproxy.IsNotFound(err))
+	})
+}
+
+func TestToxics(t *testing.T) {
+	tests := []struct {
+		name       string
+		toxic      toxiproxy.Toxic
+		typ        string
+		attributes map[string]any
+	}{
+		{
+			name:       "latency",
+			toxic:      toxiproxy.Latency(500*time.Millisecond, 50*time.Millisecond),
+			typ:        "latency",
+			attributes: map[string]any{"latency": int64(500), "jitter": int64(50)},
+		},
+		{
+			name:       "bandwidth",
+			toxic:      toxiproxy.Bandwidth(64),
+			typ:        "bandwidth",
+			attributes: map[string]any{"rate": int64(64)},
+		},
+		{
+			name:       "slow-close",
+			toxic:      toxiproxy.SlowClose(time.Second),
+			typ:        "slow_close",
+			attributes: map[string]any{"delay": int64(1000)},
+		},
+		{
+			name:       "timeout",
+			toxic:      toxiproxy.Timeout(2 * time.Second),
+			typ:        "timeout",
+			attributes: map[string]any{"timeout": int64(2000)},
+		},
+		{
+			name:       "reset-peer",
+			toxic:      toxiproxy.ResetPeer(0),
+			typ:        "reset_peer",
+			attributes: map[string]any{"timeout": int64(0)},
+		},
+		{
+			name:       "slicer",
+			toxic:      toxiproxy.Slicer(100, 10, time.Millisecond),
+			typ:        "slicer",
+			attributes: map[string]any{"average_size": 100, "size_variation": 10, "delay": int64(1000)},
+		},
+		{
+			name:       "limit-data",
+			toxic:      toxiproxy.LimitData(1024),
+			typ:        "limit_data",
+			attributes: map[string]any{"bytes": int64(1024)},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.typ, tt.toxic.Type)
+			require.Equal(t, toxiproxy.Downstream, tt.toxic.Stream)
+			require.InDelta(t, 1, tt.toxic.Toxicity, 0)
+			require.Equal(t, tt.attributes, tt.toxic.Attributes)
+		})
+	}
+}
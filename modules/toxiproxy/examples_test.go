@@ -0,0 +1,107 @@
+package toxiproxy_test
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/modules/toxiproxy"
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+func ExampleRun() {
+	// runToxiproxyContainer {
+	ctx := context.Background()
+
+	toxiproxyContainer, err := toxiproxy.Run(ctx, "ghcr.io/shopify/toxiproxy:2.12.0")
+	defer func() {
+		if err := testcontainers.TerminateContainer(toxiproxyContainer); err != nil {
+			log.Printf("failed to terminate container: %s", err)
+		}
+	}()
+	if err != nil {
+		log.Printf("failed to start container: %s", err)
+		return
+	}
+	// }
+
+	state, err := toxiproxyContainer.State(ctx)
+	if err != nil {
+		log.Printf("failed to get container state: %s", err)
+		return
+	}
+
+	fmt.Println(state.Running)
+
+	// Output:
+	// true
+}
+
+func ExampleToxiproxyContainer_Route() {
+	ctx := context.Background()
+
+	toxiproxyContainer, err := toxiproxy.Run(ctx, "ghcr.io/shopify/toxiproxy:2.12.0")
+	defer func() {
+		if err := testcontainers.TerminateContainer(toxiproxyContainer); err != nil {
+			log.Printf("failed to terminate container: %s", err)
+		}
+	}()
+	if err != nil {
+		log.Printf("failed to start container: %s", err)
+		return
+	}
+
+	// routeRedis {
+	proxy, route := toxiproxyContainer.Route("redis", "6379/tcp")
+
+	// the modules accept the customizer as an option, e.g. redis.Run(ctx, "redis:7", route)
+	req := testcontainers.GenericContainerRequest{
+		ContainerRequest: testcontainers.ContainerRequest{
+			Image:        "redis:7",
+			ExposedPorts: []string{"6379/tcp"},
+			WaitingFor:   wait.ForLog("Ready to accept connections"),
+		},
+		Started: true,
+	}
+	if err := route(&req); err != nil {
+		log.Printf("failed to route container: %s", err)
+		return
+	}
+
+	redisContainer, err := testcontainers.GenericContainer(ctx, req)
+	defer func() {
+		if err := testcontainers.TerminateContainer(redisContainer); err != nil {
+			log.Printf("failed to terminate container: %s", err)
+		}
+	}()
+	if err != nil {
+		log.Printf("failed to start container: %s", err)
+		return
+	}
+
+	// connect the Redis client to proxy.Endpoint, e.g. localhost:32771, instead of the mapped port of Redis
+	// }
+
+	// adjustToxics {
+	toxic, err := proxy.AddToxic(ctx, toxiproxy.Latency(500*time.Millisecond, 0))
+	if err != nil {
+		log.Printf("failed to add toxic: %s", err)
+		return
+	}
+
+	// the toxics can be adjusted during the test, without closing the connections
+	toxic.Toxicity = 0.5
+	toxic, err = proxy.UpdateToxic(ctx, *toxic)
+	if err != nil {
+		log.Printf("failed to update toxic: %s", err)
+		return
+	}
+	// }
+
+	fmt.Println(toxic.Type, toxic.Toxicity)
+
+	// Output:
+	// latency 0.5
+}
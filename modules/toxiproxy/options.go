@@ -0,0 +1,45 @@
+package toxiproxy
+
+import (
+	"github.com/testcontainers/testcontainers-go"
+)
+
+const (
+	// firstProxyPort is the first port of the Toxiproxy container exposed for the proxies.
+	firstProxyPort = 8666
+
+	// defaultProxyPorts is the number of ports of the Toxiproxy container exposed for the proxies by default.
+	defaultProxyPorts = 32
+)
+
+// options holds the configuration of the Toxiproxy container.
+type options struct {
+	proxyPorts int
+}
+
+func defaultOptions() options {
+	return options{
+		proxyPorts: defaultProxyPorts,
+	}
+}
+
+// Compiler check to ensure that Option implements the testcontainers.ContainerCustomizer interface.
+var _ testcontainers.ContainerCustomizer = (Option)(nil)
+
+// Option is an option for the Toxiproxy container.
+type Option func(*options)
+
+// Customize is a NOOP. It's defined to satisfy the testcontainers.ContainerCustomizer interface.
+func (o Option) Customize(*testcontainers.GenericContainerRequest) error {
+	// NOOP to satisfy interface.
+	return nil
+}
+
+// WithProxyPorts sets the number of ports of the Toxiproxy container exposed for the proxies,
+// starting at 8666, which limits the number of proxies created with [ToxiproxyContainer.CreateProxy]
+// and [ToxiproxyContainer.Route]. Default: 32.
+func WithProxyPorts(n int) Option {
+	return func(o *options) {
+		o.proxyPorts = n
+	}
+}
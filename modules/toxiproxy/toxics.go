@@ -0,0 +1,97 @@
+package toxiproxy
+
+import "time"
+
+// Stream is the direction of the traffic of a proxy a toxic applies to.
+type Stream string
+
+const (
+	// Downstream is the traffic from the upstream to the client.
+	Downstream Stream = "downstream"
+	// Upstream is the traffic from the client to the upstream.
+	Upstream Stream = "upstream"
+)
+
+// Toxic is a toxic of a proxy, altering the traffic in one direction.
+// The functions of this package return toxics of the types supported by Toxiproxy,
+// applied to the downstream traffic of every connection, which can be changed
+// modifying the returned value.
+//
+// See https://github.com/Shopify/toxiproxy#toxics for more information.
+type Toxic struct {
+	// Name is the unique name of the toxic in the proxy.
+	Name string `json:"name,omitempty"`
+	// Type is the type of the toxic, e.g. "latency".
+	Type string `json:"type"`
+	// Stream is the direction of the traffic the toxic applies to.
+	Stream Stream `json:"stream,omitempty"`
+	// Toxicity is the probability of the toxic being applied to a connection, between 0 and 1.
+	Toxicity float32 `json:"toxicity"`
+	// Attributes are the attributes of the toxic, which depend on its type.
+	Attributes map[string]any `json:"attributes"`
+}
+
+// newToxic returns a toxic of the given type, applied to the downstream traffic of every connection.
+func newToxic(typ string, attributes map[string]any) Toxic {
+	return Toxic{
+		Type:       typ,
+		Stream:     Downstream,
+		Toxicity:   1,
+		Attributes: attributes,
+	}
+}
+
+// Latency returns a toxic delaying the data by the given latency, plus or minus the jitter.
+func Latency(latency time.Duration, jitter time.Duration) Toxic {
+	return newToxic("latency", map[string]any{
+		"latency": latency.Milliseconds(),
+		"jitter":  jitter.Milliseconds(),
+	})
+}
+
+// Bandwidth returns a toxic limiting the rate of the data to the given kilobytes per second.
+func Bandwidth(rate int64) Toxic {
+	return newToxic("bandwidth", map[string]any{
+		"rate": rate,
+	})
+}
+
+// SlowClose returns a toxic delaying the closing of the connections by the given delay.
+func SlowClose(delay time.Duration) Toxic {
+	return newToxic("slow_close", map[string]any{
+		"delay": delay.Milliseconds(),
+	})
+}
+
+// Timeout returns a toxic stopping all the data, and closing the connections after the given timeout.
+// If the timeout is zero, the connections are never closed.
+func Timeout(timeout time.Duration) Toxic {
+	return newToxic("timeout", map[string]any{
+		"timeout": timeout.Milliseconds(),
+	})
+}
+
+// ResetPeer returns a toxic resetting the connections with a TCP RST after the given timeout.
+// If the timeout is zero, the connections are reset immediately.
+func ResetPeer(timeout time.Duration) Toxic {
+	return newToxic("reset_peer", map[string]any{
+		"timeout": timeout.Milliseconds(),
+	})
+}
+
+// Slicer returns a toxic slicing the data into chunks of the given average size in bytes,
+// plus or minus the size variation, sent with the given delay between them.
+func Slicer(averageSize int, sizeVariation int, delay time.Duration) Toxic {
+	return newToxic("slicer", map[string]any{
+		"average_size":   averageSize,
+		"size_variation": sizeVariation,
+		"delay":          delay.Microseconds(),
+	})
+}
+
+// LimitData returns a toxic closing the connections once the given number of bytes was transmitted.
+func LimitData(bytes int64) Toxic {
+	return newToxic("limit_data", map[string]any{
+		"bytes": bytes,
+	})
+}
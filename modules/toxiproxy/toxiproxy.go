@@ -0,0 +1,265 @@
+package toxiproxy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"sync"
+
+	"github.com/docker/go-connections/nat"
+
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/network"
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+const (
+	// ControlPort is the port of the HTTP API of Toxiproxy.
+	ControlPort = "8474/tcp"
+
+	// defaultNetworkAlias is the alias of the Toxiproxy container in the network created by Run.
+	defaultNetworkAlias = "toxiproxy"
+
+	// upstreamAliasPrefix is the prefix of the network alias of the containers routed through a proxy.
+	upstreamAliasPrefix = "toxiproxy-upstream-"
+)
+
+// ToxiproxyContainer represents the Toxiproxy container type used in the module
+type ToxiproxyContainer struct {
+	testcontainers.Container
+
+	// network is the name of the network shared with the containers routed through the proxies.
+	network string
+
+	proxyPortsMx   sync.Mutex
+	proxyPorts     []int
+	usedProxyPorts map[int]bool
+}
+
+// Run creates an instance of the Toxiproxy container type. The container exposes the port of its HTTP API
+// and a range of ports for the proxies, see [WithProxyPorts]. Unless the container is attached to a network
+// using the network options, a new network is created for it, which is shared with the containers routed
+// through the proxies using [ToxiproxyContainer.Route].
+func Run(ctx context.Context, img string, opts ...testcontainers.ContainerCustomizer) (*ToxiproxyContainer, error) {
+	settings := defaultOptions()
+	for _, opt := range opts {
+		if apply, ok := opt.(Option); ok {
+			apply(&settings)
+		}
+	}
+
+	if settings.proxyPorts < 1 {
+		return nil, fmt.Errorf("at least one proxy port is required: %d", settings.proxyPorts)
+	}
+
+	proxyPorts := make([]int, settings.proxyPorts)
+	exposedPorts := []string{ControlPort}
+	for i := range proxyPorts {
+		proxyPorts[i] = firstProxyPort + i
+		exposedPorts = append(exposedPorts, strconv.Itoa(proxyPorts[i])+"/tcp")
+	}
+
+	req := testcontainers.ContainerRequest{
+		Image:        img,
+		ExposedPorts: exposedPorts,
+		WaitingFor:   wait.ForHTTP("/version").WithPort(ControlPort),
+	}
+
+	genericContainerReq := testcontainers.GenericContainerRequest{
+		ContainerRequest: req,
+		Started:          true,
+	}
+
+	for _, opt := range opts {
+		if err := opt.Customize(&genericContainerReq); err != nil {
+			return nil, fmt.Errorf("customize: %w", err)
+		}
+	}
+
+	var nw *testcontainers.DockerNetwork
+	if len(genericContainerReq.Networks) == 0 {
+		var err error
+		nw, err = network.New(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("new network: %w", err)
+		}
+
+		if err := network.WithNetwork([]string{defaultNetworkAlias}, nw)(&genericContainerReq); err != nil {
+			return nil, errors.Join(err, nw.Remove(ctx))
+		}
+
+		// the network is removed once the container is terminated, as it was created for it.
+		genericContainerReq.LifecycleHooks = append(genericContainerReq.LifecycleHooks, testcontainers.ContainerLifecycleHooks{
+			PostTerminates: []testcontainers.ContainerHook{
+				func(ctx context.Context, _ testcontainers.Container) error {
+					if err := nw.Remove(ctx); err != nil {
+						return fmt.Errorf("remove network: %w", err)
+					}
+
+					return nil
+				},
+			},
+		})
+	}
+
+	container, err := testcontainers.GenericContainer(ctx, genericContainerReq)
+	if container == nil && nw != nil {
+		// the container was not created, so it will never be terminated
+		err = errors.Join(err, nw.Remove(ctx))
+	}
+	var c *ToxiproxyContainer
+	if container != nil {
+		c = &ToxiproxyContainer{
+			Container:      container,
+			network:        genericContainerReq.Networks[0],
+			proxyPorts:     proxyPorts,
+			usedProxyPorts: map[int]bool{},
+		}
+	}
+
+	if err != nil {
+		return c, fmt.Errorf("generic container: %w", err)
+	}
+
+	return c, nil
+}
+
+// URI returns the URI of the HTTP API of Toxiproxy, e.g. "http://localhost:32768".
+func (c *ToxiproxyContainer) URI(ctx context.Context) (string, error) {
+	return c.PortEndpoint(ctx, ControlPort, "http")
+}
+
+// Client returns a client of the HTTP API of Toxiproxy.
+func (c *ToxiproxyContainer) Client(ctx context.Context) (*Client, error) {
+	uri, err := c.URI(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewClient(uri), nil
+}
+
+// CreateProxy creates an enabled proxy with the given name, forwarding the connections to the upstream
+// address, e.g. "redis:6379", which must be reachable from the Toxiproxy container. The proxy listens at
+// the first free port exposed for the proxies, and the endpoint to reach it from the host is set in
+// [Proxy.Endpoint]. The port is freed when the proxy is deleted.
+func (c *ToxiproxyContainer) CreateProxy(ctx context.Context, name string, upstream string) (*Proxy, error) {
+	client, err := c.Client(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	port, err := c.reserveProxyPort()
+	if err != nil {
+		return nil, err
+	}
+
+	endpoint, err := c.PortEndpoint(ctx, nat.Port(strconv.Itoa(port)+"/tcp"), "")
+	if err != nil {
+		c.releaseProxyPort(port)
+		return nil, fmt.Errorf("port endpoint: %w", err)
+	}
+
+	p, err := client.CreateProxy(ctx, name, "0.0.0.0:"+strconv.Itoa(port), upstream)
+	if err != nil {
+		c.releaseProxyPort(port)
+		return nil, fmt.Errorf("create proxy %s: %w", name, err)
+	}
+
+	p.Endpoint = endpoint
+	p.onDelete = func() {
+		c.releaseProxyPort(port)
+	}
+
+	return p, nil
+}
+
+// Route returns a customizer routing the given port of another container, e.g. "6379/tcp", through
+// a proxy of Toxiproxy with the given name, and the proxy itself, which is created with [ToxiproxyContainer.CreateProxy]
+// right after the container is created, before it's started, so its [Proxy.Endpoint] can be used to connect to the
+// container from the host once it's started. As the proxy only exists along with the container, it's not left behind
+// if the container fails to be created. The container is attached to the network of the Toxiproxy container, so the proxy can reach it,
+// and the proxy is deleted when the container is terminated. Only TCP ports can be routed.
+func (c *ToxiproxyContainer) Route(name string, containerPort string) (*Proxy, testcontainers.CustomizeRequestOption) {
+	proxy := &Proxy{Name: name}
+
+	return proxy, func(req *testcontainers.GenericContainerRequest) error {
+		proto, port := nat.SplitProtoPort(containerPort)
+		if proto != "tcp" {
+			return fmt.Errorf("only TCP ports can be routed through a proxy: %s", containerPort)
+		}
+
+		if _, err := strconv.Atoi(port); err != nil {
+			return fmt.Errorf("invalid container port: %s", containerPort)
+		}
+
+		alias := upstreamAliasPrefix + name
+
+		if !slices.Contains(req.Networks, c.network) {
+			req.Networks = append(req.Networks, c.network)
+		}
+
+		if req.NetworkAliases == nil {
+			req.NetworkAliases = make(map[string][]string)
+		}
+		req.NetworkAliases[c.network] = append(req.NetworkAliases[c.network], alias)
+
+		req.LifecycleHooks = append(req.LifecycleHooks, testcontainers.ContainerLifecycleHooks{
+			PostCreates: []testcontainers.ContainerHook{
+				func(ctx context.Context, _ testcontainers.Container) error {
+					// the proxy resolves the upstream address on each connection,
+					// so it can be created before the container is started.
+					p, err := c.CreateProxy(ctx, name, alias+":"+port)
+					if err != nil {
+						return err
+					}
+
+					*proxy = *p
+
+					return nil
+				},
+			},
+			PostTerminates: []testcontainers.ContainerHook{
+				func(ctx context.Context, _ testcontainers.Container) error {
+					if proxy.client == nil {
+						// the proxy was never created
+						return nil
+					}
+
+					if err := proxy.Delete(ctx); err != nil && !IsNotFound(err) {
+						return fmt.Errorf("delete proxy %s: %w", name, err)
+					}
+
+					return nil
+				},
+			},
+		})
+
+		return nil
+	}
+}
+
+// reserveProxyPort returns the first free port exposed for the proxies, and marks it as used.
+func (c *ToxiproxyContainer) reserveProxyPort() (int, error) {
+	c.proxyPortsMx.Lock()
+	defer c.proxyPortsMx.Unlock()
+
+	for _, port := range c.proxyPorts {
+		if !c.usedProxyPorts[port] {
+			c.usedProxyPorts[port] = true
+			return port, nil
+		}
+	}
+
+	return 0, errors.New("all the ports exposed for the proxies are used, see WithProxyPorts")
+}
+
+// releaseProxyPort marks a port exposed for the proxies as free.
+func (c *ToxiproxyContainer) releaseProxyPort(port int) {
+	c.proxyPortsMx.Lock()
+	defer c.proxyPortsMx.Unlock()
+
+	delete(c.usedProxyPorts, port)
+}
@@ -0,0 +1,160 @@
+package toxiproxy_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/docker/docker/api/types/network"
+	"github.com/docker/docker/client"
+	"github.com/stretchr/testify/require"
+
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/modules/toxiproxy"
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+const toxiproxyImage = "ghcr.io/shopify/toxiproxy:2.12.0"
+
+func TestToxiproxy(t *testing.T) {
+	ctx := context.Background()
+
+	ctr, err := toxiproxy.Run(ctx, toxiproxyImage, toxiproxy.WithProxyPorts(2))
+	testcontainers.CleanupContainer(t, ctr)
+	require.NoError(t, err)
+
+	// routeContainer {
+	proxy, route := ctr.Route("nginx", "80/tcp")
+
+	req := testcontainers.GenericContainerRequest{
+		ContainerRequest: testcontainers.ContainerRequest{
+			Image:        "nginx:alpine",
+			ExposedPorts: []string{"80/tcp"},
+			WaitingFor:   wait.ForListeningPort("80/tcp"),
+		},
+		Started: true,
+	}
+	require.NoError(t, route(&req))
+
+	nginx, err := testcontainers.GenericContainer(ctx, req)
+	testcontainers.CleanupContainer(t, nginx)
+	require.NoError(t, err)
+
+	// the container is reached through the proxy at proxy.Endpoint
+	get(t, "http://"+proxy.Endpoint)
+	// }
+
+	t.Run("client", func(t *testing.T) {
+		client, err := ctr.Client(ctx)
+		require.NoError(t, err)
+
+		version, err := client.Version(ctx)
+		require.NoError(t, err)
+		require.NotEmpty(t, version)
+
+		p, err := client.Proxy(ctx, "nginx")
+		require.NoError(t, err)
+		require.Equal(t, "0.0.0.0:8666", p.Listen)
+		require.Equal(t, "toxiproxy-upstream-nginx:80", p.Upstream)
+	})
+
+	t.Run("toxics", func(t *testing.T) {
+		// addToxic {
+		toxic, err := proxy.AddToxic(ctx, toxiproxy.Latency(time.Second, 0))
+		require.NoError(t, err)
+		// }
+
+		start := time.Now()
+		get(t, "http://"+proxy.Endpoint)
+		require.GreaterOrEqual(t, time.Since(start), time.Second)
+
+		// updateToxic {
+		toxic.Attributes["latency"] = 0
+		_, err = proxy.UpdateToxic(ctx, *toxic)
+		require.NoError(t, err)
+		// }
+
+		start = time.Now()
+		get(t, "http://"+proxy.Endpoint)
+		require.Less(t, time.Since(start), time.Second)
+
+		require.NoError(t, proxy.RemoveToxic(ctx, toxic.Name))
+	})
+
+	t.Run("disable", func(t *testing.T) {
+		require.NoError(t, proxy.Disable(ctx))
+
+		_, err := http.Get("http://" + proxy.Endpoint)
+		require.Error(t, err)
+
+		require.NoError(t, proxy.Enable(ctx))
+		get(t, "http://"+proxy.Endpoint)
+	})
+
+	t.Run("proxy-ports", func(t *testing.T) {
+		// the second and last proxy port is free
+		p, err := ctr.CreateProxy(ctx, "second", "toxiproxy-upstream-nginx:80")
+		require.NoError(t, err)
+
+		_, err = ctr.CreateProxy(ctx, "third", "toxiproxy-upstream-nginx:80")
+		require.Error(t, err)
+
+		get(t, "http://"+p.Endpoint)
+
+		require.NoError(t, p.Delete(ctx))
+
+		p, err = ctr.CreateProxy(ctx, "third", "toxiproxy-upstream-nginx:80")
+		require.NoError(t, err)
+		require.NoError(t, p.Delete(ctx))
+	})
+
+	t.Run("terminate-routed", func(t *testing.T) {
+		require.NoError(t, nginx.Terminate(ctx))
+
+		client, err := ctr.Client(ctx)
+		require.NoError(t, err)
+
+		_, err = client.Proxy(ctx, "nginx")
+		require.True(t, toxiproxy.IsNotFound(err))
+	})
+
+	t.Run("terminate", func(t *testing.T) {
+		networks, err := ctr.Networks(ctx)
+		require.NoError(t, err)
+		require.Len(t, networks, 1)
+
+		require.NoError(t, ctr.Terminate(ctx))
+
+		// the network created for the container is removed
+		cli, err := testcontainers.NewDockerClientWithOpts(ctx)
+		require.NoError(t, err)
+		defer cli.Close()
+
+		_, err = cli.NetworkInspect(ctx, networks[0], network.InspectOptions{})
+		require.True(t, client.IsErrNotFound(err))
+	})
+}
+
+func TestRoute_invalidPort(t *testing.T) {
+	ctr := &toxiproxy.ToxiproxyContainer{}
+
+	_, route := ctr.Route("dns", "53/udp")
+
+	err := route(&testcontainers.GenericContainerRequest{})
+	require.Error(t, err)
+}
+
+// get sends a GET request to the URL, expecting a successful response.
+func get(t *testing.T, url string) {
+	t.Helper()
+
+	resp, err := http.Get(url)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	_, err = io.Copy(io.Discard, resp.Body)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+}
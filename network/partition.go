@@ -0,0 +1,176 @@
+package network
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/testcontainers/testcontainers-go"
+)
+
+// Partition is a network partition of a set of containers attached to the same network,
+// splitting them into groups which can't reach each other, created with [NewPartition].
+type Partition struct {
+	network *testcontainers.DockerNetwork
+	groups  []*partitionGroup
+	healed  bool
+}
+
+// partitionGroup is a group of containers of a partition, attached to their own network.
+type partitionGroup struct {
+	network *testcontainers.DockerNetwork
+	members []*partitionMember
+	removed bool
+}
+
+// partitionMember is a container of a partition group.
+type partitionMember struct {
+	container   testcontainers.Container
+	aliases     []string // aliases of the container in the partitioned network
+	connected   bool     // whether the container is attached to the network of its group
+	partitioned bool     // whether the container was detached from the partitioned network
+}
+
+// NewPartition splits the given containers, attached to the network, into groups which can't reach
+// each other, to simulate a split-brain in a cluster. Each group is moved to a new network, where the
+// containers keep the aliases they had in the original network, so the containers of the same group
+// can still reach each other by their aliases. The containers not included in any group are not
+// modified, so they can't reach any of the grouped containers.
+//
+// The partition is healed by calling [Partition.Heal], which attaches the containers to the original
+// network again, with the same aliases, although they could get different IP addresses.
+func NewPartition(ctx context.Context, nw *testcontainers.DockerNetwork, groups ...[]testcontainers.Container) (*Partition, error) {
+	if len(groups) == 0 {
+		return nil, errors.New("at least one group of containers is required")
+	}
+
+	seen := map[string]bool{}
+	for _, group := range groups {
+		if len(group) == 0 {
+			return nil, errors.New("groups of containers must not be empty")
+		}
+
+		for _, c := range group {
+			id := c.GetContainerID()
+			if seen[id] {
+				return nil, fmt.Errorf("container %s is included in more than one group", id)
+			}
+			seen[id] = true
+		}
+	}
+
+	p := &Partition{network: nw}
+
+	for _, group := range groups {
+		if err := p.addGroup(ctx, group); err != nil {
+			return nil, errors.Join(err, p.Heal(ctx))
+		}
+	}
+
+	return p, nil
+}
+
+// addGroup moves a group of containers from the partitioned network to a new network.
+func (p *Partition) addGroup(ctx context.Context, containers []testcontainers.Container) error {
+	members := make([]*partitionMember, 0, len(containers))
+	for _, c := range containers {
+		aliases, err := c.NetworkAliases(ctx)
+		if err != nil {
+			return fmt.Errorf("network aliases: %w", err)
+		}
+
+		networkAliases, ok := aliases[p.network.Name]
+		if !ok {
+			return fmt.Errorf("container %s is not attached to network %s", c.GetContainerID(), p.network.Name)
+		}
+
+		members = append(members, &partitionMember{
+			container: c,
+			aliases:   withoutShortID(networkAliases, c.GetContainerID()),
+		})
+	}
+
+	nw, err := New(ctx)
+	if err != nil {
+		return fmt.Errorf("new network: %w", err)
+	}
+
+	group := &partitionGroup{network: nw, members: members}
+	p.groups = append(p.groups, group)
+
+	for _, m := range members {
+		// the container is attached to the new network first, so it's never left without networks.
+		if err := nw.Connect(ctx, m.container, m.aliases...); err != nil {
+			return err
+		}
+		m.connected = true
+
+		if err := p.network.Disconnect(ctx, m.container); err != nil {
+			return err
+		}
+		m.partitioned = true
+	}
+
+	return nil
+}
+
+// Heal attaches the containers of the partition to the original network again,
+// with the same aliases, and removes the networks of the groups.
+// It's safe to call it multiple times, as it does nothing once the partition is healed.
+func (p *Partition) Heal(ctx context.Context) error {
+	if p.healed {
+		return nil
+	}
+
+	var errs []error
+	for _, group := range p.groups {
+		for _, m := range group.members {
+			if m.partitioned {
+				if err := p.network.Connect(ctx, m.container, m.aliases...); err != nil {
+					errs = append(errs, err)
+					continue
+				}
+				m.partitioned = false
+			}
+
+			if m.connected {
+				if err := group.network.Disconnect(ctx, m.container); err != nil {
+					errs = append(errs, err)
+					continue
+				}
+				m.connected = false
+			}
+		}
+
+		if group.removed {
+			continue
+		}
+
+		if err := group.network.Remove(ctx); err != nil {
+			errs = append(errs, fmt.Errorf("remove network %s: %w", group.network.Name, err))
+			continue
+		}
+		group.removed = true
+	}
+
+	if err := errors.Join(errs...); err != nil {
+		return err
+	}
+
+	p.healed = true
+
+	return nil
+}
+
+// withoutShortID returns the aliases without the short ID of the container,
+// which Docker adds to the aliases of the container in every network.
+func withoutShortID(aliases []string, containerID string) []string {
+	if len(containerID) > 12 {
+		containerID = containerID[:12]
+	}
+
+	return slices.DeleteFunc(slices.Clone(aliases), func(alias string) bool {
+		return alias == containerID
+	})
+}
@@ -0,0 +1,149 @@
+package network_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/network"
+)
+
+func TestNetworkConnectDisconnect(t *testing.T) {
+	ctx := context.Background()
+
+	nw, err := network.New(ctx)
+	testcontainers.CleanupNetwork(t, nw)
+	require.NoError(t, err)
+
+	ctr, err := testcontainers.GenericContainer(ctx, testcontainers.GenericContainerRequest{
+		ContainerRequest: testcontainers.ContainerRequest{
+			Image: nginxAlpineImage,
+		},
+		Started: true,
+	})
+	testcontainers.CleanupContainer(t, ctr)
+	require.NoError(t, err)
+
+	// connectNetwork {
+	err = nw.Connect(ctx, ctr, "web")
+	// }
+	require.NoError(t, err)
+
+	networks, err := ctr.Networks(ctx)
+	require.NoError(t, err)
+	require.Contains(t, networks, nw.Name)
+
+	aliases, err := ctr.NetworkAliases(ctx)
+	require.NoError(t, err)
+	require.Contains(t, aliases[nw.Name], "web")
+
+	// disconnectNetwork {
+	err = nw.Disconnect(ctx, ctr)
+	// }
+	require.NoError(t, err)
+
+	networks, err = ctr.Networks(ctx)
+	require.NoError(t, err)
+	require.NotContains(t, networks, nw.Name)
+}
+
+func TestPartition(t *testing.T) {
+	ctx := context.Background()
+
+	nw, err := network.New(ctx)
+	testcontainers.CleanupNetwork(t, nw)
+	require.NoError(t, err)
+
+	run := func(alias string) testcontainers.Container {
+		t.Helper()
+
+		ctr, err := testcontainers.GenericContainer(ctx, testcontainers.GenericContainerRequest{
+			ContainerRequest: testcontainers.ContainerRequest{
+				Image: nginxAlpineImage,
+			},
+			Started: true,
+		})
+		testcontainers.CleanupContainer(t, ctr)
+		require.NoError(t, err)
+
+		require.NoError(t, nw.Connect(ctx, ctr, alias))
+
+		return ctr
+	}
+
+	node1 := run("node1")
+	node2 := run("node2")
+	node3 := run("node3")
+
+	reachable := func(from testcontainers.Container, alias string) bool {
+		t.Helper()
+
+		code, _, err := from.Exec(ctx, []string{"wget", "-q", "-T", "2", "-O", "/dev/null", "http://" + alias})
+		require.NoError(t, err)
+
+		return code == 0
+	}
+
+	// partitionNetwork {
+	partition, err := network.NewPartition(ctx, nw,
+		[]testcontainers.Container{node1},
+		[]testcontainers.Container{node2, node3},
+	)
+	require.NoError(t, err)
+	// }
+
+	require.False(t, reachable(node1, "node2"))
+	require.False(t, reachable(node2, "node1"))
+	require.True(t, reachable(node2, "node3"))
+
+	networks, err := node1.Networks(ctx)
+	require.NoError(t, err)
+	require.NotContains(t, networks, nw.Name)
+
+	// healPartition {
+	err = partition.Heal(ctx)
+	require.NoError(t, err)
+	// }
+
+	require.True(t, reachable(node1, "node2"))
+	require.True(t, reachable(node3, "node1"))
+
+	for _, ctr := range []testcontainers.Container{node1, node2, node3} {
+		networks, err := ctr.Networks(ctx)
+		require.NoError(t, err)
+		require.Contains(t, networks, nw.Name)
+
+		aliases, err := ctr.NetworkAliases(ctx)
+		require.NoError(t, err)
+		require.Len(t, aliases, 2, "the container must be attached to the default and the original networks only")
+	}
+
+	require.NoError(t, partition.Heal(ctx))
+}
+
+func TestPartition_invalidGroups(t *testing.T) {
+	ctx := context.Background()
+
+	p := testcontainers.NewFakeProvider(t)
+
+	req := testcontainers.GenericContainerRequest{
+		ContainerRequest: testcontainers.ContainerRequest{Image: nginxAlpineImage},
+		Started:          true,
+	}
+	require.NoError(t, p.Customize(&req))
+
+	ctr, err := testcontainers.GenericContainer(ctx, req)
+	testcontainers.CleanupContainer(t, ctr)
+	require.NoError(t, err)
+
+	_, err = network.NewPartition(ctx, nil)
+	require.EqualError(t, err, "at least one group of containers is required")
+
+	_, err = network.NewPartition(ctx, nil, []testcontainers.Container{})
+	require.EqualError(t, err, "groups of containers must not be empty")
+
+	_, err = network.NewPartition(ctx, nil, []testcontainers.Container{ctr}, []testcontainers.Container{ctr})
+	require.ErrorContains(t, err, "is included in more than one group")
+}
@@ -233,6 +233,18 @@ func WithLogConsumers(consumer ...LogConsumer) CustomizeRequestOption {
 	}
 }
 
+// WithStatsConsumers sets the stats consumers for a container
+func WithStatsConsumers(consumer ...StatsConsumer) CustomizeRequestOption {
+	return func(req *GenericContainerRequest) error {
+		if req.StatsConsumerCfg == nil {
+			req.StatsConsumerCfg = &StatsConsumerConfig{}
+		}
+
+		req.StatsConsumerCfg.Consumers = consumer
+		return nil
+	}
+}
+
 // Executable represents an executable command to be sent to a container, including options,
 // as part of the different lifecycle hooks.
 type Executable interface {
@@ -0,0 +1,322 @@
+// Package pool provides pools of identical containers, started once and shared by the tests of a package,
+// e.g. the parallel tests which need isolated instances of a database, but cannot afford starting one per test.
+package pool
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/testcontainers/testcontainers-go"
+)
+
+// ErrClosed is returned when a container is acquired from a closed pool.
+var ErrClosed = errors.New("pool is closed")
+
+// StartFunc starts a container of the pool, e.g. calling [testcontainers.GenericContainer]
+// or the Run function of a module. It's called concurrently to start the containers.
+type StartFunc[T testcontainers.Container] func(ctx context.Context) (T, error)
+
+// ResetFunc resets a released container to its initial state, e.g. truncating the tables of a database,
+// so it can be handed to another test. If it returns an error, the container is replaced with a fresh one.
+type ResetFunc[T testcontainers.Container] func(ctx context.Context, ctr T) error
+
+// Option is an option of a pool.
+type Option[T testcontainers.Container] func(*Pool[T])
+
+// WithReset sets the function resetting the released containers, and the timeout of each reset.
+// If the reset does not return in time, the container is terminated and replaced with a fresh one,
+// as if the reset failed. Without it, the released containers are terminated and replaced with fresh ones.
+func WithReset[T testcontainers.Container](reset ResetFunc[T], timeout time.Duration) Option[T] {
+	return func(p *Pool[T]) {
+		p.reset = reset
+		p.resetTimeout = timeout
+	}
+}
+
+// slot is a container of the pool, or the error starting it if it could not be replaced.
+type slot[T testcontainers.Container] struct {
+	ctr T
+	err error
+}
+
+// Pool is a pool of identical containers. The containers are handed to the tests by Acquire,
+// and released when the tests finish: they are reset and handed to the next tests, or replaced
+// with fresh ones, in the background.
+type Pool[T testcontainers.Container] struct {
+	start        StartFunc[T]
+	reset        ResetFunc[T]
+	resetTimeout time.Duration
+
+	// idle holds the containers which are not acquired, it has the capacity of the pool.
+	idle chan slot[T]
+
+	// done is closed when the pool is closed, waking up the tests waiting for a container.
+	done chan struct{}
+
+	mtx       sync.Mutex
+	closed    bool
+	releasing sync.WaitGroup
+}
+
+// New creates a pool of size containers, which are started concurrently with the start function.
+// If any of them fails to start, the started ones are terminated and an error is returned.
+// The pool is meant to be created in TestMain, and closed after running the tests.
+func New[T testcontainers.Container](ctx context.Context, size int, start StartFunc[T], opts ...Option[T]) (*Pool[T], error) {
+	if size <= 0 {
+		return nil, fmt.Errorf("invalid pool size %d", size)
+	}
+
+	p := &Pool[T]{
+		start: start,
+		idle:  make(chan slot[T], size),
+		done:  make(chan struct{}),
+	}
+
+	for _, opt := range opts {
+		opt(p)
+	}
+
+	if p.reset != nil && p.resetTimeout <= 0 {
+		return nil, fmt.Errorf("invalid reset timeout %s", p.resetTimeout)
+	}
+
+	var wg sync.WaitGroup
+	errs := make([]error, size)
+	for i := 0; i < size; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+
+			ctr, err := start(ctx)
+			if err != nil {
+				errs[i] = errors.Join(fmt.Errorf("start container: %w", err), testcontainers.TerminateContainer(ctr))
+				return
+			}
+
+			p.idle <- slot[T]{ctr: ctr}
+		}(i)
+	}
+	wg.Wait()
+
+	if err := errors.Join(errs...); err != nil {
+		return nil, errors.Join(err, p.Close(ctx))
+	}
+
+	return p, nil
+}
+
+// FromRequest returns a start function creating and starting a container from the request.
+// Each container is created from a copy of the request, so they can be started concurrently.
+// The readers of the files of the request are read once, and each container copies their content
+// from its own reader.
+func FromRequest(req testcontainers.GenericContainerRequest) StartFunc[testcontainers.Container] {
+	contents := make([][]byte, len(req.Files))
+	var readErr error
+	for i, f := range req.Files {
+		if f.Reader == nil {
+			continue
+		}
+
+		content, err := io.ReadAll(f.Reader)
+		if err != nil {
+			readErr = fmt.Errorf("read file %s: %w", f.ContainerFilePath, err)
+			break
+		}
+		contents[i] = content
+	}
+
+	return func(ctx context.Context) (testcontainers.Container, error) {
+		if readErr != nil {
+			return nil, readErr
+		}
+
+		r := req
+		r.Started = true
+
+		// the provider modifies the labels and appends to the slices of the request
+		r.Labels = make(map[string]string, len(req.Labels))
+		for k, v := range req.Labels {
+			r.Labels[k] = v
+		}
+		r.Networks = slices.Clone(req.Networks)
+		r.ExposedPorts = slices.Clone(req.ExposedPorts)
+		r.ImageSubstitutors = slices.Clone(req.ImageSubstitutors)
+		r.LifecycleHooks = slices.Clone(req.LifecycleHooks)
+
+		r.Files = slices.Clone(req.Files)
+		for i, f := range req.Files {
+			if f.Reader != nil {
+				r.Files[i].Reader = bytes.NewReader(contents[i])
+			}
+		}
+
+		return testcontainers.GenericContainer(ctx, r)
+	}
+}
+
+// Acquire hands a container of the pool to the test, waiting for one to be released if all of them
+// are acquired, until the deadline of the test, if any. The container is released when the test and
+// its subtests finish, so it must not be terminated by the test. The test fails if the pool is closed,
+// if no container is released in time, or if a container cannot be started.
+func (p *Pool[T]) Acquire(tb testing.TB) T {
+	tb.Helper()
+
+	return p.AcquireContext(context.Background(), tb)
+}
+
+// AcquireContext is like Acquire, but it also stops waiting for a container to be released
+// when the context is done, e.g. to bound the wait with [context.WithTimeout].
+func (p *Pool[T]) AcquireContext(ctx context.Context, tb testing.TB) T {
+	tb.Helper()
+
+	if t, ok := tb.(interface{ Deadline() (time.Time, bool) }); ok {
+		if deadline, ok := t.Deadline(); ok {
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithDeadline(ctx, deadline)
+			defer cancel()
+		}
+	}
+
+	ctr, err := p.acquire(ctx)
+	if err != nil {
+		tb.Fatalf("acquire container: %s", err)
+	}
+
+	tb.Cleanup(func() {
+		p.release(ctr)
+	})
+
+	return ctr
+}
+
+// acquire takes an idle container, starting a fresh one if the previous one could not be replaced.
+func (p *Pool[T]) acquire(ctx context.Context) (T, error) {
+	var zero T
+
+	p.mtx.Lock()
+	closed := p.closed
+	p.mtx.Unlock()
+
+	if closed {
+		return zero, ErrClosed
+	}
+
+	var s slot[T]
+	select {
+	case s = <-p.idle:
+	case <-p.done:
+		return zero, ErrClosed
+	case <-ctx.Done():
+		return zero, fmt.Errorf("wait for a released container: %w", ctx.Err())
+	}
+
+	if s.err == nil {
+		return s.ctr, nil
+	}
+
+	ctr, err := p.start(ctx)
+	if err != nil {
+		// keep the slot, so the next acquisition tries again
+		p.idle <- slot[T]{err: err}
+		return zero, errors.Join(fmt.Errorf("start container: %w", err), testcontainers.TerminateContainer(ctr))
+	}
+
+	return ctr, nil
+}
+
+// release hands the container back to the pool in the background, resetting it or replacing it
+// with a fresh one. The container is terminated if the pool is closed.
+func (p *Pool[T]) release(ctr T) {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+
+	if p.closed {
+		if err := testcontainers.TerminateContainer(ctr); err != nil {
+			testcontainers.Logger.Printf("🔥 Failed to terminate released container: %s", err)
+		}
+		return
+	}
+
+	p.releasing.Add(1)
+	go func() {
+		defer p.releasing.Done()
+
+		p.idle <- p.recycle(context.Background(), ctr)
+	}()
+}
+
+// recycle resets a released container, or replaces it with a fresh one if the pool has no reset
+// function or the reset fails.
+func (p *Pool[T]) recycle(ctx context.Context, ctr T) slot[T] {
+	if p.reset != nil {
+		err := p.resetContainer(ctx, ctr)
+		if err == nil {
+			return slot[T]{ctr: ctr}
+		}
+
+		testcontainers.Logger.Printf("🔥 Failed to reset container, replacing it: %s", err)
+	}
+
+	if err := testcontainers.TerminateContainer(ctr); err != nil {
+		testcontainers.Logger.Printf("🔥 Failed to terminate released container: %s", err)
+	}
+
+	fresh, err := p.start(ctx)
+	if err != nil {
+		return slot[T]{err: errors.Join(err, testcontainers.TerminateContainer(fresh))}
+	}
+
+	return slot[T]{ctr: fresh}
+}
+
+// resetContainer resets the container, giving up once the reset timeout expires, even if the reset function
+// ignores the context: the container is then terminated by the caller, which is expected to unblock it.
+func (p *Pool[T]) resetContainer(ctx context.Context, ctr T) error {
+	ctx, cancel := context.WithTimeout(ctx, p.resetTimeout)
+	defer cancel()
+
+	done := make(chan error, 1)
+	go func() {
+		done <- p.reset(ctx, ctr)
+	}()
+
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+		return fmt.Errorf("reset timed out after %s: %w", p.resetTimeout, ctx.Err())
+	}
+}
+
+// Close terminates the containers of the pool, after waiting for the released ones to be reset
+// or replaced. It's meant to be called at the end of TestMain, once all the containers are released:
+// the containers released afterwards are terminated, and the tests still waiting for a container fail.
+func (p *Pool[T]) Close(ctx context.Context) error {
+	p.mtx.Lock()
+	if !p.closed {
+		p.closed = true
+		close(p.done)
+	}
+	p.mtx.Unlock()
+
+	p.releasing.Wait()
+
+	var errs []error
+	for {
+		select {
+		case s := <-p.idle:
+			if err := testcontainers.TerminateContainer(s.ctr, testcontainers.StopContext(ctx)); err != nil {
+				errs = append(errs, err)
+			}
+		default:
+			return errors.Join(errs...)
+		}
+	}
+}
@@ -0,0 +1,371 @@
+package pool_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"runtime"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/testcontainers/testcontainers-go"
+	tcexec "github.com/testcontainers/testcontainers-go/exec"
+	"github.com/testcontainers/testcontainers-go/pool"
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+// fakeRequest returns a request of a container of the fake provider.
+func fakeRequest(p *testcontainers.FakeProvider) testcontainers.GenericContainerRequest {
+	return testcontainers.GenericContainerRequest{
+		ContainerRequest: testcontainers.ContainerRequest{
+			Image:        "redis:7",
+			ExposedPorts: []string{"6379/tcp"},
+			Labels:       map[string]string{"app": "pool"},
+		},
+		ProviderType: p.ProviderType(),
+	}
+}
+
+// destroyed returns true if the fake container was terminated.
+func destroyed(c *testcontainers.FakeContainer) bool {
+	lifecycle := c.Lifecycle()
+	return len(lifecycle) > 0 && lifecycle[len(lifecycle)-1] == "destroy"
+}
+
+// recorderTB records the failure of an acquisition, stopping the goroutine as the tests do,
+// and the cleanup functions registered by the acquisition.
+type recorderTB struct {
+	testing.TB
+
+	failure  string
+	cleanups []func()
+}
+
+func (r *recorderTB) Helper() {}
+
+func (r *recorderTB) Fatalf(format string, args ...any) {
+	r.failure = fmt.Sprintf(format, args...)
+	runtime.Goexit()
+}
+
+func (r *recorderTB) Cleanup(f func()) {
+	r.cleanups = append(r.cleanups, f)
+}
+
+// cleanup runs the cleanup functions, releasing the acquired container.
+func (r *recorderTB) cleanup() {
+	for _, f := range r.cleanups {
+		f()
+	}
+}
+
+// acquireAsync acquires a container in a goroutine, returning a channel closed once it's acquired or it failed.
+func acquireAsync(ctx context.Context, pl *pool.Pool[testcontainers.Container], tb *recorderTB) <-chan struct{} {
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		pl.AcquireContext(ctx, tb)
+	}()
+
+	return done
+}
+
+func TestPool(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("reset", func(t *testing.T) {
+		p := testcontainers.NewFakeProvider(t)
+
+		var resets atomic.Int32
+		reset := func(_ context.Context, _ testcontainers.Container) error {
+			resets.Add(1)
+			return nil
+		}
+
+		pl, err := pool.New(ctx, 1, pool.FromRequest(fakeRequest(p)), pool.WithReset(reset, time.Minute))
+		require.NoError(t, err)
+
+		var ids []string
+		for i := 0; i < 3; i++ {
+			t.Run("acquire", func(t *testing.T) {
+				ids = append(ids, pl.Acquire(t).GetContainerID())
+			})
+		}
+
+		require.NoError(t, pl.Close(ctx))
+
+		require.Equal(t, []string{ids[0], ids[0], ids[0]}, ids)
+		require.Equal(t, int32(3), resets.Load())
+		require.Len(t, p.Containers(), 1)
+		require.True(t, destroyed(p.Containers()[0]))
+	})
+
+	t.Run("replace", func(t *testing.T) {
+		p := testcontainers.NewFakeProvider(t)
+
+		pl, err := pool.New(ctx, 1, pool.FromRequest(fakeRequest(p)))
+		require.NoError(t, err)
+
+		var ids []string
+		for i := 0; i < 2; i++ {
+			t.Run("acquire", func(t *testing.T) {
+				ids = append(ids, pl.Acquire(t).GetContainerID())
+			})
+		}
+
+		require.NoError(t, pl.Close(ctx))
+
+		require.NotEqual(t, ids[0], ids[1])
+		require.Len(t, p.Containers(), 3)
+		for _, c := range p.Containers() {
+			require.True(t, destroyed(c))
+		}
+	})
+
+	t.Run("reset-failure", func(t *testing.T) {
+		p := testcontainers.NewFakeProvider(t)
+
+		reset := func(_ context.Context, _ testcontainers.Container) error {
+			return errors.New("reset failed")
+		}
+
+		pl, err := pool.New(ctx, 1, pool.FromRequest(fakeRequest(p)), pool.WithReset(reset, time.Minute))
+		require.NoError(t, err)
+
+		t.Run("acquire", func(t *testing.T) {
+			pl.Acquire(t)
+		})
+
+		require.NoError(t, pl.Close(ctx))
+		require.Len(t, p.Containers(), 2)
+		require.True(t, destroyed(p.Containers()[0]))
+	})
+
+	t.Run("reset-timeout", func(t *testing.T) {
+		p := testcontainers.NewFakeProvider(t)
+
+		// the reset hangs, ignoring the context, until the end of the test
+		unblock := make(chan struct{})
+		defer close(unblock)
+		reset := func(_ context.Context, _ testcontainers.Container) error {
+			<-unblock
+			return nil
+		}
+
+		pl, err := pool.New(ctx, 1, pool.FromRequest(fakeRequest(p)), pool.WithReset(reset, 50*time.Millisecond))
+		require.NoError(t, err)
+
+		var ids []string
+		for i := 0; i < 2; i++ {
+			t.Run("acquire", func(t *testing.T) {
+				ids = append(ids, pl.Acquire(t).GetContainerID())
+			})
+		}
+
+		require.NoError(t, pl.Close(ctx))
+
+		// the container which could not be reset in time was replaced
+		require.NotEqual(t, ids[0], ids[1])
+		require.Len(t, p.Containers(), 3)
+		for _, c := range p.Containers() {
+			require.True(t, destroyed(c))
+		}
+	})
+
+	t.Run("start-failure", func(t *testing.T) {
+		p := testcontainers.NewFakeProvider(t)
+
+		var starts atomic.Int32
+		start := func(ctx context.Context) (testcontainers.Container, error) {
+			if starts.Add(1) == 2 {
+				return nil, errors.New("start failed")
+			}
+			return pool.FromRequest(fakeRequest(p))(ctx)
+		}
+
+		_, err := pool.New(ctx, 3, start)
+		require.ErrorContains(t, err, "start failed")
+
+		// the started containers are terminated
+		require.Len(t, p.Containers(), 2)
+		for _, c := range p.Containers() {
+			require.True(t, destroyed(c))
+		}
+	})
+
+	t.Run("parallel", func(t *testing.T) {
+		p := testcontainers.NewFakeProvider(t)
+
+		var mtx sync.Mutex
+		acquired := map[string]bool{}
+		reset := func(_ context.Context, c testcontainers.Container) error {
+			mtx.Lock()
+			defer mtx.Unlock()
+
+			delete(acquired, c.GetContainerID())
+			return nil
+		}
+
+		pl, err := pool.New(ctx, 2, pool.FromRequest(fakeRequest(p)), pool.WithReset(reset, time.Minute))
+		require.NoError(t, err)
+
+		t.Run("group", func(t *testing.T) {
+			for i := 0; i < 6; i++ {
+				t.Run("acquire", func(t *testing.T) {
+					t.Parallel()
+
+					id := pl.Acquire(t).GetContainerID()
+
+					mtx.Lock()
+					defer mtx.Unlock()
+
+					// a container is never handed to two tests at once
+					require.False(t, acquired[id])
+					acquired[id] = true
+				})
+			}
+		})
+
+		require.NoError(t, pl.Close(ctx))
+		require.Len(t, p.Containers(), 2)
+	})
+
+	t.Run("acquire-timeout", func(t *testing.T) {
+		p := testcontainers.NewFakeProvider(t)
+
+		pl, err := pool.New(ctx, 1, pool.FromRequest(fakeRequest(p)))
+		require.NoError(t, err)
+
+		holder := &recorderTB{}
+		<-acquireAsync(ctx, pl, holder)
+		require.Empty(t, holder.failure)
+
+		timeoutCtx, cancel := context.WithTimeout(ctx, 50*time.Millisecond)
+		defer cancel()
+
+		waiter := &recorderTB{}
+		<-acquireAsync(timeoutCtx, pl, waiter)
+		require.Contains(t, waiter.failure, context.DeadlineExceeded.Error())
+
+		holder.cleanup()
+		require.NoError(t, pl.Close(ctx))
+	})
+
+	t.Run("close-wakes-waiters", func(t *testing.T) {
+		p := testcontainers.NewFakeProvider(t)
+
+		pl, err := pool.New(ctx, 1, pool.FromRequest(fakeRequest(p)))
+		require.NoError(t, err)
+
+		holder := &recorderTB{}
+		<-acquireAsync(ctx, pl, holder)
+
+		waiter := &recorderTB{}
+		waiting := acquireAsync(ctx, pl, waiter)
+
+		require.NoError(t, pl.Close(ctx))
+
+		select {
+		case <-waiting:
+			require.Contains(t, waiter.failure, pool.ErrClosed.Error())
+		case <-time.After(5 * time.Second):
+			t.Fatal("the waiting test was not woken up by Close")
+		}
+
+		// the container released after closing the pool is terminated
+		holder.cleanup()
+		require.True(t, destroyed(p.Containers()[0]))
+	})
+
+	t.Run("files", func(t *testing.T) {
+		p := testcontainers.NewFakeProvider(t)
+
+		req := fakeRequest(p)
+		req.Files = []testcontainers.ContainerFile{
+			{Reader: strings.NewReader("maxmemory 64mb"), ContainerFilePath: "/etc/redis.conf", FileMode: 0o644},
+		}
+
+		pl, err := pool.New(ctx, 3, pool.FromRequest(req))
+		require.NoError(t, err)
+
+		// every container gets the content of the file, although the containers are started concurrently
+		for _, c := range p.Containers() {
+			r, err := c.CopyFileFromContainer(ctx, "/etc/redis.conf")
+			require.NoError(t, err)
+			content, err := io.ReadAll(r)
+			require.NoError(t, err)
+			require.Equal(t, "maxmemory 64mb", string(content))
+		}
+
+		require.NoError(t, pl.Close(ctx))
+	})
+
+	t.Run("invalid-size", func(t *testing.T) {
+		_, err := pool.New(ctx, 0, pool.FromRequest(testcontainers.GenericContainerRequest{}))
+		require.EqualError(t, err, "invalid pool size 0")
+	})
+
+	t.Run("invalid-reset-timeout", func(t *testing.T) {
+		reset := func(_ context.Context, _ testcontainers.Container) error {
+			return nil
+		}
+
+		_, err := pool.New(ctx, 1, pool.FromRequest(testcontainers.GenericContainerRequest{}), pool.WithReset(reset, 0))
+		require.EqualError(t, err, "invalid reset timeout 0s")
+	})
+}
+
+func TestRedisPool(t *testing.T) {
+	ctx := context.Background()
+
+	// createPool {
+	redisPool, err := pool.New(ctx, 2, pool.FromRequest(testcontainers.GenericContainerRequest{
+		ContainerRequest: testcontainers.ContainerRequest{
+			Image:        "redis:7-alpine",
+			ExposedPorts: []string{"6379/tcp"},
+			WaitingFor:   wait.ForLog("Ready to accept connections"),
+		},
+	}), pool.WithReset(func(ctx context.Context, ctr testcontainers.Container) error {
+		// remove the keys written by the previous test
+		code, _, err := ctr.Exec(ctx, []string{"redis-cli", "FLUSHALL"})
+		if err != nil {
+			return err
+		}
+		if code != 0 {
+			return fmt.Errorf("flush all: exit code %d", code)
+		}
+		return nil
+	}, time.Minute))
+	// }
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, redisPool.Close(ctx))
+	})
+
+	for i := 0; i < 4; i++ {
+		t.Run("set-key", func(t *testing.T) {
+			// acquireContainer {
+			t.Parallel()
+
+			ctr := redisPool.Acquire(t)
+			// }
+
+			// the key written by the previous test was removed by the reset
+			_, out, err := ctr.Exec(ctx, []string{"redis-cli", "EXISTS", "key"}, tcexec.Multiplexed())
+			require.NoError(t, err)
+			exists, err := io.ReadAll(out)
+			require.NoError(t, err)
+			require.Equal(t, "0\n", string(exists))
+
+			code, _, err := ctr.Exec(ctx, []string{"redis-cli", "SET", "key", "value"})
+			require.NoError(t, err)
+			require.Zero(t, code)
+		})
+	}
+}
@@ -0,0 +1,238 @@
+package testcontainers
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/docker/go-connections/nat"
+)
+
+// maxHostPortReservationAttempts is the number of free host ports asked to the
+// operating system before giving up on reserving a host port.
+const maxHostPortReservationAttempts = 10
+
+var (
+	// reservedHostPortsMx protects the host ports reserved by the current process,
+	// so containers created in parallel are never bound to the same host port.
+	reservedHostPortsMx sync.Mutex
+	reservedHostPorts   = map[nat.Port]struct{}{}
+)
+
+// WithReservedHostPort reserves a free host port and binds the given container port to it,
+// e.g. "9092/tcp", defaulting to TCP if the protocol is not set. It's meant for services that
+// must advertise the host port they are reachable on, which has to be known before the container
+// is created, e.g. the advertised listeners of Kafka or the redirect URIs of an OAuth server.
+//
+// The reserved host port is stored in [ContainerRequest.ReservedHostPorts], and the placeholders
+// {{hostPort "9092/tcp"}} in the values of [ContainerRequest.Env] and in the content of
+// [ContainerRequest.Files] are replaced with it when the container is created,
+// regardless of the order of the customizers.
+//
+// A host port is never reserved twice by the same process, so it's safe to use it with
+// [ParallelContainers], and it's released when the container is terminated, or when
+// [GenericContainer] fails to create it. The port is free
+// on the host when it's reserved, but another process could still take it before the container
+// is started, and it's reserved on the local host, so it might not be free on a remote Docker host.
+func WithReservedHostPort(containerPort string) CustomizeRequestOption {
+	return func(req *GenericContainerRequest) error {
+		proto, port := nat.SplitProtoPort(containerPort)
+		cp, err := nat.NewPort(proto, port)
+		if err != nil {
+			return fmt.Errorf("invalid container port %q: %w", containerPort, err)
+		}
+
+		if _, ok := req.ReservedHostPorts[string(cp)]; ok {
+			// already reserved by a previous customizer
+			return nil
+		}
+
+		hostPort, err := reserveHostPort(cp.Proto())
+		if err != nil {
+			return fmt.Errorf("reserve host port for %s: %w", cp, err)
+		}
+
+		if req.ReservedHostPorts == nil {
+			req.ReservedHostPorts = map[string]string{}
+		}
+		req.ReservedHostPorts[string(cp)] = hostPort
+
+		// replace any random binding of the container port
+		exposedPorts := make([]string, 0, len(req.ExposedPorts)+1)
+		for _, p := range req.ExposedPorts {
+			if p == cp.Port() || p == string(cp) {
+				continue
+			}
+
+			exposedPorts = append(exposedPorts, p)
+		}
+		req.ExposedPorts = append(exposedPorts, hostPort+":"+string(cp))
+
+		reserved, _ := nat.NewPort(cp.Proto(), hostPort)
+		req.LifecycleHooks = append(req.LifecycleHooks, ContainerLifecycleHooks{
+			PostTerminates: []ContainerHook{
+				func(_ context.Context, _ Container) error {
+					releaseHostPort(reserved)
+					return nil
+				},
+			},
+		})
+
+		return nil
+	}
+}
+
+// reserveHostPort returns a free host port for the given protocol,
+// which is not reserved yet by the current process, and reserves it.
+func reserveHostPort(proto string) (string, error) {
+	reservedHostPortsMx.Lock()
+	defer reservedHostPortsMx.Unlock()
+
+	for i := 0; i < maxHostPortReservationAttempts; i++ {
+		hostPort, err := freeHostPort(proto)
+		if err != nil {
+			return "", err
+		}
+
+		p, err := nat.NewPort(proto, strconv.Itoa(hostPort))
+		if err != nil {
+			return "", err
+		}
+
+		if _, ok := reservedHostPorts[p]; ok {
+			continue
+		}
+
+		reservedHostPorts[p] = struct{}{}
+
+		return p.Port(), nil
+	}
+
+	return "", fmt.Errorf("no free %s host port found after %d attempts", proto, maxHostPortReservationAttempts)
+}
+
+// releaseHostPort releases a host port reserved by reserveHostPort.
+func releaseHostPort(p nat.Port) {
+	reservedHostPortsMx.Lock()
+	defer reservedHostPortsMx.Unlock()
+
+	delete(reservedHostPorts, p)
+}
+
+// releaseReservedHostPorts releases the host ports reserved for the request.
+func (c *ContainerRequest) releaseReservedHostPorts() {
+	for containerPort, hostPort := range c.ReservedHostPorts {
+		proto, _ := nat.SplitProtoPort(containerPort)
+		p, err := nat.NewPort(proto, hostPort)
+		if err != nil {
+			continue
+		}
+
+		releaseHostPort(p)
+	}
+}
+
+// freeHostPort asks the operating system for a free host port for the given protocol.
+func freeHostPort(proto string) (int, error) {
+	switch proto {
+	case "tcp":
+		l, err := net.Listen("tcp", ":0")
+		if err != nil {
+			return 0, err
+		}
+		defer l.Close()
+
+		return l.Addr().(*net.TCPAddr).Port, nil
+	case "udp":
+		c, err := net.ListenPacket("udp", ":0")
+		if err != nil {
+			return 0, err
+		}
+		defer c.Close()
+
+		return c.LocalAddr().(*net.UDPAddr).Port, nil
+	default:
+		return 0, fmt.Errorf("unsupported protocol %s", proto)
+	}
+}
+
+// hostPortPlaceholders returns the placeholders of the reserved host ports and their values.
+// For TCP ports, the placeholder without the protocol is also returned.
+func hostPortPlaceholders(reservedHostPorts map[string]string) []string {
+	oldnew := make([]string, 0, len(reservedHostPorts)*4)
+	for containerPort, hostPort := range reservedHostPorts {
+		oldnew = append(oldnew, `{{hostPort "`+containerPort+`"}}`, hostPort)
+
+		if port, ok := strings.CutSuffix(containerPort, "/tcp"); ok {
+			oldnew = append(oldnew, `{{hostPort "`+port+`"}}`, hostPort)
+		}
+	}
+
+	return oldnew
+}
+
+// expandReservedHostPorts replaces the placeholders of the reserved host ports in the
+// values of the environment variables and in the content of the files of the request.
+// Files read from the host that contain any placeholder are copied from memory instead.
+func (c *ContainerRequest) expandReservedHostPorts() error {
+	if len(c.ReservedHostPorts) == 0 {
+		return nil
+	}
+
+	replacer := strings.NewReplacer(hostPortPlaceholders(c.ReservedHostPorts)...)
+
+	if len(c.Env) > 0 {
+		env := make(map[string]string, len(c.Env))
+		for k, v := range c.Env {
+			env[k] = replacer.Replace(v)
+		}
+		c.Env = env
+	}
+
+	if len(c.Files) == 0 {
+		return nil
+	}
+
+	files := make([]ContainerFile, len(c.Files))
+	for i, f := range c.Files {
+		var content []byte
+		switch {
+		case f.Reader != nil:
+			bs, err := io.ReadAll(f.Reader)
+			if err != nil {
+				return fmt.Errorf("read file %s: %w", f.ContainerFilePath, err)
+			}
+			content = bs
+		default:
+			fi, err := os.Stat(f.HostFilePath)
+			if err != nil || fi.IsDir() {
+				// directories are copied as they are, and missing files fail when copied.
+				files[i] = f
+				continue
+			}
+
+			bs, err := os.ReadFile(f.HostFilePath)
+			if err != nil {
+				return fmt.Errorf("read file %s: %w", f.HostFilePath, err)
+			}
+
+			if !bytes.Contains(bs, []byte("{{hostPort ")) {
+				files[i] = f
+				continue
+			}
+			content = bs
+		}
+
+		f.Reader = strings.NewReader(replacer.Replace(string(content)))
+		files[i] = f
+	}
+	c.Files = files
+
+	return nil
+}
@@ -0,0 +1,198 @@
+package testcontainers
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/docker/go-connections/nat"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithReservedHostPort(t *testing.T) {
+	req := GenericContainerRequest{
+		ContainerRequest: ContainerRequest{
+			ExposedPorts: []string{"9092", "8080/tcp"},
+		},
+	}
+
+	require.NoError(t, WithReservedHostPort("9092").Customize(&req))
+	require.NoError(t, WithReservedHostPort("9092/tcp").Customize(&req))
+	require.NoError(t, WithReservedHostPort("53/udp").Customize(&req))
+
+	require.Len(t, req.ReservedHostPorts, 2)
+	hostPort := req.ReservedHostPorts["9092/tcp"]
+	require.NotEmpty(t, hostPort)
+	udpPort := req.ReservedHostPorts["53/udp"]
+	require.NotEmpty(t, udpPort)
+
+	require.Equal(t, []string{"8080/tcp", hostPort + ":9092/tcp", udpPort + ":53/udp"}, req.ExposedPorts)
+	require.Len(t, req.LifecycleHooks, 2)
+
+	t.Run("invalid-port", func(t *testing.T) {
+		err := WithReservedHostPort("kafka").Customize(&GenericContainerRequest{})
+		require.Error(t, err)
+	})
+
+	t.Run("release", func(t *testing.T) {
+		p := nat.Port(hostPort + "/tcp")
+
+		reservedHostPortsMx.Lock()
+		_, ok := reservedHostPorts[p]
+		reservedHostPortsMx.Unlock()
+		require.True(t, ok)
+
+		for _, hooks := range req.LifecycleHooks {
+			for _, hook := range hooks.PostTerminates {
+				require.NoError(t, hook(context.Background(), nil))
+			}
+		}
+
+		reservedHostPortsMx.Lock()
+		_, ok = reservedHostPorts[p]
+		reservedHostPortsMx.Unlock()
+		require.False(t, ok)
+	})
+}
+
+func TestWithReservedHostPort_createFailure(t *testing.T) {
+	p := NewFakeProvider(t)
+
+	req := GenericContainerRequest{
+		ContainerRequest: ContainerRequest{
+			Image: "kafka:3",
+			LifecycleHooks: []ContainerLifecycleHooks{
+				{
+					PreCreates: []ContainerRequestHook{
+						func(_ context.Context, _ ContainerRequest) error {
+							return errors.New("create failed")
+						},
+					},
+				},
+			},
+		},
+		ProviderType: p.ProviderType(),
+	}
+	require.NoError(t, WithReservedHostPort("9092/tcp").Customize(&req))
+
+	hostPort := nat.Port(req.ReservedHostPorts["9092/tcp"] + "/tcp")
+
+	c, err := GenericContainer(context.Background(), req)
+	require.ErrorContains(t, err, "create failed")
+	require.Nil(t, c)
+
+	// the host port is released, as the container was not created
+	reservedHostPortsMx.Lock()
+	_, ok := reservedHostPorts[hostPort]
+	reservedHostPortsMx.Unlock()
+	require.False(t, ok)
+}
+
+func TestWithReservedHostPort_parallel(t *testing.T) {
+	const count = 50
+
+	var mtx sync.Mutex
+	hostPorts := map[string]struct{}{}
+
+	var wg sync.WaitGroup
+	for i := 0; i < count; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			req := GenericContainerRequest{}
+			require.NoError(t, WithReservedHostPort("80/tcp").Customize(&req))
+
+			mtx.Lock()
+			defer mtx.Unlock()
+			hostPorts[req.ReservedHostPorts["80/tcp"]] = struct{}{}
+		}()
+	}
+	wg.Wait()
+
+	require.Len(t, hostPorts, count)
+}
+
+func TestExpandReservedHostPorts(t *testing.T) {
+	hostFile := filepath.Join(t.TempDir(), "server.properties")
+	require.NoError(t, os.WriteFile(hostFile, []byte(`listeners=PLAINTEXT://localhost:{{hostPort "9092"}}`), 0o644))
+
+	plainFile := filepath.Join(t.TempDir(), "plain.txt")
+	require.NoError(t, os.WriteFile(plainFile, []byte("plain"), 0o644))
+
+	env := map[string]string{
+		"ADVERTISED": `localhost:{{hostPort "9092/tcp"}}`,
+		"UNKNOWN":    `{{hostPort "1234"}}`,
+	}
+
+	req := ContainerRequest{
+		Env:               env,
+		ReservedHostPorts: map[string]string{"9092/tcp": "49152", "53/udp": "49153"},
+		Files: []ContainerFile{
+			{Reader: strings.NewReader(`dns={{hostPort "53/udp"}}`), ContainerFilePath: "/dns.conf"},
+			{HostFilePath: hostFile, ContainerFilePath: "/server.properties"},
+			{HostFilePath: plainFile, ContainerFilePath: "/plain.txt"},
+		},
+	}
+
+	require.NoError(t, req.expandReservedHostPorts())
+
+	require.Equal(t, map[string]string{
+		"ADVERTISED": "localhost:49152",
+		"UNKNOWN":    `{{hostPort "1234"}}`,
+	}, req.Env)
+	require.Equal(t, `localhost:{{hostPort "9092/tcp"}}`, env["ADVERTISED"], "the original env must not be modified")
+
+	readFile := func(f ContainerFile) string {
+		t.Helper()
+
+		require.NotNil(t, f.Reader)
+		bs, err := io.ReadAll(f.Reader)
+		require.NoError(t, err)
+
+		return string(bs)
+	}
+
+	require.Equal(t, "dns=49153", readFile(req.Files[0]))
+	require.Equal(t, "listeners=PLAINTEXT://localhost:49152", readFile(req.Files[1]))
+	require.Nil(t, req.Files[2].Reader)
+}
+
+func TestReservedHostPort(t *testing.T) {
+	ctx := context.Background()
+
+	// reservedHostPort {
+	req := GenericContainerRequest{
+		ContainerRequest: ContainerRequest{
+			Image: nginxAlpineImage,
+			Env: map[string]string{
+				"PUBLIC_URL": `http://localhost:{{hostPort "80/tcp"}}`,
+			},
+		},
+		ProviderType: providerType,
+		Started:      true,
+	}
+
+	err := WithReservedHostPort(nginxDefaultPort).Customize(&req)
+	require.NoError(t, err)
+
+	ctr, err := GenericContainer(ctx, req)
+	CleanupContainer(t, ctr)
+	require.NoError(t, err)
+	// }
+
+	hostPort := req.ReservedHostPorts[nginxDefaultPort]
+
+	port, err := ctr.MappedPort(ctx, nginxDefaultPort)
+	require.NoError(t, err)
+	require.Equal(t, hostPort, port.Port())
+
+	inspect, err := ctr.Inspect(ctx)
+	require.NoError(t, err)
+	require.Contains(t, inspect.Config.Env, "PUBLIC_URL=http://localhost:"+hostPort)
+}
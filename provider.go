@@ -24,8 +24,9 @@ type (
 
 	// GenericProviderOptions defines options applicable to all providers
 	GenericProviderOptions struct {
-		Logger         Logging
-		DefaultNetwork string
+		Logger               Logging
+		DefaultNetwork       string
+		PullProgressListener PullProgressListener // receives the progress of the image pulls, defaults to logging summaries to the Logger
 	}
 
 	// GenericProviderOption defines a common interface to modify GenericProviderOptions
@@ -123,6 +124,10 @@ func (t ProviderType) GetProvider(opts ...GenericProviderOption) (GenericProvide
 			return nil, fmt.Errorf("%w, failed to create Docker provider", err)
 		}
 		return provider, nil
+	default:
+		if provider, ok := lookupFakeProvider(pt); ok {
+			return provider, nil
+		}
 	}
 	return nil, errors.New("unknown provider")
 }
@@ -0,0 +1,125 @@
+package testcontainers
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/docker/docker/api/types"
+)
+
+// ErrImageNotPresent is returned by the PullNever pull policy when the image does not exist locally.
+var ErrImageNotPresent = errors.New("image not present locally and the pull policy forbids pulling it")
+
+// pulledImages holds the time of the last pull of the images pulled in this session, by image name.
+var pulledImages sync.Map
+
+// pullPolicyInterface {
+
+// PullPolicy decides whether the image of a container is pulled before creating the container.
+type PullPolicy interface {
+	// ShouldPull returns true if the image must be pulled, given its local image,
+	// which is nil if the image does not exist locally for the requested platform.
+	ShouldPull(image string, local *types.ImageInspect) (bool, error)
+}
+
+// }
+
+// PullPolicyFunc is a shorthand to implement the PullPolicy interface.
+type PullPolicyFunc func(image string, local *types.ImageInspect) (bool, error)
+
+// ShouldPull implements PullPolicy.
+func (f PullPolicyFunc) ShouldPull(image string, local *types.ImageInspect) (bool, error) {
+	return f(image, local)
+}
+
+// PullAlways returns a pull policy which always pulls the image, even if it exists locally.
+func PullAlways() PullPolicy {
+	return PullPolicyFunc(func(_ string, _ *types.ImageInspect) (bool, error) {
+		return true, nil
+	})
+}
+
+// PullIfMissing returns a pull policy which pulls the image only if it does not exist locally.
+// It's the default pull policy.
+func PullIfMissing() PullPolicy {
+	return PullPolicyFunc(func(_ string, local *types.ImageInspect) (bool, error) {
+		return local == nil, nil
+	})
+}
+
+// PullNever returns a pull policy which never pulls the image, failing fast with
+// ErrImageNotPresent if the image does not exist locally, e.g. in offline environments.
+func PullNever() PullPolicy {
+	return PullPolicyFunc(func(_ string, local *types.ImageInspect) (bool, error) {
+		if local == nil {
+			return false, ErrImageNotPresent
+		}
+
+		return false, nil
+	})
+}
+
+// PullMaxAge returns a pull policy which pulls the image if it does not exist locally,
+// or if the local image is older than the given age, e.g. to refresh images using the
+// latest tag once a day. The age of the local image is the time since it was created,
+// tagged, or pulled in this session, whichever is the most recent, so an image is pulled
+// at most once per age in a session, even if its creation time is older than the age.
+func PullMaxAge(age time.Duration) PullPolicy {
+	return PullPolicyFunc(func(image string, local *types.ImageInspect) (bool, error) {
+		if local == nil {
+			return true, nil
+		}
+
+		var updated time.Time
+		if created, err := time.Parse(time.RFC3339Nano, local.Created); err == nil {
+			updated = created
+		}
+
+		if local.Metadata.LastTagTime.After(updated) {
+			updated = local.Metadata.LastTagTime
+		}
+
+		if pulled, ok := pulledImages.Load(image); ok && pulled.(time.Time).After(updated) {
+			updated = pulled.(time.Time)
+		}
+
+		return time.Since(updated) > age, nil
+	})
+}
+
+// WithPullPolicy sets the pull policy of the container image, overriding the default one.
+func WithPullPolicy(policy PullPolicy) CustomizeRequestOption {
+	return func(req *GenericContainerRequest) error {
+		req.PullPolicy = policy
+
+		return nil
+	}
+}
+
+// ParsePullPolicy returns the pull policy with the given name, as used in the pull.policy property
+// of the configuration: "always", "missing", "never", or "max-age=<duration>", e.g. "max-age=24h".
+// An empty name returns the default pull policy, PullIfMissing.
+func ParsePullPolicy(name string) (PullPolicy, error) {
+	switch name = strings.TrimSpace(name); name {
+	case "", "missing":
+		return PullIfMissing(), nil
+	case "always":
+		return PullAlways(), nil
+	case "never":
+		return PullNever(), nil
+	}
+
+	if age, ok := strings.CutPrefix(name, "max-age="); ok {
+		d, err := time.ParseDuration(age)
+		if err != nil {
+			return nil, fmt.Errorf("invalid max age %q of pull policy: %w", age, err)
+		}
+
+		return PullMaxAge(d), nil
+	}
+
+	return nil, fmt.Errorf("unknown pull policy %q", name)
+}
@@ -0,0 +1,123 @@
+package testcontainers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/image"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPullPolicies(t *testing.T) {
+	now := time.Now()
+
+	localImage := func(created time.Time) *types.ImageInspect {
+		return &types.ImageInspect{Created: created.Format(time.RFC3339Nano)}
+	}
+
+	tests := []struct {
+		name     string
+		policy   PullPolicy
+		local    *types.ImageInspect
+		expected bool
+		err      error
+	}{
+		{name: "always/missing", policy: PullAlways(), expected: true},
+		{name: "always/present", policy: PullAlways(), local: localImage(now), expected: true},
+		{name: "if-missing/missing", policy: PullIfMissing(), expected: true},
+		{name: "if-missing/present", policy: PullIfMissing(), local: localImage(now.Add(-24 * time.Hour))},
+		{name: "never/missing", policy: PullNever(), err: ErrImageNotPresent},
+		{name: "never/present", policy: PullNever(), local: localImage(now)},
+		{name: "max-age/missing", policy: PullMaxAge(time.Hour), expected: true},
+		{name: "max-age/recent", policy: PullMaxAge(time.Hour), local: localImage(now.Add(-time.Minute))},
+		{name: "max-age/old", policy: PullMaxAge(time.Hour), local: localImage(now.Add(-2 * time.Hour)), expected: true},
+		{
+			name:   "max-age/old-recently-tagged",
+			policy: PullMaxAge(time.Hour),
+			local: &types.ImageInspect{
+				Created:  now.Add(-48 * time.Hour).Format(time.RFC3339Nano),
+				Metadata: image.Metadata{LastTagTime: now.Add(-time.Minute)},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pull, err := tt.policy.ShouldPull("docker.io/alpine:latest", tt.local)
+			require.ErrorIs(t, err, tt.err)
+			require.Equal(t, tt.expected, pull)
+		})
+	}
+
+	t.Run("max-age/old-recently-pulled", func(t *testing.T) {
+		const img = "registry.example.com/recently-pulled:latest"
+
+		pulledImages.Store(img, now.Add(-time.Minute))
+		t.Cleanup(func() {
+			pulledImages.Delete(img)
+		})
+
+		pull, err := PullMaxAge(time.Hour).ShouldPull(img, localImage(now.Add(-48*time.Hour)))
+		require.NoError(t, err)
+		require.False(t, pull)
+	})
+}
+
+func TestParsePullPolicy(t *testing.T) {
+	local := &types.ImageInspect{Created: time.Now().Add(-2 * time.Hour).Format(time.RFC3339Nano)}
+
+	for name, expected := range map[string]bool{
+		"":             false,
+		"missing":      false,
+		"always":       true,
+		" always ":     true,
+		"max-age=1h":   true,
+		"max-age=24h":  false,
+		"max-age=1h0m": true,
+	} {
+		t.Run(name, func(t *testing.T) {
+			policy, err := ParsePullPolicy(name)
+			require.NoError(t, err)
+
+			pull, err := policy.ShouldPull("docker.io/alpine:latest", local)
+			require.NoError(t, err)
+			require.Equal(t, expected, pull)
+		})
+	}
+
+	t.Run("never", func(t *testing.T) {
+		policy, err := ParsePullPolicy("never")
+		require.NoError(t, err)
+
+		_, err = policy.ShouldPull("docker.io/alpine:latest", nil)
+		require.ErrorIs(t, err, ErrImageNotPresent)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		_, err := ParsePullPolicy("sometimes")
+		require.EqualError(t, err, `unknown pull policy "sometimes"`)
+
+		_, err = ParsePullPolicy("max-age=daily")
+		require.ErrorContains(t, err, `invalid max age "daily" of pull policy`)
+	})
+}
+
+func TestPullNever_imageNotPresent(t *testing.T) {
+	ctx := context.Background()
+
+	// withPullPolicy {
+	req := GenericContainerRequest{
+		ContainerRequest: ContainerRequest{
+			Image:      "docker.io/testcontainers/not-present:never-pulled",
+			PullPolicy: PullNever(),
+		},
+		Started: true,
+	}
+	// }
+
+	ctr, err := GenericContainer(ctx, req)
+	CleanupContainer(t, ctr)
+	require.ErrorIs(t, err, ErrImageNotPresent)
+}
@@ -0,0 +1,261 @@
+package testcontainers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/docker/docker/pkg/jsonmessage"
+	"github.com/docker/go-units"
+)
+
+// defaultPullProgressInterval is the interval of the summaries of the default pull progress listener.
+const defaultPullProgressInterval = 5 * time.Second
+
+// pullProgressStruct {
+
+// PullProgress represents an event of the progress of an image pull, as reported by the container runtime.
+// The byte counters of the image only include the layers whose size is already known.
+type PullProgress struct {
+	Image      string // reference of the pulled image
+	Layer      string // ID of the layer of the event, empty for the events of the whole image
+	Status     string // status of the layer, e.g. "Downloading", "Extracting" or "Pull complete"
+	Current    int64  // bytes of the layer processed in its current status
+	Total      int64  // bytes of the layer to process in its current status, 0 if unknown
+	Downloaded int64  // bytes downloaded over all the layers
+	Size       int64  // bytes to download over all the layers
+	Layers     int    // number of layers of the image known so far
+	Completed  int    // number of layers pulled, or which already existed
+	Attempt    int    // number of the pull attempt, starting at 1
+	Err        error  // error of a failed attempt, which is retried
+	Done       bool   // true for the last event of a successful pull
+}
+
+// }
+
+// pullProgressListenerInterface {
+
+// PullProgressListener represents any object that can
+// handle a PullProgress event, it is up to the PullProgressListener
+// instance what to do with it
+type PullProgressListener interface {
+	Accept(PullProgress)
+}
+
+// }
+
+// PullProgressListenerFunc is a shorthand to implement the PullProgressListener interface.
+type PullProgressListenerFunc func(PullProgress)
+
+// Accept implements PullProgressListener.
+func (f PullProgressListenerFunc) Accept(p PullProgress) {
+	f(p)
+}
+
+// WithPullProgressListener sets the listener of the progress of the image pulls.
+// It can be used for providers, receiving the progress of all the pulls of the provider,
+// and for containers, receiving the progress of the pull of the container image.
+func WithPullProgressListener(listener PullProgressListener) PullProgressListenerOption {
+	return PullProgressListenerOption{
+		listener: listener,
+	}
+}
+
+// PullProgressListenerOption is a generic option that sets the listener of the progress of the image pulls.
+//
+// It can be used to set the listener for providers and containers.
+type PullProgressListenerOption struct {
+	listener PullProgressListener
+}
+
+// ApplyGenericTo implements GenericProviderOption.
+func (o PullProgressListenerOption) ApplyGenericTo(opts *GenericProviderOptions) {
+	opts.PullProgressListener = o.listener
+}
+
+// ApplyDockerTo implements DockerProviderOption.
+func (o PullProgressListenerOption) ApplyDockerTo(opts *DockerProviderOptions) {
+	opts.PullProgressListener = o.listener
+}
+
+// Customize implements ContainerCustomizer.
+func (o PullProgressListenerOption) Customize(req *GenericContainerRequest) error {
+	req.PullProgressListener = o.listener
+	return nil
+}
+
+// NewLoggingPullProgressListener returns a listener logging a summary of the progress
+// of the pulls to the logger, at most once per interval. The pulls completing
+// within the interval are not logged, except for their failed attempts.
+//
+// It's the default listener of the providers, logging to their logger every 5 seconds.
+func NewLoggingPullProgressListener(logger Logging, interval time.Duration) PullProgressListener {
+	return &loggingPullProgressListener{
+		logger:   logger,
+		interval: interval,
+		pulls:    map[string]*loggedPull{},
+	}
+}
+
+// loggingPullProgressListener logs throttled summaries of the progress of the pulls.
+type loggingPullProgressListener struct {
+	logger   Logging
+	interval time.Duration
+
+	mtx   sync.Mutex
+	pulls map[string]*loggedPull // the pulls in progress, by image
+}
+
+// loggedPull is the state of a pull in progress of a loggingPullProgressListener.
+type loggedPull struct {
+	started time.Time
+	logged  time.Time
+}
+
+// Accept implements PullProgressListener.
+func (l *loggingPullProgressListener) Accept(p PullProgress) {
+	l.mtx.Lock()
+	defer l.mtx.Unlock()
+
+	now := time.Now()
+
+	pull, ok := l.pulls[p.Image]
+	if !ok {
+		pull = &loggedPull{started: now, logged: now}
+		l.pulls[p.Image] = pull
+	}
+
+	switch {
+	case p.Err != nil:
+		l.logger.Printf("⏳ Pulling image %s failed, will retry (attempt %d): %s", p.Image, p.Attempt, p.Err)
+	case p.Done:
+		delete(l.pulls, p.Image)
+
+		if elapsed := now.Sub(pull.started); elapsed >= l.interval {
+			l.logger.Printf("✅ Pulled image %s (%s) in %s", p.Image, units.HumanSize(float64(p.Size)), elapsed.Round(time.Second))
+		}
+	case now.Sub(pull.logged) >= l.interval:
+		pull.logged = now
+		l.logger.Printf("⏳ Pulling image %s: %d/%d layers, %s/%s downloaded",
+			p.Image, p.Completed, p.Layers, units.HumanSize(float64(p.Downloaded)), units.HumanSize(float64(p.Size)))
+	}
+}
+
+// pullTracker tracks the progress of the layers of an image pull, notifying a listener.
+type pullTracker struct {
+	image    string
+	listener PullProgressListener
+	attempt  int
+	layers   map[string]*pulledLayer
+}
+
+// pulledLayer is the progress of a layer of an image pull.
+type pulledLayer struct {
+	downloaded int64
+	size       int64
+	completed  bool
+}
+
+// newPullTracker returns a tracker of the progress of the pull of the image, notifying the listener.
+func newPullTracker(image string, listener PullProgressListener) *pullTracker {
+	return &pullTracker{
+		image:    image,
+		listener: listener,
+		attempt:  1,
+		layers:   map[string]*pulledLayer{},
+	}
+}
+
+// retry notifies the listener of a failed attempt, which is retried.
+func (t *pullTracker) retry(err error) {
+	t.notify(PullProgress{Err: err})
+	t.attempt++
+}
+
+// read reads the progress messages of the pull, notifying the listener of each of them,
+// until the pull is complete. It returns the error reported by the container runtime, if any.
+func (t *pullTracker) read(r io.Reader) error {
+	dec := json.NewDecoder(r)
+	for {
+		var msg jsonmessage.JSONMessage
+		if err := dec.Decode(&msg); err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return fmt.Errorf("read pull progress: %w", err)
+		}
+
+		if msg.Error != nil {
+			return fmt.Errorf("pull image: %w", msg.Error)
+		}
+
+		t.update(msg)
+	}
+
+	t.notify(PullProgress{Done: true})
+
+	return nil
+}
+
+// update updates the progress of the layer of the message, notifying the listener.
+func (t *pullTracker) update(msg jsonmessage.JSONMessage) {
+	p := PullProgress{Status: msg.Status}
+	if msg.Progress != nil {
+		p.Current = msg.Progress.Current
+		p.Total = msg.Progress.Total
+	}
+
+	// the messages of the whole image have no ID, except for the first one,
+	// which has the tag as ID, e.g. "Pulling from library/alpine".
+	if msg.ID == "" || strings.HasPrefix(msg.Status, "Pulling from") {
+		t.notify(p)
+		return
+	}
+
+	p.Layer = msg.ID
+
+	layer, ok := t.layers[msg.ID]
+	if !ok {
+		layer = &pulledLayer{}
+		t.layers[msg.ID] = layer
+	}
+
+	switch msg.Status {
+	case "Downloading":
+		layer.downloaded = p.Current
+		if p.Total > 0 {
+			layer.size = p.Total
+		}
+	case "Download complete", "Verifying Checksum":
+		layer.downloaded = layer.size
+	case "Pull complete", "Already exists":
+		layer.downloaded = layer.size
+		layer.completed = true
+	}
+
+	t.notify(p)
+}
+
+// notify notifies the listener of the event, with the progress of the whole image.
+func (t *pullTracker) notify(p PullProgress) {
+	if t.listener == nil {
+		return
+	}
+
+	p.Image = t.image
+	p.Attempt = t.attempt
+	p.Layers = len(t.layers)
+	for _, layer := range t.layers {
+		p.Downloaded += layer.downloaded
+		p.Size += layer.size
+		if layer.completed {
+			p.Completed++
+		}
+	}
+
+	t.listener.Accept(p)
+}
@@ -0,0 +1,94 @@
+package testcontainers
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPullTracker(t *testing.T) {
+	stream := `{"status":"Pulling from library/alpine","id":"3.20"}
+{"status":"Already exists","progressDetail":{},"id":"aaa"}
+{"status":"Pulling fs layer","progressDetail":{},"id":"bbb"}
+{"status":"Downloading","progressDetail":{"current":100,"total":400},"id":"bbb"}
+{"status":"Downloading","progressDetail":{"current":300,"total":400},"id":"bbb"}
+{"status":"Download complete","progressDetail":{},"id":"bbb"}
+{"status":"Extracting","progressDetail":{"current":400,"total":400},"id":"bbb"}
+{"status":"Pull complete","progressDetail":{},"id":"bbb"}
+{"status":"Digest: sha256:abc"}
+{"status":"Status: Downloaded newer image for alpine:3.20"}
+`
+
+	var events []PullProgress
+	tracker := newPullTracker("alpine:3.20", PullProgressListenerFunc(func(p PullProgress) {
+		events = append(events, p)
+	}))
+
+	tracker.retry(errors.New("connection reset"))
+	require.NoError(t, tracker.read(strings.NewReader(stream)))
+
+	require.Len(t, events, 12)
+
+	require.Equal(t, PullProgress{Image: "alpine:3.20", Attempt: 1, Err: errors.New("connection reset")}, events[0])
+	require.Equal(t, PullProgress{Image: "alpine:3.20", Attempt: 2, Status: "Pulling from library/alpine"}, events[1])
+	require.Equal(t, PullProgress{
+		Image: "alpine:3.20", Attempt: 2, Layer: "bbb", Status: "Downloading",
+		Current: 300, Total: 400, Downloaded: 300, Size: 400, Layers: 2, Completed: 1,
+	}, events[5])
+	require.Equal(t, PullProgress{
+		Image: "alpine:3.20", Attempt: 2, Layer: "bbb", Status: "Pull complete",
+		Downloaded: 400, Size: 400, Layers: 2, Completed: 2,
+	}, events[8])
+	require.Equal(t, PullProgress{
+		Image: "alpine:3.20", Attempt: 2, Downloaded: 400, Size: 400, Layers: 2, Completed: 2, Done: true,
+	}, events[11])
+
+	t.Run("error", func(t *testing.T) {
+		tracker := newPullTracker("private:latest", nil)
+
+		err := tracker.read(strings.NewReader(`{"errorDetail":{"message":"pull access denied"},"error":"pull access denied"}`))
+		require.ErrorContains(t, err, "pull access denied")
+	})
+}
+
+type testPullLogger struct {
+	lines []string
+}
+
+func (l *testPullLogger) Printf(format string, v ...any) {
+	l.lines = append(l.lines, fmt.Sprintf(format, v...))
+}
+
+func TestLoggingPullProgressListener(t *testing.T) {
+	t.Run("fast-pull", func(t *testing.T) {
+		logger := &testPullLogger{}
+		listener := NewLoggingPullProgressListener(logger, time.Hour)
+
+		listener.Accept(PullProgress{Image: "alpine", Attempt: 1, Layer: "aaa", Status: "Downloading"})
+		listener.Accept(PullProgress{Image: "alpine", Attempt: 1, Err: errors.New("timeout")})
+		listener.Accept(PullProgress{Image: "alpine", Attempt: 2, Done: true})
+
+		// only the failed attempt is logged
+		require.Equal(t, []string{"⏳ Pulling image alpine failed, will retry (attempt 1): timeout"}, logger.lines)
+	})
+
+	t.Run("slow-pull", func(t *testing.T) {
+		logger := &testPullLogger{}
+		listener := NewLoggingPullProgressListener(logger, 10*time.Millisecond)
+
+		listener.Accept(PullProgress{Image: "ollama", Attempt: 1, Layers: 2, Size: 2048})
+		time.Sleep(20 * time.Millisecond)
+		listener.Accept(PullProgress{Image: "ollama", Attempt: 1, Layers: 2, Completed: 1, Downloaded: 1024, Size: 2048})
+		// throttled
+		listener.Accept(PullProgress{Image: "ollama", Attempt: 1, Layers: 2, Completed: 1, Downloaded: 1536, Size: 2048})
+		listener.Accept(PullProgress{Image: "ollama", Attempt: 1, Layers: 2, Completed: 2, Downloaded: 2048, Size: 2048, Done: true})
+
+		require.Len(t, logger.lines, 2)
+		require.Equal(t, "⏳ Pulling image ollama: 1/2 layers, 1.024kB/2.048kB downloaded", logger.lines[0])
+		require.True(t, strings.HasPrefix(logger.lines[1], "✅ Pulled image ollama (2.048kB) in "), logger.lines[1])
+	})
+}
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"math/rand"
 	"net"
+	"net/url"
 	"strings"
 	"sync"
 	"time"
@@ -354,9 +355,11 @@ func (r *Reaper) Connect() (chan bool, error) {
 		sock := bufio.NewReadWriter(bufio.NewReader(conn), bufio.NewWriter(conn))
 		defer conn.Close()
 
+		// the filters are parsed as a query string, and the values of the labels,
+		// e.g. the project path, can contain any character.
 		labelFilters := []string{}
 		for l, v := range core.DefaultLabels(r.SessionID) {
-			labelFilters = append(labelFilters, fmt.Sprintf("label=%s=%s", l, v))
+			labelFilters = append(labelFilters, fmt.Sprintf("label=%s=%s", l, url.QueryEscape(v)))
 		}
 
 		retryLimit := 3
@@ -0,0 +1,193 @@
+package testcontainers
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"strings"
+
+	"github.com/distribution/reference"
+
+	"github.com/testcontainers/testcontainers-go/internal/config"
+)
+
+// RegistryMirrorRule is a rule of a RegistryMirrorSubstitutor, rewriting
+// the images matching its pattern to its mirror.
+type RegistryMirrorRule struct {
+	// Pattern matches the registry of the images, optionally followed by the first
+	// segments of their path, where each segment can contain * wildcards,
+	// e.g. "quay.io", "*.gcr.io" or "docker.io/bitnami". The images of the Docker Hub
+	// match the "docker.io" registry, and their official images the "docker.io/library" path.
+	Pattern string
+
+	// Mirror is the registry, optionally followed by a path, replacing the part of the
+	// image matched by the pattern, e.g. "mirror.example.com/quay".
+	Mirror string
+}
+
+// RegistryMirrorSubstitutor is an ImageSubstitutor rewriting the images of any registry
+// to mirrors, using the first of its rules matching the image.
+type RegistryMirrorSubstitutor struct {
+	rules []RegistryMirrorRule
+}
+
+// NewRegistryMirrorSubstitutor returns a substitutor rewriting the images with the given rules,
+// which are evaluated in order.
+func NewRegistryMirrorSubstitutor(rules ...RegistryMirrorRule) RegistryMirrorSubstitutor {
+	return RegistryMirrorSubstitutor{
+		rules: rules,
+	}
+}
+
+// Description returns the name of the type and a short description of how it modifies the image.
+func (s RegistryMirrorSubstitutor) Description() string {
+	return fmt.Sprintf("RegistryMirrorSubstitutor (%d rules)", len(s.rules))
+}
+
+// Substitute rewrites the image with the first rule matching it, replacing the registry and
+// the path segments matched by the pattern of the rule with its mirror. The image is returned
+// as is if no rule matches it, or if it's not a valid image reference.
+func (s RegistryMirrorSubstitutor) Substitute(image string) (string, error) {
+	named, err := reference.ParseNormalizedNamed(image)
+	if err != nil {
+		return image, nil
+	}
+
+	// the segments of the image, without its tag or digest, and the tag or digest itself
+	name := named.Name()
+	segments := strings.Split(name, "/")
+	suffix := strings.TrimPrefix(named.String(), name)
+
+	for _, rule := range s.rules {
+		n, err := rule.match(segments)
+		if err != nil {
+			return "", err
+		}
+
+		if n == 0 {
+			continue
+		}
+
+		rest := strings.Join(segments[n:], "/")
+		if rest == "" {
+			return strings.TrimSuffix(rule.Mirror, "/") + suffix, nil
+		}
+
+		return strings.TrimSuffix(rule.Mirror, "/") + "/" + rest + suffix, nil
+	}
+
+	return image, nil
+}
+
+// match returns the number of segments of the image matched by the pattern of the rule,
+// which is 0 if the rule does not match the image.
+func (r RegistryMirrorRule) match(segments []string) (int, error) {
+	patterns := strings.Split(strings.Trim(r.Pattern, "/"), "/")
+	if len(patterns) > len(segments) {
+		return 0, nil
+	}
+
+	for i, pattern := range patterns {
+		ok, err := path.Match(pattern, segments[i])
+		if err != nil {
+			return 0, fmt.Errorf("invalid pattern %q of registry mirror: %w", r.Pattern, err)
+		}
+
+		if !ok {
+			return 0, nil
+		}
+	}
+
+	return len(patterns), nil
+}
+
+// ParseRegistryMirrorRules parses a comma-separated list of <pattern>=<mirror> registry mirror rules,
+// e.g. "quay.io=mirror.example.com/quay,*.gcr.io=mirror.example.com/gcr".
+func ParseRegistryMirrorRules(rules string) ([]RegistryMirrorRule, error) {
+	var parsed []RegistryMirrorRule
+	for _, rule := range strings.Split(rules, ",") {
+		rule = strings.TrimSpace(rule)
+		if rule == "" {
+			continue
+		}
+
+		r, err := parseRegistryMirrorRule(rule)
+		if err != nil {
+			return nil, err
+		}
+
+		parsed = append(parsed, r)
+	}
+
+	return parsed, nil
+}
+
+// ReadRegistryMirrorRules reads registry mirror rules, one <pattern>=<mirror> rule per line,
+// ignoring the empty lines and the comments starting with #.
+func ReadRegistryMirrorRules(r io.Reader) ([]RegistryMirrorRule, error) {
+	var parsed []RegistryMirrorRule
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		rule, err := parseRegistryMirrorRule(line)
+		if err != nil {
+			return nil, err
+		}
+
+		parsed = append(parsed, rule)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("read registry mirror rules: %w", err)
+	}
+
+	return parsed, nil
+}
+
+// parseRegistryMirrorRule parses a <pattern>=<mirror> registry mirror rule.
+func parseRegistryMirrorRule(rule string) (RegistryMirrorRule, error) {
+	pattern, mirror, ok := strings.Cut(rule, "=")
+	pattern, mirror = strings.TrimSpace(pattern), strings.TrimSpace(mirror)
+	if !ok || pattern == "" || mirror == "" {
+		return RegistryMirrorRule{}, fmt.Errorf("invalid registry mirror rule %q, expected <pattern>=<mirror>", rule)
+	}
+
+	if _, err := path.Match(pattern, ""); err != nil {
+		return RegistryMirrorRule{}, fmt.Errorf("invalid pattern of registry mirror rule %q: %w", rule, err)
+	}
+
+	return RegistryMirrorRule{Pattern: pattern, Mirror: mirror}, nil
+}
+
+// registryMirrorRules returns the registry mirror rules of the configuration: the rules
+// of the registry.mirrors property, followed by the rules of the registry.mirrors.file file.
+func registryMirrorRules(cfg config.Config) ([]RegistryMirrorRule, error) {
+	rules, err := ParseRegistryMirrorRules(cfg.RegistryMirrors)
+	if err != nil {
+		return nil, err
+	}
+
+	if cfg.RegistryMirrorsFile == "" {
+		return rules, nil
+	}
+
+	f, err := os.Open(cfg.RegistryMirrorsFile)
+	if err != nil {
+		return nil, fmt.Errorf("open registry mirrors file: %w", err)
+	}
+	defer f.Close()
+
+	fileRules, err := ReadRegistryMirrorRules(f)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", cfg.RegistryMirrorsFile, err)
+	}
+
+	return append(rules, fileRules...), nil
+}
@@ -0,0 +1,106 @@
+package testcontainers
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/testcontainers/testcontainers-go/internal/config"
+)
+
+func TestRegistryMirrorSubstitutor(t *testing.T) {
+	rules, err := ParseRegistryMirrorRules(strings.Join([]string{
+		"quay.io=mirror.example.com/quay",
+		"*.gcr.io=mirror.example.com/gcr",
+		"mcr.microsoft.com/mssql=mirror.example.com/mssql",
+		"docker.elastic.co=mirror.example.com/elastic/",
+		"docker.io/library=mirror.example.com/hub",
+		"docker.io/bitnami=mirror.example.com/bitnami",
+	}, ","))
+	require.NoError(t, err)
+
+	s := NewRegistryMirrorSubstitutor(rules...)
+
+	for image, expected := range map[string]string{
+		"quay.io/prometheus/prometheus:v2.53.0":                "mirror.example.com/quay/prometheus/prometheus:v2.53.0",
+		"eu.gcr.io/project/image:1.0":                          "mirror.example.com/gcr/project/image:1.0",
+		"mcr.microsoft.com/mssql/server:2022-latest":           "mirror.example.com/mssql/server:2022-latest",
+		"docker.elastic.co/elasticsearch/elasticsearch:8.15.0": "mirror.example.com/elastic/elasticsearch/elasticsearch:8.15.0",
+		"alpine:3.20":                     "mirror.example.com/hub/alpine:3.20",
+		"docker.io/library/alpine":        "mirror.example.com/hub/alpine",
+		"bitnami/redis:7.2":               "mirror.example.com/bitnami/redis:7.2",
+		"redis@sha256:" + testDigest:      "mirror.example.com/hub/redis@sha256:" + testDigest,
+		"gcr.io/project/image:1.0":        "gcr.io/project/image:1.0",
+		"mcr.microsoft.com/azure-sql:1":   "mcr.microsoft.com/azure-sql:1",
+		"testcontainers/ryuk:0.8.1":       "testcontainers/ryuk:0.8.1",
+		"registry.example.com/foo:latest": "registry.example.com/foo:latest",
+	} {
+		t.Run(image, func(t *testing.T) {
+			img, err := s.Substitute(image)
+			require.NoError(t, err)
+			require.Equal(t, expected, img)
+		})
+	}
+
+	t.Run("first-matching-rule", func(t *testing.T) {
+		s := NewRegistryMirrorSubstitutor(
+			RegistryMirrorRule{Pattern: "quay.io/keycloak", Mirror: "keycloak.example.com"},
+			RegistryMirrorRule{Pattern: "*", Mirror: "mirror.example.com"},
+		)
+
+		img, err := s.Substitute("quay.io/keycloak/keycloak:25.0")
+		require.NoError(t, err)
+		require.Equal(t, "keycloak.example.com/keycloak:25.0", img)
+
+		img, err = s.Substitute("quay.io/minio/minio:latest")
+		require.NoError(t, err)
+		require.Equal(t, "mirror.example.com/minio/minio:latest", img)
+	})
+}
+
+const testDigest = "0000000000000000000000000000000000000000000000000000000000000000"
+
+func TestParseRegistryMirrorRules(t *testing.T) {
+	rules, err := ParseRegistryMirrorRules(" quay.io = mirror.example.com/quay ,, *.gcr.io=mirror.example.com/gcr")
+	require.NoError(t, err)
+	require.Equal(t, []RegistryMirrorRule{
+		{Pattern: "quay.io", Mirror: "mirror.example.com/quay"},
+		{Pattern: "*.gcr.io", Mirror: "mirror.example.com/gcr"},
+	}, rules)
+
+	for _, rule := range []string{"quay.io", "=mirror.example.com", "quay.io=", "[quay.io=mirror.example.com"} {
+		t.Run(rule, func(t *testing.T) {
+			_, err := ParseRegistryMirrorRules(rule)
+			require.ErrorContains(t, err, "registry mirror rule")
+		})
+	}
+}
+
+func TestRegistryMirrorRules(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "mirrors")
+	err := os.WriteFile(file, []byte(`# internal mirrors
+quay.io=mirror.example.com/quay
+
+*.gcr.io=mirror.example.com/gcr
+`), 0o600)
+	require.NoError(t, err)
+
+	rules, err := registryMirrorRules(config.Config{
+		RegistryMirrors:     "docker.elastic.co=mirror.example.com/elastic",
+		RegistryMirrorsFile: file,
+	})
+	require.NoError(t, err)
+	require.Equal(t, []RegistryMirrorRule{
+		{Pattern: "docker.elastic.co", Mirror: "mirror.example.com/elastic"},
+		{Pattern: "quay.io", Mirror: "mirror.example.com/quay"},
+		{Pattern: "*.gcr.io", Mirror: "mirror.example.com/gcr"},
+	}, rules)
+
+	t.Run("missing-file", func(t *testing.T) {
+		_, err := registryMirrorRules(config.Config{RegistryMirrorsFile: filepath.Join(t.TempDir(), "missing")})
+		require.ErrorContains(t, err, "open registry mirrors file")
+	})
+}
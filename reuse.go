@@ -0,0 +1,206 @@
+package testcontainers
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/docker/docker/api/types"
+
+	"github.com/testcontainers/testcontainers-go/internal/core"
+)
+
+// ErrReuseRequestChanged is returned when a container is reused by its name,
+// but it was created from a different request, so reusing it would be stale.
+var ErrReuseRequestChanged = errors.New("reusable container was created from a different request")
+
+// reuseNamePrefix is the prefix of the names of the reusable containers requested without a name,
+// which are named after the hash of their request.
+const reuseNamePrefix = "testcontainers-reuse-"
+
+// prepareReuse prepares the request of a reusable container, returning the hash of the request.
+// The request is labeled with its hash, and named after it if it has no name, so the processes
+// reusing the same container, e.g. the packages run in parallel by go test, create it only once.
+func (c *ContainerRequest) prepareReuse() (string, error) {
+	hash, err := c.reuseHash()
+	if err != nil {
+		return "", fmt.Errorf("reuse hash: %w", err)
+	}
+
+	if c.Name == "" {
+		c.Name = reuseNamePrefix + hash[:12]
+	}
+
+	// copy the labels, so the ones of the caller are not modified
+	labels := make(map[string]string, len(c.Labels)+1)
+	for k, v := range c.Labels {
+		labels[k] = v
+	}
+	labels[core.LabelReuseHash] = hash
+	c.Labels = labels
+
+	return hash, nil
+}
+
+// checkReuseHash returns an error if the container with the given labels was created from a request
+// with a different hash. The containers without the hash label, e.g. created without the Reuse option,
+// are reused by their name.
+func checkReuseHash(name string, labels map[string]string, hash string) error {
+	if h, ok := labels[core.LabelReuseHash]; ok && h != hash {
+		return fmt.Errorf("%w: container %s, remove it to create it again", ErrReuseRequestChanged, name)
+	}
+
+	return nil
+}
+
+// reuseHash returns the hex-encoded SHA-256 hash of the parts of the request defining the container:
+// the image or its build, the entrypoint, the command, the environment, the exposed ports, the files,
+// the mounts, the networks, the labels, the user and the working directory. The modifiers and the hooks
+// cannot be hashed, so changing them does not change the hash.
+//
+// The readers of the files are read to hash their content, so they are replaced with readers of the
+// same content in a copy of the files of the request, to copy them into the container. The readers of
+// the caller are drained, so a request must not be created twice with the same readers.
+func (c *ContainerRequest) reuseHash() (string, error) {
+	h := sha256.New()
+
+	writeField := func(values ...string) {
+		for _, v := range values {
+			fmt.Fprintf(h, "%d:%s", len(v), v)
+		}
+	}
+
+	if c.ShouldBuildImage() {
+		buildHash, err := c.buildHash(types.ImageBuildOptions{
+			Dockerfile: c.GetDockerfile(),
+			BuildArgs:  c.GetBuildArgs(),
+			Target:     c.FromDockerfile.Target,
+		})
+		if err != nil {
+			return "", fmt.Errorf("build hash: %w", err)
+		}
+		writeField("build", buildHash)
+	} else {
+		writeField("image", c.Image, "platform", c.ImagePlatform)
+	}
+
+	writeField("entrypoint", strconv.Itoa(len(c.Entrypoint)))
+	writeField(c.Entrypoint...)
+	writeField("cmd", strconv.Itoa(len(c.Cmd)))
+	writeField(c.Cmd...)
+	writeField("user", c.User, "workdir", c.WorkingDir)
+
+	for _, k := range sortedKeys(c.Env) {
+		writeField("env", k, c.Env[k])
+	}
+
+	ports := slices.Clone(c.ExposedPorts)
+	sort.Strings(ports)
+	for _, p := range ports {
+		writeField("port", p)
+	}
+
+	for _, k := range sortedKeys(c.Labels) {
+		// the default labels, e.g. the session ID, change across the test sessions
+		if strings.HasPrefix(k, core.LabelBase+".") {
+			continue
+		}
+		writeField("label", k, c.Labels[k])
+	}
+
+	for _, m := range c.Mounts {
+		writeField("mount", strconv.Itoa(int(m.Source.Type())), m.Source.Source(), string(m.Target), strconv.FormatBool(m.ReadOnly))
+	}
+
+	for _, k := range sortedKeys(c.Tmpfs) {
+		writeField("tmpfs", k, c.Tmpfs[k])
+	}
+
+	for _, n := range c.Networks {
+		writeField("network", n)
+		writeField(c.NetworkAliases[n]...)
+	}
+
+	files := slices.Clone(c.Files)
+	for i, f := range c.Files {
+		writeField("file", f.ContainerFilePath, strconv.FormatInt(f.FileMode, 8))
+
+		if f.Reader != nil {
+			content, err := io.ReadAll(f.Reader)
+			if err != nil {
+				return "", fmt.Errorf("read file %s: %w", f.ContainerFilePath, err)
+			}
+
+			// the reader of the caller is drained, so it's replaced in the copy of the files,
+			// which does not share its array with the caller.
+			files[i].Reader = bytes.NewReader(content)
+			writeField(string(content))
+			continue
+		}
+
+		if err := hashHostPath(h, f.HostFilePath); err != nil {
+			return "", fmt.Errorf("read file %s: %w", f.HostFilePath, err)
+		}
+	}
+	c.Files = files
+
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// sortedKeys returns the keys of a map in lexical order, so they are hashed in a stable order.
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
+
+// hashHostPath writes the relative paths and the content of the files of a host path,
+// which is either a file or a directory, to the hash.
+func hashHostPath(w io.Writer, hostPath string) error {
+	return filepath.WalkDir(hostPath, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		rel, err := filepath.Rel(hostPath, p)
+		if err != nil {
+			return err
+		}
+
+		if d.IsDir() {
+			fmt.Fprintf(w, "%d:%s", len(rel), rel)
+			return nil
+		}
+
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+
+		// the size delimits the content of the file
+		fmt.Fprintf(w, "%d:%s%d:", len(rel), rel, info.Size())
+
+		f, err := os.Open(p)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+
+		_, err = io.Copy(w, f)
+		return err
+	})
+}
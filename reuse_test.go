@@ -0,0 +1,122 @@
+package testcontainers
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/testcontainers/testcontainers-go/internal/core"
+)
+
+func TestReuseHash(t *testing.T) {
+	hostDir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(hostDir, "init.sql"), []byte("CREATE TABLE t (id INT);"), 0o644))
+
+	newRequest := func() ContainerRequest {
+		return ContainerRequest{
+			Image:        "postgres:16",
+			Cmd:          []string{"postgres", "-c", "fsync=off"},
+			Env:          map[string]string{"POSTGRES_USER": "test", "POSTGRES_PASSWORD": "test"},
+			ExposedPorts: []string{"5432/tcp", "8080/tcp"},
+			Labels:       map[string]string{"app": "test"},
+			Files: []ContainerFile{
+				{HostFilePath: hostDir, ContainerFilePath: "/docker-entrypoint-initdb.d", FileMode: 0o755},
+				{Reader: strings.NewReader("listen_addresses = '*'"), ContainerFilePath: "/etc/postgresql.conf", FileMode: 0o644},
+			},
+			Mounts: Mounts(VolumeMount("data", "/var/lib/postgresql/data")),
+		}
+	}
+
+	hash := func(t *testing.T, req ContainerRequest) string {
+		t.Helper()
+
+		h, err := req.reuseHash()
+		require.NoError(t, err)
+		return h
+	}
+
+	base := hash(t, newRequest())
+
+	t.Run("same-request", func(t *testing.T) {
+		req := newRequest()
+		req.ExposedPorts = []string{"8080/tcp", "5432/tcp"}
+		req.Labels[core.LabelSessionID] = "another-session"
+		req.WaitingFor = nil
+
+		require.Equal(t, base, hash(t, req))
+	})
+
+	t.Run("changed-request", func(t *testing.T) {
+		changes := map[string]func(req *ContainerRequest){
+			"image":   func(req *ContainerRequest) { req.Image = "postgres:17" },
+			"cmd":     func(req *ContainerRequest) { req.Cmd = []string{"postgres"} },
+			"env":     func(req *ContainerRequest) { req.Env["POSTGRES_DB"] = "test" },
+			"ports":   func(req *ContainerRequest) { req.ExposedPorts = req.ExposedPorts[:1] },
+			"labels":  func(req *ContainerRequest) { req.Labels["app"] = "other" },
+			"mounts":  func(req *ContainerRequest) { req.Mounts = nil },
+			"reader":  func(req *ContainerRequest) { req.Files[1].Reader = strings.NewReader("port = 5433") },
+			"mode":    func(req *ContainerRequest) { req.Files[1].FileMode = 0o600 },
+			"network": func(req *ContainerRequest) { req.Networks = []string{"backend"} },
+		}
+
+		for name, change := range changes {
+			t.Run(name, func(t *testing.T) {
+				req := newRequest()
+				change(&req)
+
+				require.NotEqual(t, base, hash(t, req))
+			})
+		}
+	})
+
+	t.Run("changed-host-file", func(t *testing.T) {
+		t.Cleanup(func() {
+			require.NoError(t, os.Remove(filepath.Join(hostDir, "seed.sql")))
+		})
+		require.NoError(t, os.WriteFile(filepath.Join(hostDir, "seed.sql"), []byte("INSERT INTO t VALUES (1);"), 0o644))
+
+		require.NotEqual(t, base, hash(t, newRequest()))
+	})
+
+	t.Run("readers-are-replaced", func(t *testing.T) {
+		req := newRequest()
+		files := req.Files
+		reader := files[1].Reader
+
+		first, err := req.reuseHash()
+		require.NoError(t, err)
+		require.Equal(t, base, first)
+
+		// the drained reader is replaced in a copy of the files, so it can be copied into the container
+		content, err := io.ReadAll(req.Files[1].Reader)
+		require.NoError(t, err)
+		require.Equal(t, "listen_addresses = '*'", string(content))
+
+		// the files of the caller are not modified
+		require.Same(t, reader, files[1].Reader)
+	})
+}
+
+func TestPrepareReuse(t *testing.T) {
+	labels := map[string]string{"app": "test"}
+	req := ContainerRequest{Image: "redis:7", Labels: labels}
+
+	hash, err := req.prepareReuse()
+	require.NoError(t, err)
+	require.Equal(t, reuseNamePrefix+hash[:12], req.Name)
+	require.Equal(t, hash, req.Labels[core.LabelReuseHash])
+	require.NotContains(t, labels, core.LabelReuseHash)
+
+	named := ContainerRequest{Image: "redis:7", Name: "cache"}
+	_, err = named.prepareReuse()
+	require.NoError(t, err)
+	require.Equal(t, "cache", named.Name)
+
+	require.NoError(t, checkReuseHash("cache", map[string]string{}, hash))
+	require.NoError(t, checkReuseHash("cache", map[string]string{core.LabelReuseHash: hash}, hash))
+	require.ErrorIs(t, checkReuseHash("cache", map[string]string{core.LabelReuseHash: "other"}, hash), ErrReuseRequestChanged)
+}
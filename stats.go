@@ -0,0 +1,264 @@
+package testcontainers
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/go-units"
+)
+
+// statsStruct {
+
+// Stats represents a sample of the resource usage of a container, as reported by the container runtime.
+// Block IO and network counters are cumulative since the container was started.
+type Stats struct {
+	ContainerID   string    // ID of the container
+	Read          time.Time // time when the sample was read
+	CPUPercent    float64   // CPU usage, where 100% is a whole CPU core
+	MemoryUsage   uint64    // memory usage in bytes, excluding the page cache
+	MemoryLimit   uint64    // memory limit in bytes
+	MemoryPercent float64   // memory usage relative to the memory limit
+	BlockRead     uint64    // bytes read from block devices
+	BlockWrite    uint64    // bytes written to block devices
+	NetworkRx     uint64    // bytes received over all the networks
+	NetworkTx     uint64    // bytes sent over all the networks
+	PIDs          uint64    // number of processes or threads
+}
+
+// }
+
+// statsConsumerInterface {
+
+// StatsConsumer represents any object that can
+// handle a Stats sample, it is up to the StatsConsumer instance
+// what to do with it
+type StatsConsumer interface {
+	Accept(Stats)
+}
+
+// }
+
+// StatsReporter is an optional interface of a [StatsConsumer], which is
+// notified when a container that was sending it stats is terminated.
+type StatsReporter interface {
+	Report(containerID string)
+}
+
+// StatsConsumerConfig is a configuration object for the producer/consumer pattern of the stats
+type StatsConsumerConfig struct {
+	Consumers []StatsConsumer // consumers for the stats
+}
+
+// newStats converts the stats returned by the Docker daemon, computing the usage
+// the same way the Docker CLI does.
+func newStats(containerID string, s container.StatsResponse) Stats {
+	stats := Stats{
+		ContainerID: containerID,
+		Read:        s.Read,
+		MemoryUsage: memoryUsageNoCache(s.MemoryStats),
+		MemoryLimit: s.MemoryStats.Limit,
+		PIDs:        s.PidsStats.Current,
+	}
+
+	// the first sample has no previous sample to compare with
+	if !s.PreRead.IsZero() {
+		stats.CPUPercent = cpuPercent(s.CPUStats, s.PreCPUStats)
+	}
+
+	if stats.MemoryLimit > 0 {
+		stats.MemoryPercent = float64(stats.MemoryUsage) / float64(stats.MemoryLimit) * 100
+	}
+
+	for _, entry := range s.BlkioStats.IoServiceBytesRecursive {
+		switch strings.ToLower(entry.Op) {
+		case "read":
+			stats.BlockRead += entry.Value
+		case "write":
+			stats.BlockWrite += entry.Value
+		}
+	}
+
+	for _, nw := range s.Networks {
+		stats.NetworkRx += nw.RxBytes
+		stats.NetworkTx += nw.TxBytes
+	}
+
+	return stats
+}
+
+// cpuPercent returns the CPU usage between two samples, where 100% is a whole CPU core.
+func cpuPercent(current container.CPUStats, previous container.CPUStats) float64 {
+	cpuDelta := float64(current.CPUUsage.TotalUsage) - float64(previous.CPUUsage.TotalUsage)
+	systemDelta := float64(current.SystemUsage) - float64(previous.SystemUsage)
+	if cpuDelta <= 0 || systemDelta <= 0 {
+		return 0
+	}
+
+	onlineCPUs := float64(current.OnlineCPUs)
+	if onlineCPUs == 0 {
+		onlineCPUs = float64(len(current.CPUUsage.PercpuUsage))
+	}
+
+	return cpuDelta / systemDelta * onlineCPUs * 100
+}
+
+// memoryUsageNoCache returns the memory usage without the page cache,
+// for both cgroup v1 and cgroup v2.
+func memoryUsageNoCache(mem container.MemoryStats) uint64 {
+	// cgroup v1
+	if v, ok := mem.Stats["total_inactive_file"]; ok && v < mem.Usage {
+		return mem.Usage - v
+	}
+
+	// cgroup v2
+	if v, ok := mem.Stats["inactive_file"]; ok && v < mem.Usage {
+		return mem.Usage - v
+	}
+
+	return mem.Usage
+}
+
+// StatsSummary is the peak and average resource usage of a container,
+// as computed by a [StatsAggregator].
+type StatsSummary struct {
+	Samples            int     // number of samples received
+	PeakCPUPercent     float64 // maximum CPU usage
+	AverageCPUPercent  float64 // average CPU usage
+	PeakMemoryUsage    uint64  // maximum memory usage in bytes
+	AverageMemoryUsage uint64  // average memory usage in bytes
+	MemoryLimit        uint64  // memory limit in bytes
+	BlockRead          uint64  // total bytes read from block devices
+	BlockWrite         uint64  // total bytes written to block devices
+	NetworkRx          uint64  // total bytes received over all the networks
+	NetworkTx          uint64  // total bytes sent over all the networks
+	PeakPIDs           uint64  // maximum number of processes or threads
+}
+
+// String returns a human-readable representation of the summary.
+func (s StatsSummary) String() string {
+	return fmt.Sprintf(
+		"CPU peak %.2f%% avg %.2f%%, memory peak %s avg %s limit %s, block IO %s read %s written, network %s received %s sent, PIDs peak %d (%d samples)",
+		s.PeakCPUPercent, s.AverageCPUPercent,
+		units.BytesSize(float64(s.PeakMemoryUsage)), units.BytesSize(float64(s.AverageMemoryUsage)), units.BytesSize(float64(s.MemoryLimit)),
+		units.BytesSize(float64(s.BlockRead)), units.BytesSize(float64(s.BlockWrite)),
+		units.BytesSize(float64(s.NetworkRx)), units.BytesSize(float64(s.NetworkTx)),
+		s.PeakPIDs, s.Samples,
+	)
+}
+
+// Implement interfaces
+var (
+	_ StatsConsumer = (*StatsAggregator)(nil)
+	_ StatsReporter = (*StatsAggregator)(nil)
+)
+
+// StatsAggregator is a [StatsConsumer] that computes the peak and average resource usage
+// of each container, logging the summary of a container when it's terminated.
+// It's safe to share the same aggregator between several containers.
+type StatsAggregator struct {
+	logger Logging
+
+	mtx       sync.Mutex
+	summaries map[string]*statsAccumulator
+}
+
+// statsAccumulator holds the running totals of the stats of a container.
+type statsAccumulator struct {
+	summary     StatsSummary
+	cpuTotal    float64
+	memoryTotal float64
+}
+
+// NewStatsAggregator returns a stats aggregator that logs the summaries with the given logger.
+// If the logger is nil, the default Testcontainers logger is used.
+func NewStatsAggregator(logger Logging) *StatsAggregator {
+	if logger == nil {
+		logger = Logger
+	}
+
+	return &StatsAggregator{
+		logger:    logger,
+		summaries: map[string]*statsAccumulator{},
+	}
+}
+
+// Accept adds a sample to the summary of its container.
+func (a *StatsAggregator) Accept(s Stats) {
+	a.mtx.Lock()
+	defer a.mtx.Unlock()
+
+	acc, ok := a.summaries[s.ContainerID]
+	if !ok {
+		acc = &statsAccumulator{}
+		a.summaries[s.ContainerID] = acc
+	}
+
+	acc.summary.Samples++
+	acc.cpuTotal += s.CPUPercent
+	acc.memoryTotal += float64(s.MemoryUsage)
+
+	acc.summary.PeakCPUPercent = max(acc.summary.PeakCPUPercent, s.CPUPercent)
+	acc.summary.AverageCPUPercent = acc.cpuTotal / float64(acc.summary.Samples)
+	acc.summary.PeakMemoryUsage = max(acc.summary.PeakMemoryUsage, s.MemoryUsage)
+	acc.summary.AverageMemoryUsage = uint64(acc.memoryTotal / float64(acc.summary.Samples))
+	acc.summary.MemoryLimit = s.MemoryLimit
+	acc.summary.PeakPIDs = max(acc.summary.PeakPIDs, s.PIDs)
+
+	// the counters are cumulative, so the highest value is kept, as they are reset when the container is restarted
+	acc.summary.BlockRead = max(acc.summary.BlockRead, s.BlockRead)
+	acc.summary.BlockWrite = max(acc.summary.BlockWrite, s.BlockWrite)
+	acc.summary.NetworkRx = max(acc.summary.NetworkRx, s.NetworkRx)
+	acc.summary.NetworkTx = max(acc.summary.NetworkTx, s.NetworkTx)
+}
+
+// Summary returns the summary of the container with the given ID,
+// and false if no stats have been received for it.
+func (a *StatsAggregator) Summary(containerID string) (StatsSummary, bool) {
+	a.mtx.Lock()
+	defer a.mtx.Unlock()
+
+	acc, ok := a.summaries[containerID]
+	if !ok {
+		return StatsSummary{}, false
+	}
+
+	return acc.summary, true
+}
+
+// Summaries returns the summaries of all the containers, by container ID.
+func (a *StatsAggregator) Summaries() map[string]StatsSummary {
+	a.mtx.Lock()
+	defer a.mtx.Unlock()
+
+	summaries := make(map[string]StatsSummary, len(a.summaries))
+	for id, acc := range a.summaries {
+		summaries[id] = acc.summary
+	}
+
+	return summaries
+}
+
+// Report logs the summary of the container with the given ID.
+func (a *StatsAggregator) Report(containerID string) {
+	summary, ok := a.Summary(containerID)
+	if !ok {
+		return
+	}
+
+	a.logger.Printf("📊 Resource usage of container %s: %s", shortContainerID(containerID), summary)
+}
+
+// statsReporters returns the consumers implementing [StatsReporter], keeping their order.
+func statsReporters(consumers []StatsConsumer) []StatsReporter {
+	reporters := make([]StatsReporter, 0, len(consumers))
+	for _, c := range consumers {
+		if r, ok := c.(StatsReporter); ok {
+			reporters = append(reporters, r)
+		}
+	}
+
+	return reporters
+}
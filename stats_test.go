@@ -0,0 +1,174 @@
+package testcontainers
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/stretchr/testify/require"
+)
+
+type testStatsConsumer struct {
+	mtx     sync.Mutex
+	samples []Stats
+}
+
+func (c *testStatsConsumer) Accept(s Stats) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	c.samples = append(c.samples, s)
+}
+
+func (c *testStatsConsumer) Samples() []Stats {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	return append([]Stats{}, c.samples...)
+}
+
+type testStatsLogger struct {
+	mtx  sync.Mutex
+	msgs []string
+}
+
+func (l *testStatsLogger) Printf(format string, v ...interface{}) {
+	l.mtx.Lock()
+	defer l.mtx.Unlock()
+
+	l.msgs = append(l.msgs, format)
+}
+
+func TestNewStats(t *testing.T) {
+	now := time.Now()
+
+	s := container.StatsResponse{
+		Stats: container.Stats{
+			Read:    now,
+			PreRead: now.Add(-time.Second),
+			CPUStats: container.CPUStats{
+				CPUUsage:    container.CPUUsage{TotalUsage: 1_500_000_000},
+				SystemUsage: 20_000_000_000,
+				OnlineCPUs:  4,
+			},
+			PreCPUStats: container.CPUStats{
+				CPUUsage:    container.CPUUsage{TotalUsage: 1_000_000_000},
+				SystemUsage: 16_000_000_000,
+			},
+			MemoryStats: container.MemoryStats{
+				Usage: 300 << 20,
+				Limit: 1 << 30,
+				Stats: map[string]uint64{"inactive_file": 44 << 20},
+			},
+			BlkioStats: container.BlkioStats{
+				IoServiceBytesRecursive: []container.BlkioStatEntry{
+					{Op: "read", Value: 100},
+					{Op: "Write", Value: 200},
+					{Op: "Total", Value: 300},
+				},
+			},
+			PidsStats: container.PidsStats{Current: 7},
+		},
+		Networks: map[string]container.NetworkStats{
+			"eth0": {RxBytes: 10, TxBytes: 20},
+			"eth1": {RxBytes: 1, TxBytes: 2},
+		},
+	}
+
+	stats := newStats("abc", s)
+	require.Equal(t, "abc", stats.ContainerID)
+	require.Equal(t, now, stats.Read)
+	require.InDelta(t, 50.0, stats.CPUPercent, 0.001)
+	require.Equal(t, uint64(256<<20), stats.MemoryUsage)
+	require.Equal(t, uint64(1<<30), stats.MemoryLimit)
+	require.InDelta(t, 25.0, stats.MemoryPercent, 0.001)
+	require.Equal(t, uint64(100), stats.BlockRead)
+	require.Equal(t, uint64(200), stats.BlockWrite)
+	require.Equal(t, uint64(11), stats.NetworkRx)
+	require.Equal(t, uint64(22), stats.NetworkTx)
+	require.Equal(t, uint64(7), stats.PIDs)
+
+	t.Run("first-sample", func(t *testing.T) {
+		s.PreRead = time.Time{}
+		require.Zero(t, newStats("abc", s).CPUPercent)
+	})
+}
+
+func TestStatsAggregator(t *testing.T) {
+	logger := &testStatsLogger{}
+	a := NewStatsAggregator(logger)
+
+	a.Accept(Stats{ContainerID: "a", CPUPercent: 10, MemoryUsage: 100, MemoryLimit: 1000, NetworkRx: 5, PIDs: 2})
+	a.Accept(Stats{ContainerID: "a", CPUPercent: 30, MemoryUsage: 300, MemoryLimit: 1000, NetworkRx: 8, PIDs: 4})
+	a.Accept(Stats{ContainerID: "b", CPUPercent: 5, MemoryUsage: 50})
+
+	summary, ok := a.Summary("a")
+	require.True(t, ok)
+	require.Equal(t, StatsSummary{
+		Samples:            2,
+		PeakCPUPercent:     30,
+		AverageCPUPercent:  20,
+		PeakMemoryUsage:    300,
+		AverageMemoryUsage: 200,
+		MemoryLimit:        1000,
+		NetworkRx:          8,
+		PeakPIDs:           4,
+	}, summary)
+
+	require.Len(t, a.Summaries(), 2)
+
+	_, ok = a.Summary("unknown")
+	require.False(t, ok)
+
+	a.Report("a")
+	a.Report("unknown")
+	require.Len(t, logger.msgs, 1)
+}
+
+func TestStatsConsumer(t *testing.T) {
+	ctx := context.Background()
+
+	consumer := &testStatsConsumer{}
+
+	// statsConsumersAtRequest {
+	aggregator := NewStatsAggregator(nil)
+
+	req := GenericContainerRequest{
+		ContainerRequest: ContainerRequest{
+			Image:        nginxAlpineImage,
+			ExposedPorts: []string{nginxDefaultPort},
+			StatsConsumerCfg: &StatsConsumerConfig{
+				Consumers: []StatsConsumer{consumer, aggregator},
+			},
+		},
+		ProviderType: providerType,
+		Started:      true,
+	}
+	// }
+
+	ctr, err := GenericContainer(ctx, req)
+	CleanupContainer(t, ctr)
+	require.NoError(t, err)
+
+	require.Eventually(t, func() bool {
+		return len(consumer.Samples()) >= 2
+	}, 10*time.Second, 100*time.Millisecond)
+
+	samples := consumer.Samples()
+	require.Equal(t, ctr.GetContainerID(), samples[0].ContainerID)
+	require.NotZero(t, samples[1].MemoryUsage)
+
+	require.NoError(t, ctr.Stop(ctx, nil))
+
+	// no more stats are produced once the container is stopped
+	count := len(consumer.Samples())
+	time.Sleep(2 * time.Second)
+	require.Len(t, consumer.Samples(), count)
+
+	summary, ok := aggregator.Summary(ctr.GetContainerID())
+	require.True(t, ok)
+	require.Equal(t, count, summary.Samples)
+	require.NotZero(t, summary.PeakMemoryUsage)
+}
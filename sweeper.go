@@ -0,0 +1,288 @@
+package testcontainers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/filters"
+	"github.com/docker/docker/api/types/image"
+	"github.com/docker/docker/api/types/network"
+	"github.com/docker/docker/api/types/volume"
+	"github.com/docker/docker/client"
+
+	"github.com/testcontainers/testcontainers-go/internal/core"
+)
+
+// sweepTimeout is the timeout of the removal of the resources when the process is interrupted.
+const sweepTimeout = 30 * time.Second
+
+// sweptKind is the kind of a resource tracked by the sweeper.
+// The resources are removed in the order of their kinds.
+type sweptKind int
+
+const (
+	sweptContainer sweptKind = iota
+	sweptNetwork
+	sweptVolume
+	sweptImage
+)
+
+// sweptResource is a resource tracked by the sweeper, identified by its kind and its ID,
+// or its name for the volumes and the built images.
+type sweptResource struct {
+	kind sweptKind
+	id   string
+}
+
+// sweeper removes the resources created by the process when Ryuk is disabled, when the process
+// is interrupted or when SweepResources is called. When it starts, it also removes the resources
+// of the sessions whose owning process no longer exists.
+type sweeper struct {
+	mtx       sync.Mutex
+	resources map[sweptResource]client.APIClient // the client of the provider which created each resource
+	logger    Logging
+	startOnce sync.Once
+}
+
+// processSweeper is the sweeper of the resources created by the process.
+var processSweeper = &sweeper{
+	resources: map[sweptResource]client.APIClient{},
+}
+
+// SweepResources removes the containers, networks, volumes and images created by the process
+// and not removed yet, when the sweeper is enabled, that is, when Ryuk is disabled and the
+// sweeper.enabled property is true. As Go does not allow running code when the process exits,
+// it's meant to be called at the end of TestMain:
+//
+//	func TestMain(m *testing.M) {
+//		code := m.Run()
+//		if err := testcontainers.SweepResources(context.Background()); err != nil {
+//			log.Printf("sweep resources: %s", err)
+//		}
+//		os.Exit(code)
+//	}
+//
+// The resources are also removed when the process receives an interrupt or a termination signal.
+func SweepResources(ctx context.Context) error {
+	return processSweeper.sweep(ctx)
+}
+
+// sweeperEnabled returns true if the resources created by the provider are tracked by the sweeper.
+func (p *DockerProvider) sweeperEnabled() bool {
+	return p.config.RyukDisabled && p.config.SweeperEnabled
+}
+
+// track tracks a resource created by the provider with the sweeper, if it's enabled,
+// starting the sweeper with the first tracked resource.
+func (p *DockerProvider) track(ctx context.Context, kind sweptKind, id string) {
+	if !p.sweeperEnabled() {
+		return
+	}
+
+	processSweeper.start(ctx, p.client, p.Logger)
+	processSweeper.track(kind, id, p.client)
+}
+
+// untrack stops tracking a removed resource.
+func (p *DockerProvider) untrack(kind sweptKind, id string) {
+	processSweeper.untrack(kind, id)
+}
+
+// start removes the resources of the orphan sessions, and removes the resources of the process
+// when it's interrupted. It only runs once.
+func (s *sweeper) start(ctx context.Context, cli client.APIClient, logger Logging) {
+	s.startOnce.Do(func() {
+		s.mtx.Lock()
+		s.logger = logger
+		s.mtx.Unlock()
+
+		if err := sweepOrphanSessions(ctx, cli, logger); err != nil {
+			logger.Printf("🧹 Failed to remove the resources of the orphan sessions: %s", err)
+		}
+
+		signals := make(chan os.Signal, 1)
+		signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+
+		go func() {
+			sig := <-signals
+			signal.Stop(signals)
+
+			ctx, cancel := context.WithTimeout(context.Background(), sweepTimeout)
+			if err := s.sweep(ctx); err != nil {
+				logger.Printf("🧹 Failed to remove the resources of the process: %s", err)
+			}
+			cancel()
+
+			// raise the signal again, so the process terminates as it would have without the sweeper
+			if p, err := os.FindProcess(os.Getpid()); err == nil && p.Signal(sig) == nil {
+				return
+			}
+
+			os.Exit(1)
+		}()
+	})
+}
+
+// track tracks a resource created with the client.
+func (s *sweeper) track(kind sweptKind, id string, cli client.APIClient) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	s.resources[sweptResource{kind: kind, id: id}] = cli
+}
+
+// untrack stops tracking a resource.
+func (s *sweeper) untrack(kind sweptKind, id string) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	delete(s.resources, sweptResource{kind: kind, id: id})
+}
+
+// sweep removes the tracked resources, containers first, as they use the rest of the resources.
+func (s *sweeper) sweep(ctx context.Context) error {
+	s.mtx.Lock()
+	resources := s.resources
+	s.resources = map[sweptResource]client.APIClient{}
+	logger := s.logger
+	s.mtx.Unlock()
+
+	if len(resources) == 0 {
+		return nil
+	}
+
+	var errs []error
+	for _, kind := range []sweptKind{sweptContainer, sweptNetwork, sweptVolume, sweptImage} {
+		for r, cli := range resources {
+			if r.kind != kind {
+				continue
+			}
+
+			if err := removeResource(ctx, cli, r); err != nil && !isCleanupSafe(err) {
+				errs = append(errs, err)
+			}
+		}
+	}
+
+	if logger != nil {
+		logger.Printf("🧹 Removed %d resources of the process", len(resources)-len(errs))
+	}
+
+	return errors.Join(errs...)
+}
+
+// removeResource removes a resource tracked by the sweeper.
+func removeResource(ctx context.Context, cli client.APIClient, r sweptResource) error {
+	var err error
+	switch r.kind {
+	case sweptContainer:
+		err = cli.ContainerRemove(ctx, r.id, container.RemoveOptions{RemoveVolumes: true, Force: true})
+	case sweptNetwork:
+		err = cli.NetworkRemove(ctx, r.id)
+	case sweptVolume:
+		err = cli.VolumeRemove(ctx, r.id, true)
+	case sweptImage:
+		_, err = cli.ImageRemove(ctx, r.id, image.RemoveOptions{Force: true, PruneChildren: true})
+	}
+
+	if err != nil {
+		return fmt.Errorf("remove %s: %w", r.id, err)
+	}
+
+	return nil
+}
+
+// sweepOrphanSessions removes the containers, networks, volumes and images of the sessions whose
+// owning process, identified by the core.LabelSessionOwner label, no longer exists.
+func sweepOrphanSessions(ctx context.Context, cli client.APIClient, logger Logging) error {
+	args := filters.NewArgs(
+		filters.Arg("label", core.LabelBase+"=true"),
+		filters.Arg("label", core.LabelLang+"=go"),
+		filters.Arg("label", core.LabelSessionOwner),
+	)
+
+	// the sessions checked so far, with true for the orphan ones
+	orphans := map[string]bool{}
+	isOrphan := func(labels map[string]string) bool {
+		sessionID := labels[core.LabelSessionID]
+		if sessionID == core.SessionID() {
+			return false
+		}
+
+		orphan, ok := orphans[sessionID]
+		if !ok {
+			orphan = core.IsOrphanSession(sessionID, labels[core.LabelSessionOwner])
+			orphans[sessionID] = orphan
+		}
+
+		return orphan
+	}
+
+	var orphanResources []sweptResource
+
+	containers, err := cli.ContainerList(ctx, container.ListOptions{All: true, Filters: args})
+	if err != nil {
+		return fmt.Errorf("list containers: %w", err)
+	}
+
+	for _, c := range containers {
+		if isOrphan(c.Labels) {
+			orphanResources = append(orphanResources, sweptResource{kind: sweptContainer, id: c.ID})
+		}
+	}
+
+	networks, err := cli.NetworkList(ctx, network.ListOptions{Filters: args})
+	if err != nil {
+		return fmt.Errorf("list networks: %w", err)
+	}
+
+	for _, n := range networks {
+		if isOrphan(n.Labels) {
+			orphanResources = append(orphanResources, sweptResource{kind: sweptNetwork, id: n.ID})
+		}
+	}
+
+	volumes, err := cli.VolumeList(ctx, volume.ListOptions{Filters: args})
+	if err != nil {
+		return fmt.Errorf("list volumes: %w", err)
+	}
+
+	for _, v := range volumes.Volumes {
+		if isOrphan(v.Labels) {
+			orphanResources = append(orphanResources, sweptResource{kind: sweptVolume, id: v.Name})
+		}
+	}
+
+	images, err := cli.ImageList(ctx, image.ListOptions{Filters: args})
+	if err != nil {
+		return fmt.Errorf("list images: %w", err)
+	}
+
+	for _, img := range images {
+		if isOrphan(img.Labels) {
+			orphanResources = append(orphanResources, sweptResource{kind: sweptImage, id: img.ID})
+		}
+	}
+
+	if len(orphanResources) == 0 {
+		return nil
+	}
+
+	var errs []error
+	for _, r := range orphanResources {
+		if err := removeResource(ctx, cli, r); err != nil && !isCleanupSafe(err) {
+			errs = append(errs, err)
+		}
+	}
+
+	logger.Printf("🧹 Removed %d resources of orphan sessions", len(orphanResources)-len(errs))
+
+	return errors.Join(errs...)
+}
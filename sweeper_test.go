@@ -0,0 +1,155 @@
+package testcontainers
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/image"
+	"github.com/docker/docker/api/types/network"
+	"github.com/docker/docker/api/types/volume"
+	"github.com/docker/docker/client"
+	"github.com/docker/docker/errdefs"
+	"github.com/stretchr/testify/require"
+
+	"github.com/testcontainers/testcontainers-go/internal/config"
+	"github.com/testcontainers/testcontainers-go/internal/core"
+)
+
+// sweeperMockCli is a mock implementation of client.APIClient, listing the given resources
+// and recording the removed ones.
+type sweeperMockCli struct {
+	client.APIClient
+
+	containers []types.Container
+	networks   []network.Summary
+	volumes    []*volume.Volume
+	images     []image.Summary
+
+	removed []string
+}
+
+func (m *sweeperMockCli) ContainerList(_ context.Context, _ container.ListOptions) ([]types.Container, error) {
+	return m.containers, nil
+}
+
+func (m *sweeperMockCli) NetworkList(_ context.Context, _ network.ListOptions) ([]network.Summary, error) {
+	return m.networks, nil
+}
+
+func (m *sweeperMockCli) VolumeList(_ context.Context, _ volume.ListOptions) (volume.ListResponse, error) {
+	return volume.ListResponse{Volumes: m.volumes}, nil
+}
+
+func (m *sweeperMockCli) ImageList(_ context.Context, _ image.ListOptions) ([]image.Summary, error) {
+	return m.images, nil
+}
+
+func (m *sweeperMockCli) ContainerRemove(_ context.Context, id string, _ container.RemoveOptions) error {
+	m.removed = append(m.removed, "container "+id)
+	return nil
+}
+
+func (m *sweeperMockCli) NetworkRemove(_ context.Context, id string) error {
+	m.removed = append(m.removed, "network "+id)
+	return nil
+}
+
+func (m *sweeperMockCli) VolumeRemove(_ context.Context, name string, _ bool) error {
+	m.removed = append(m.removed, "volume "+name)
+	if name == "gone" {
+		return errdefs.NotFound(os.ErrNotExist)
+	}
+	return nil
+}
+
+func (m *sweeperMockCli) ImageRemove(_ context.Context, id string, _ image.RemoveOptions) ([]image.DeleteResponse, error) {
+	m.removed = append(m.removed, "image "+id)
+	return nil, nil
+}
+
+func TestSweeper(t *testing.T) {
+	cli := &sweeperMockCli{}
+	s := &sweeper{resources: map[sweptResource]client.APIClient{}, logger: &testPullLogger{}}
+
+	s.track(sweptImage, "built:latest", cli)
+	s.track(sweptVolume, "data", cli)
+	s.track(sweptVolume, "gone", cli)
+	s.track(sweptNetwork, "net", cli)
+	s.track(sweptContainer, "ctr1", cli)
+	s.track(sweptContainer, "ctr2", cli)
+	s.untrack(sweptContainer, "ctr2")
+
+	require.NoError(t, s.sweep(context.Background()))
+	require.Equal(t, "container ctr1", cli.removed[0])
+	require.Equal(t, "network net", cli.removed[1])
+	require.ElementsMatch(t, []string{"volume data", "volume gone"}, cli.removed[2:4])
+	require.Equal(t, "image built:latest", cli.removed[4])
+	require.Len(t, cli.removed, 5)
+
+	// the swept resources are not tracked anymore
+	cli.removed = nil
+	require.NoError(t, s.sweep(context.Background()))
+	require.Empty(t, cli.removed)
+}
+
+func TestSweepOrphanSessions(t *testing.T) {
+	hostname, err := os.Hostname()
+	require.NoError(t, err)
+
+	labels := func(sessionID string, owner string) map[string]string {
+		labels := core.DefaultLabels(sessionID)
+		labels[core.LabelSessionOwner] = owner
+		return labels
+	}
+
+	// above the maximum pid of Linux, so the owner is gone
+	orphan := labels("orphan-session", hostname+":4194305")
+	remote := labels("remote-session", "another-host:1")
+	current := labels(core.SessionID(), hostname+":4194305")
+
+	cli := &sweeperMockCli{
+		containers: []types.Container{{ID: "orphan-ctr", Labels: orphan}, {ID: "remote-ctr", Labels: remote}, {ID: "current-ctr", Labels: current}},
+		networks:   []network.Summary{{ID: "orphan-net", Labels: orphan}, {ID: "remote-net", Labels: remote}},
+		volumes:    []*volume.Volume{{Name: "orphan-vol", Labels: orphan}, {Name: "current-vol", Labels: current}},
+		images:     []image.Summary{{ID: "orphan-img", Labels: orphan}, {ID: "remote-img", Labels: remote}},
+	}
+
+	logger := &testPullLogger{}
+	require.NoError(t, sweepOrphanSessions(context.Background(), cli, logger))
+	require.Equal(t, []string{"container orphan-ctr", "network orphan-net", "volume orphan-vol", "image orphan-img"}, cli.removed)
+	require.Equal(t, []string{"🧹 Removed 4 resources of orphan sessions"}, logger.lines)
+}
+
+func TestSweepResources(t *testing.T) {
+	config.Reset() // reset the config using the internal method to avoid the sync.Once
+	t.Cleanup(config.Reset)
+	t.Setenv("TESTCONTAINERS_RYUK_DISABLED", "true")
+	t.Setenv("TESTCONTAINERS_SWEEPER_ENABLED", "true")
+
+	ctx := context.Background()
+
+	provider, err := NewDockerProvider()
+	require.NoError(t, err)
+	defer provider.Close()
+
+	// sweepResources {
+	ctr, err := provider.CreateContainer(ctx, ContainerRequest{Image: nginxAlpineImage})
+	require.NoError(t, err)
+
+	nw, err := provider.CreateNetwork(ctx, NetworkRequest{Name: "sweeper-" + core.SessionID()[:12]})
+	require.NoError(t, err)
+
+	// removes the container and the network, as they were not removed yet
+	err = SweepResources(ctx)
+	require.NoError(t, err)
+	// }
+
+	_, err = provider.client.ContainerInspect(ctx, ctr.GetContainerID())
+	require.True(t, errdefs.IsNotFound(err), err)
+
+	_, err = provider.client.NetworkInspect(ctx, nw.(*DockerNetwork).ID, network.InspectOptions{})
+	require.True(t, errdefs.IsNotFound(err), err)
+}
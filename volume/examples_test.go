@@ -0,0 +1,33 @@
+package volume_test
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/testcontainers/testcontainers-go/volume"
+)
+
+func ExampleNew() {
+	// createVolume {
+	ctx := context.Background()
+
+	vol, err := volume.New(ctx)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	defer func() {
+		if err := vol.Remove(ctx); err != nil {
+			log.Printf("failed to remove volume: %s", err)
+		}
+	}()
+	// }
+
+	fmt.Println(vol.Name != "")
+	fmt.Println(vol.Driver)
+
+	// Output:
+	// true
+	// local
+}
@@ -0,0 +1,112 @@
+package volume
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/docker/docker/api/types/volume"
+	"github.com/google/uuid"
+
+	"github.com/testcontainers/testcontainers-go"
+)
+
+// New creates a new volume with a random UUID name.
+// By default, the volume is created with the following options:
+// - Driver: local
+// - Labels: the Testcontainers for Go generic labels, to be managed by Ryuk. Please see the GenericLabels() function
+// And those options can be modified by the user, using the VolumeCustomizer options.
+//
+// The volume can be pre-populated with [testcontainers.DockerVolume.CopyDirToVolume] or
+// [testcontainers.DockerVolume.CopyArchiveToVolume], and mounted in several containers with [WithVolume].
+func New(ctx context.Context, opts ...VolumeCustomizer) (*testcontainers.DockerVolume, error) {
+	vc := volume.CreateOptions{
+		Name:   uuid.NewString(),
+		Driver: "local",
+		Labels: testcontainers.GenericLabels(),
+	}
+
+	for _, opt := range opts {
+		if err := opt.Customize(&vc); err != nil {
+			return nil, err
+		}
+	}
+
+	provider, err := testcontainers.NewDockerProvider()
+	if err != nil {
+		return nil, fmt.Errorf("new docker provider: %w", err)
+	}
+
+	return provider.CreateVolume(ctx, vc)
+}
+
+// VolumeCustomizer is an interface that can be used to configure the volume create request.
+type VolumeCustomizer interface {
+	Customize(req *volume.CreateOptions) error
+}
+
+// CustomizeVolumeOption is a type that can be used to configure the volume create request.
+type CustomizeVolumeOption func(req *volume.CreateOptions) error
+
+// Customize implements the VolumeCustomizer interface,
+// applying the option to the volume create request.
+func (opt CustomizeVolumeOption) Customize(req *volume.CreateOptions) error {
+	return opt(req)
+}
+
+// WithDriver allows to override the default volume driver, which is "local".
+func WithDriver(driver string) CustomizeVolumeOption {
+	return func(original *volume.CreateOptions) error {
+		original.Driver = driver
+
+		return nil
+	}
+}
+
+// WithDriverOpts allows to set the options of the volume driver, adding the new ones
+// to the existing ones, e.g. the "type", "device" and "o" options of the local driver.
+func WithDriverOpts(driverOpts map[string]string) CustomizeVolumeOption {
+	return func(original *volume.CreateOptions) error {
+		if original.DriverOpts == nil {
+			original.DriverOpts = make(map[string]string, len(driverOpts))
+		}
+
+		for k, v := range driverOpts {
+			original.DriverOpts[k] = v
+		}
+
+		return nil
+	}
+}
+
+// WithLabels allows to set the volume labels, adding the new ones
+// to the default Testcontainers for Go labels.
+func WithLabels(labels map[string]string) CustomizeVolumeOption {
+	return func(original *volume.CreateOptions) error {
+		for k, v := range labels {
+			original.Labels[k] = v
+		}
+
+		return nil
+	}
+}
+
+// WithVolume mounts an already existing volume in the container, at the given path.
+func WithVolume(target string, v *testcontainers.DockerVolume) testcontainers.CustomizeRequestOption {
+	return func(req *testcontainers.GenericContainerRequest) error {
+		req.Mounts = append(req.Mounts, testcontainers.VolumeMount(v.Name, testcontainers.ContainerMountTarget(target)))
+
+		return nil
+	}
+}
+
+// WithReadOnlyVolume mounts an already existing volume in the container, at the given path,
+// in read-only mode, so several containers can safely share the same seeded data.
+func WithReadOnlyVolume(target string, v *testcontainers.DockerVolume) testcontainers.CustomizeRequestOption {
+	return func(req *testcontainers.GenericContainerRequest) error {
+		m := testcontainers.VolumeMount(v.Name, testcontainers.ContainerMountTarget(target))
+		m.ReadOnly = true
+		req.Mounts = append(req.Mounts, m)
+
+		return nil
+	}
+}
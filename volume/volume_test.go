@@ -0,0 +1,189 @@
+package volume_test
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/docker/docker/api/types/filters"
+	dockervolume "github.com/docker/docker/api/types/volume"
+	"github.com/stretchr/testify/require"
+
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/internal/core"
+	"github.com/testcontainers/testcontainers-go/volume"
+)
+
+func TestNew(t *testing.T) {
+	ctx := context.Background()
+
+	vol, err := volume.New(ctx,
+		volume.WithDriver("local"),
+		volume.WithLabels(map[string]string{"this-is-a-test": "value"}),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, vol.Remove(ctx))
+	})
+
+	cli, err := testcontainers.NewDockerClientWithOpts(ctx)
+	require.NoError(t, err)
+	defer cli.Close()
+
+	resp, err := cli.VolumeList(ctx, dockervolume.ListOptions{
+		Filters: filters.NewArgs(filters.Arg("name", vol.Name)),
+	})
+	require.NoError(t, err)
+	require.Len(t, resp.Volumes, 1)
+	require.Equal(t, "local", resp.Volumes[0].Driver)
+	require.Equal(t, "value", resp.Volumes[0].Labels["this-is-a-test"])
+	require.Equal(t, core.SessionID(), resp.Volumes[0].Labels[core.LabelSessionID])
+}
+
+func TestCopyDirToVolume(t *testing.T) {
+	ctx := context.Background()
+
+	// seedVolume {
+	vol, err := volume.New(ctx)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, vol.Remove(ctx))
+	})
+
+	err = vol.CopyDirToVolume(ctx, filepath.Join("testdata", "seed"))
+	require.NoError(t, err)
+	// }
+
+	for _, name := range []string{"first", "second"} {
+		// shareVolume {
+		req := testcontainers.GenericContainerRequest{
+			ContainerRequest: testcontainers.ContainerRequest{
+				Image: "docker.io/alpine:3.20",
+				Cmd:   []string{"cat", "/data/hello.txt", "/data/conf/app.properties"},
+			},
+			Started: true,
+		}
+		err := volume.WithReadOnlyVolume("/data", vol)(&req)
+		require.NoError(t, err)
+
+		ctr, err := testcontainers.GenericContainer(ctx, req)
+		testcontainers.CleanupContainer(t, ctr)
+		require.NoError(t, err, name)
+		// }
+
+		logs := waitForExit(t, ctr)
+		require.Equal(t, "hello\nport=8080\n", logs)
+	}
+
+	t.Run("export-dir", func(t *testing.T) {
+		dst := t.TempDir()
+
+		// exportVolume {
+		err := vol.ExportDir(ctx, dst)
+		// }
+		require.NoError(t, err)
+
+		bs, err := os.ReadFile(filepath.Join(dst, "conf", "app.properties"))
+		require.NoError(t, err)
+		require.Equal(t, "port=8080\n", string(bs))
+	})
+
+	t.Run("export-archive", func(t *testing.T) {
+		r, err := vol.ExportArchive(ctx)
+		require.NoError(t, err)
+		defer func() {
+			require.NoError(t, r.Close())
+		}()
+
+		names := map[string]string{}
+		tr := tar.NewReader(r)
+		for {
+			header, err := tr.Next()
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			require.NoError(t, err)
+
+			bs, err := io.ReadAll(tr)
+			require.NoError(t, err)
+			names[header.Name] = string(bs)
+		}
+
+		require.Equal(t, map[string]string{
+			"conf/":               "",
+			"conf/app.properties": "port=8080\n",
+			"hello.txt":           "hello\n",
+		}, names)
+	})
+}
+
+func TestCopyArchiveToVolume(t *testing.T) {
+	ctx := context.Background()
+
+	vol, err := volume.New(ctx)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, vol.Remove(ctx))
+	})
+
+	var buf bytes.Buffer
+	tw := tar.NewWriter(&buf)
+	require.NoError(t, tw.WriteHeader(&tar.Header{Name: "seed.sql", Mode: 0o644, Size: 9}))
+	_, err = tw.Write([]byte("SELECT 1;"))
+	require.NoError(t, err)
+	require.NoError(t, tw.Close())
+
+	require.NoError(t, vol.CopyArchiveToVolume(ctx, &buf))
+
+	dst := t.TempDir()
+	require.NoError(t, vol.ExportDir(ctx, dst))
+
+	bs, err := os.ReadFile(filepath.Join(dst, "seed.sql"))
+	require.NoError(t, err)
+	require.Equal(t, "SELECT 1;", string(bs))
+}
+
+func TestWithVolume(t *testing.T) {
+	vol := &testcontainers.DockerVolume{Name: "data"}
+
+	req := testcontainers.GenericContainerRequest{}
+	require.NoError(t, volume.WithVolume("/data", vol)(&req))
+	require.NoError(t, volume.WithReadOnlyVolume("/seed", vol)(&req))
+
+	require.Equal(t, testcontainers.ContainerMounts{
+		testcontainers.VolumeMount("data", "/data"),
+		{
+			Source:   testcontainers.GenericVolumeMountSource{Name: "data"},
+			Target:   "/seed",
+			ReadOnly: true,
+		},
+	}, req.Mounts)
+}
+
+// waitForExit waits for the container to exit, returning its logs.
+func waitForExit(t *testing.T, ctr testcontainers.Container) string {
+	t.Helper()
+
+	ctx := context.Background()
+
+	require.Eventually(t, func() bool {
+		state, err := ctr.State(ctx)
+		require.NoError(t, err)
+		return !state.Running
+	}, time.Minute, 100*time.Millisecond)
+
+	r, err := ctr.Logs(ctx)
+	require.NoError(t, err)
+	defer r.Close()
+
+	bs, err := io.ReadAll(r)
+	require.NoError(t, err)
+
+	return string(bs)
+}